@@ -0,0 +1,26 @@
+package bt
+
+import "testing"
+
+func TestSetAccessLogFormat(t *testing.T) {
+	conf := "server {\n    location ~ .*\\.(js|css)?$ {\n        access_log /dev/null;\n    }\n    access_log  /www/wwwlogs/a.com.log;\n    error_log  /www/wwwlogs/a.com.error.log;\n}\n"
+	got, err := setAccessLogFormat(conf, AccessLogJSON)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "server {\n    location ~ .*\\.(js|css)?$ {\n        access_log /dev/null;\n    }\n    access_log  /www/wwwlogs/a.com.log btsdk_json;\n    error_log  /www/wwwlogs/a.com.error.log;\n}\n"
+	if got != want {
+		t.Fatalf("got\n%s", got)
+	}
+	back, err := setAccessLogFormat(got, AccessLogDefault)
+	if err != nil || back != conf {
+		t.Fatalf("got\n%s, %v", back, err)
+	}
+	buffered, _ := setAccessLogFormat("access_log /a.log main buffer=32k;", AccessLogJSON)
+	if buffered != "access_log /a.log btsdk_json buffer=32k;" {
+		t.Fatalf("got %s", buffered)
+	}
+	if _, err := setAccessLogFormat("access_log off;", AccessLogJSON); err == nil {
+		t.Fatal("expected error")
+	}
+}
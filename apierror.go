@@ -0,0 +1,71 @@
+package bt
+
+import (
+	"net/http"
+	"reflect"
+)
+
+// maxErrorBody APIError 中保存的响应内容上限
+const maxErrorBody = 64 << 10
+
+// APIError 面板返回 HTTP 错误状态码或 {"status": false} 时的错误
+// 可通过 errors.As 取出后根据 HTTPStatus/Msg 判断失败原因 网络错误不会包装为 APIError
+type APIError struct {
+	HTTPStatus  int    // HTTP 状态码 面板返回 status 为 false 时为 200
+	PanelStatus bool   // 面板返回的 status HTTP 错误时为 false
+	Msg         string // 面板返回的 msg HTTP 错误时为状态行 eg. 502 Bad Gateway
+	Endpoint    string // eg. /site?action=AddSite
+	Body        []byte // 原始响应内容 超过 64KB 时截断
+}
+
+func (e *APIError) Error() string {
+	if e.Msg == "" {
+		return "panel returned status false"
+	}
+	return e.Msg
+}
+
+// newPanelError 面板返回 status 为 false 时的错误
+func newPanelError(endpoint string, msg string, body []byte) *APIError {
+	if len(body) > maxErrorBody {
+		body = body[:maxErrorBody]
+	}
+	return &APIError{
+		HTTPStatus: http.StatusOK,
+		Msg:        msg,
+		Endpoint:   endpoint,
+		Body:       body,
+	}
+}
+
+// DecodeError 面板响应无法解析为目标结构 通常是面板版本变化导致字段改名或类型不一致
+// 可通过 RegisterMigration 注册迁移兼容新旧响应
+type DecodeError struct {
+	Type string // 目标类型 eg. bt.RespSSLInfo
+	Body []byte // 原始响应内容 超过 64KB 时截断
+	Err  error
+}
+
+func (e *DecodeError) Error() string {
+	return "bt: decode " + e.Type + ": " + e.Err.Error()
+}
+
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
+
+// newDecodeError 解析到 v 失败时的错误
+func newDecodeError(v interface{}, body []byte, err error) *DecodeError {
+	if len(body) > maxErrorBody {
+		body = body[:maxErrorBody]
+	}
+	t := reflect.TypeOf(v)
+	if t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	name := "<nil>"
+	if t != nil {
+		name = t.String()
+	}
+	return &DecodeError{Type: name, Body: body, Err: err}
+}
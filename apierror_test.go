@@ -0,0 +1,64 @@
+package bt
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"testing"
+)
+
+func TestAPIError(t *testing.T) {
+	c := newFakePanel(t, map[string]http.HandlerFunc{
+		"/site?action=SiteStop": reply(`{"status":false,"msg":"指定站点不存在!"}`),
+		"/site?action=AddSite":  reply(`{"status":false,"msg":"您添加的站点已存在!"}`),
+		"/system?action=GetSystemTotal": func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, "upstream down", http.StatusBadGateway)
+		},
+		"/site?action=GetLimitNet": func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, "upstream down", http.StatusBadGateway)
+		},
+	})
+	c.Translator = EnglishMessages
+
+	_, err := c.StopSite(ctx, 1, "w1.hao.com")
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.HTTPStatus != 200 || apiErr.PanelStatus || apiErr.Msg != "The specified site does not exist" || apiErr.Endpoint != "/site?action=SiteStop" || len(apiErr.Body) == 0 {
+		t.Fatalf("StopSite error %#v", err)
+	}
+
+	ret, err := c.AddSite(ctx, &ReqAddSite{WebName: NewWebName("w1.hao.com"), Path: "/www/wwwroot/w1.hao.com"})
+	if !errors.As(err, &apiErr) || ret.SiteStatus || apiErr.Msg != "The site already exists" || apiErr.Endpoint != "/site?action=AddSite" {
+		t.Fatalf("AddSite = %+v, %#v", ret, err)
+	}
+
+	_, err = c.GetSystemTotal(ctx)
+	if !errors.As(err, &apiErr) || apiErr.HTTPStatus != http.StatusBadGateway || apiErr.Endpoint != "/system?action=GetSystemTotal" || string(apiErr.Body) != "upstream down\n" {
+		t.Fatalf("GetSystemTotal error %#v", err)
+	}
+	if err.Error() != "502 Bad Gateway" {
+		t.Errorf("error text %q", err.Error())
+	}
+	if _, err := c.GetLimitNet(ctx, 1); !errors.As(err, &apiErr) || apiErr.Endpoint != "/site?action=GetLimitNet" {
+		t.Fatalf("GetLimitNet error %#v", err)
+	}
+}
+
+func TestErrorKinds(t *testing.T) {
+	c := newFakePanel(t, map[string]http.HandlerFunc{
+		"/ajax?action=GetTaskCount":  reply(`"busy"`),
+		"/system?action=GetDiskInfo": reply(`{"path":"/"}`),
+	})
+	if _, err := c.GetTaskCount(ctx); !errors.As(err, new(*DecodeError)) {
+		t.Errorf("GetTaskCount error %#v", err)
+	}
+	var decErr *DecodeError
+	if _, err := c.GetDiskInfo(ctx); !errors.As(err, &decErr) || decErr.Type != "bt.DiskInfo" || string(decErr.Body) != `{"path":"/"}` {
+		t.Errorf("GetDiskInfo error %#v", err)
+	}
+	offline := NewClient("http://127.0.0.1:1", "test-key")
+	_, err := offline.GetTaskCount(ctx)
+	var urlErr *url.Error
+	if !errors.As(err, &urlErr) || errors.As(err, new(*APIError)) {
+		t.Errorf("offline GetTaskCount error %#v", err)
+	}
+}
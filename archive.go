@@ -0,0 +1,127 @@
+package bt
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// ArchiveType 压缩包格式
+type ArchiveType string
+
+const (
+	ArchiveZip   ArchiveType = "zip"
+	ArchiveTarGz ArchiveType = "tar_gz"
+)
+
+// archiveType 按扩展名判断压缩包格式 无法识别时返回空字符串
+func archiveType(name string) ArchiveType {
+	lower := strings.ToLower(name)
+	switch {
+	case strings.HasSuffix(lower, ".zip"):
+		return ArchiveZip
+	case strings.HasSuffix(lower, ".tar.gz"), strings.HasSuffix(lower, ".tgz"):
+		return ArchiveTarGz
+	}
+	return ""
+}
+
+// Zip 压缩文件或目录
+func (c *Client) Zip(ctx context.Context, params *ReqZip) (RespMSG, error) {
+	if params.Dir == "" || len(params.Names) == 0 || params.Archive == "" {
+		return RespMSG{}, errors.New("zip dir, names and archive are required")
+	}
+	typ := params.Type
+	if typ == "" {
+		typ = ArchiveZip
+	}
+	if typ != ArchiveZip && typ != ArchiveTarGz {
+		return RespMSG{}, errors.New("unsupported archive type: " + string(typ))
+	}
+	if params.Password != "" && typ != ArchiveZip {
+		return RespMSG{}, errors.New("password is only supported for zip archives")
+	}
+	data := map[string][]string{
+		"path":   {params.Dir},
+		"sfile":  {strings.Join(params.Names, ",")},
+		"dfile":  {params.Archive},
+		"z_type": {string(typ)},
+	}
+	if params.Password != "" {
+		data["password"] = []string{params.Password}
+	}
+	return c.btResult(ctx, data, "/files?action=Zip")
+}
+
+// UnZip 解压 zip 或 tar.gz 压缩包 目标目录中的同名文件会被覆盖
+func (c *Client) UnZip(ctx context.Context, params *ReqUnZip) (RespMSG, error) {
+	if params.Archive == "" || params.Dest == "" {
+		return RespMSG{}, errors.New("unzip archive and destination are required")
+	}
+	typ := params.Type
+	if typ == "" {
+		typ = archiveType(params.Archive)
+	}
+	// 解压时面板以 tar 表示 tar.gz
+	var panelType string
+	switch typ {
+	case ArchiveZip:
+		panelType = "zip"
+	case ArchiveTarGz:
+		panelType = "tar"
+	default:
+		return RespMSG{}, errors.New("unsupported archive: " + params.Archive)
+	}
+	if params.Password != "" && typ != ArchiveZip {
+		return RespMSG{}, errors.New("password is only supported for zip archives")
+	}
+	coding := params.Coding
+	if coding == "" {
+		coding = "UTF-8"
+	}
+	data := map[string][]string{
+		"sfile":    {params.Archive},
+		"dfile":    {params.Dest},
+		"type":     {panelType},
+		"coding":   {coding},
+		"password": {params.Password},
+	}
+	return c.btResult(ctx, data, "/files?action=UnZip")
+}
+
+// deployUploadDir DeployArchive 上传压缩包的目录 位于网站目录之外 避免压缩包可通过 HTTP 下载
+const deployUploadDir = "/tmp"
+
+// DeployArchive 将本地的 zip 或 tar.gz 压缩包上传到服务器并解压到网站目录 无论成功与否都会删除上传的压缩包
+// 网站目录中的同名文件会被覆盖 需要对外暂停服务时可配合 WithMaintenance 使用
+func (c *Client) DeployArchive(ctx context.Context, siteID int64, localArchive string) (err error) {
+	name := filepath.Base(localArchive)
+	if archiveType(name) == "" {
+		return errors.New("unsupported archive: " + localArchive)
+	}
+	root, err := c.siteKey(ctx, siteID, "path")
+	if err != nil {
+		return err
+	}
+	root = strings.TrimRight(root, "/")
+	f, err := os.Open(localArchive)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	remote := path.Join(deployUploadDir, "btsdk-"+c.newRequestID()+"-"+name)
+	if err := c.UploadFile(ctx, remote, f); err != nil {
+		return err
+	}
+	defer func() {
+		// 即使 ctx 已取消也要删除压缩包
+		if _, derr := c.DeleteFile(context.WithoutCancel(ctx), remote); err == nil {
+			err = derr
+		}
+	}()
+	_, err = c.UnZip(ctx, &ReqUnZip{Archive: remote, Dest: root})
+	return err
+}
@@ -0,0 +1,81 @@
+package bt
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestDeployArchive(t *testing.T) {
+	var calls []string
+	unzipMsg := `{"status":true,"msg":"解压成功"}`
+	c := newFakePanel(t, map[string]http.HandlerFunc{
+		"/data?action=getKey": reply(`"/www/wwwroot/w1.hao.com/"`),
+		"/files?action=upload": func(w http.ResponseWriter, r *http.Request) {
+			calls = append(calls, "upload "+r.FormValue("f_path")+" "+r.FormValue("f_name"))
+			_, _ = w.Write([]byte(`{"status":true,"msg":"上传成功"}`))
+		},
+		"/files?action=UnZip": func(w http.ResponseWriter, r *http.Request) {
+			calls = append(calls, "unzip "+r.FormValue("type")+" "+r.FormValue("sfile")+" "+r.FormValue("dfile"))
+			_, _ = w.Write([]byte(unzipMsg))
+		},
+		"/files?action=DeleteFile": func(w http.ResponseWriter, r *http.Request) {
+			calls = append(calls, "rm "+r.FormValue("path"))
+			_, _ = w.Write([]byte(`{"status":true,"msg":"删除成功"}`))
+		},
+	})
+	c.RequestIDFunc = func() string { return "r1" }
+	local := filepath.Join(t.TempDir(), "release.tar.gz")
+	if err := os.WriteFile(local, []byte("archive"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.DeployArchive(ctx, 1, local); err != nil {
+		t.Fatal(err)
+	}
+	// 压缩包上传到网站目录之外
+	want := []string{
+		"upload /tmp btsdk-r1-release.tar.gz",
+		"unzip tar /tmp/btsdk-r1-release.tar.gz /www/wwwroot/w1.hao.com",
+		"rm /tmp/btsdk-r1-release.tar.gz",
+	}
+	if strings.Join(calls, "\n") != strings.Join(want, "\n") {
+		t.Errorf("calls = %q", calls)
+	}
+
+	// 解压失败时同样删除压缩包
+	calls, unzipMsg = nil, `{"status":false,"msg":"解压失败"}`
+	if err := c.DeployArchive(ctx, 1, local); err == nil || err.Error() != "解压失败" {
+		t.Fatalf("DeployArchive = %v", err)
+	}
+	if len(calls) != 3 || calls[2] != "rm /tmp/btsdk-r1-release.tar.gz" {
+		t.Errorf("calls = %q", calls)
+	}
+
+	if _, err := c.UnZip(ctx, &ReqUnZip{Archive: "/a.tar.gz", Dest: "/b", Password: "x"}); err == nil {
+		t.Error("password accepted for tar.gz")
+	}
+	if err := c.DeployArchive(ctx, 1, "release.rar"); err == nil {
+		t.Error("rar accepted")
+	}
+}
+
+func TestZipPassword(t *testing.T) {
+	var form []string
+	c := newFakePanel(t, map[string]http.HandlerFunc{
+		"/files?action=Zip": func(w http.ResponseWriter, r *http.Request) {
+			form = append(form, r.FormValue("z_type")+" "+r.FormValue("sfile")+" "+r.FormValue("password"))
+			_, _ = w.Write([]byte(`{"status":true,"msg":"压缩成功"}`))
+		},
+	})
+	if _, err := c.Zip(ctx, &ReqZip{Dir: "/www", Names: []string{"a", "b"}, Archive: "/www/a.zip", Password: "s3cret"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.Zip(ctx, &ReqZip{Dir: "/www", Names: []string{"a"}, Archive: "/www/a.tar.gz", Type: ArchiveTarGz, Password: "s3cret"}); err == nil {
+		t.Error("password accepted for tar.gz")
+	}
+	if len(form) != 1 || form[0] != "zip a,b s3cret" {
+		t.Errorf("form = %q", form)
+	}
+}
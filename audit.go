@@ -0,0 +1,132 @@
+package bt
+
+import (
+	"context"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+// AuditRecord 一次变更类调用的审计记录
+type AuditRecord struct {
+	Time       time.Time         `json:"time"`
+	Actor      string            `json:"actor"` // 操作人 见 WithAuditActor
+	Panel      string            `json:"panel"` // 面板地址
+	RequestID  string            `json:"request_id"`
+	Endpoint   string            `json:"endpoint"`
+	Action     string            `json:"action"`
+	Params     map[string]string `json:"params"` // 参数摘要 敏感字段已脱敏 长内容已截断
+	Success    bool              `json:"success"`
+	StatusCode int               `json:"status_code"`     // HTTP 状态码 未收到响应时为 0
+	Error      string            `json:"error,omitempty"` // 网络/HTTP 错误或面板返回的 msg
+	Duration   time.Duration     `json:"duration"`
+}
+
+// AuditSink 审计记录的存储 可实现为写入数据库、消息队列等
+// Record 在请求所在的 goroutine 中同步调用 ctx 为发起调用时传入的 ctx
+type AuditSink interface {
+	Record(ctx context.Context, record AuditRecord) error
+}
+
+// AuditSinkFunc 函数形式的 AuditSink
+type AuditSinkFunc func(ctx context.Context, record AuditRecord) error
+
+// Record 实现 AuditSink
+func (f AuditSinkFunc) Record(ctx context.Context, record AuditRecord) error {
+	return f(ctx, record)
+}
+
+// auditActorKey 保存操作人的 ctx 键
+type auditActorKey struct{}
+
+// WithAuditActor 返回携带操作人的 ctx 使用该 ctx 发起的调用记录为 actor 所为
+func WithAuditActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, auditActorKey{}, actor)
+}
+
+// AuditActor 取 ctx 中的操作人 未设置时返回空串
+func AuditActor(ctx context.Context) string {
+	actor, _ := ctx.Value(auditActorKey{}).(string)
+	return actor
+}
+
+// AuditRecorder 将所有变更类调用（无论成功与否）写入 Sink
+// 通过 c.Hooks = append(c.Hooks, recorder.Hook(c)) 挂到 Client 上
+type AuditRecorder struct {
+	Sink AuditSink
+	// Actor 可选 ctx 中没有操作人时使用 eg. 自动化任务的服务名
+	Actor string
+	// OnError 可选 Sink 写入失败时调用 审计失败不影响请求本身的结果
+	OnError func(record AuditRecord, err error)
+}
+
+// Hook 返回挂载到 c 上的请求钩子 只记录变更类接口
+func (a *AuditRecorder) Hook(c *Client) Hook {
+	return func(info *RequestInfo) {
+		if !info.Mutation || a.Sink == nil {
+			return
+		}
+		ctx := info.Context()
+		record := AuditRecord{
+			Time:       info.Start,
+			Actor:      AuditActor(ctx),
+			Panel:      c.BTAddress,
+			RequestID:  info.ID,
+			Endpoint:   info.Endpoint,
+			Action:     endpointAction(info.Endpoint),
+			Params:     summarizeParams(info.Params),
+			Success:    info.Err == nil && !info.PanelFailed,
+			StatusCode: info.StatusCode,
+			Error:      info.PanelMsg,
+			Duration:   info.Duration,
+		}
+		if record.Actor == "" {
+			record.Actor = a.Actor
+		}
+		if info.Err != nil {
+			record.Error = info.Err.Error()
+		}
+		if err := a.Sink.Record(ctx, record); err != nil && a.OnError != nil {
+			a.OnError(record, err)
+		}
+	}
+}
+
+// JSONLinesAuditSink 将审计记录以每行一个 JSON 对象的形式写入 W 可并发使用
+type JSONLinesAuditSink struct {
+	mu sync.Mutex
+	W  io.Writer
+}
+
+// Record 实现 AuditSink
+func (s *JSONLinesAuditSink) Record(_ context.Context, record AuditRecord) error {
+	line, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, err = s.W.Write(append(line, '\n'))
+	return err
+}
+
+// AuditFile 以追加方式写入的审计日志文件
+type AuditFile struct {
+	JSONLinesAuditSink
+	file *os.File
+}
+
+// OpenAuditFile 以追加方式打开（不存在时创建）审计日志文件 用完后需 Close
+func OpenAuditFile(path string) (*AuditFile, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return nil, err
+	}
+	return &AuditFile{JSONLinesAuditSink: JSONLinesAuditSink{W: f}, file: f}, nil
+}
+
+// Close 关闭文件
+func (f *AuditFile) Close() error {
+	return f.file.Close()
+}
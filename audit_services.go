@@ -0,0 +1,165 @@
+package bt
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// 各服务的默认端口
+const (
+	defaultPanelPort      = 8888
+	defaultPHPMyAdminPort = 888
+	defaultFTPPort        = 21
+)
+
+// 服务配置文件
+const (
+	panelPortFile = "/www/server/panel/data/port.pl"
+	panelSSLFile  = "/www/server/panel/data/ssl.pl"
+	pureFTPdConf  = "/www/server/pure-ftpd/etc/pure-ftpd.conf"
+)
+
+// ServiceExposure 单个辅助服务的暴露情况
+type ServiceExposure struct {
+	Service     string // panel/phpmyadmin/ftp
+	Installed   bool   // 未安装时其余字段无意义
+	Port        int64  // 明文或面板的监听端口
+	TLS         bool   // 是否启用 TLS
+	DefaultPort bool   // 是否仍使用默认端口
+	Err         error  // 获取配置失败时的错误 其余字段不可信
+}
+
+// Insecure 已安装且未启用 TLS 或使用默认端口
+func (s ServiceExposure) Insecure() bool {
+	return s.Err == nil && s.Installed && (!s.TLS || s.DefaultPort)
+}
+
+// ServiceAudit 面板辅助服务审计结果
+type ServiceAudit struct {
+	Panel    string
+	Services []ServiceExposure
+}
+
+// Insecure 返回未启用 TLS 或使用默认端口的服务
+func (a ServiceAudit) Insecure() []ServiceExposure {
+	var ret []ServiceExposure
+	for _, s := range a.Services {
+		if s.Insecure() {
+			ret = append(ret, s)
+		}
+	}
+	return ret
+}
+
+// AuditPanelServices 检查面板、phpMyAdmin、FTP 是否启用 TLS 以及是否仍使用默认端口
+// 单项检查失败记录在对应的 Err 中 不影响其他项
+func (c *Client) AuditPanelServices(ctx context.Context) ServiceAudit {
+	return ServiceAudit{
+		Panel: c.BTAddress,
+		Services: []ServiceExposure{
+			c.auditPanel(ctx),
+			c.auditPHPMyAdmin(ctx),
+			c.auditFTP(ctx),
+		},
+	}
+}
+
+func (c *Client) auditPanel(ctx context.Context) ServiceExposure {
+	ret := ServiceExposure{Service: "panel", Installed: true}
+	port, err := c.GetFile(ctx, panelPortFile)
+	if err != nil {
+		ret.Err = err
+		return ret
+	}
+	ret.Port = defaultPanelPort
+	if port.Status {
+		if n, err := strconv.ParseInt(strings.TrimSpace(port.Data), 10, 64); err == nil {
+			ret.Port = n
+		}
+	}
+	ret.DefaultPort = ret.Port == defaultPanelPort
+	ssl, err := c.GetFile(ctx, panelSSLFile)
+	if err != nil {
+		ret.Err = err
+		return ret
+	}
+	ret.TLS = ssl.Status
+	return ret
+}
+
+func (c *Client) auditPHPMyAdmin(ctx context.Context) ServiceExposure {
+	ret := ServiceExposure{Service: "phpmyadmin"}
+	soft, err := c.GetSoftList(ctx, "phpmyadmin")
+	if err != nil {
+		ret.Err = err
+		return ret
+	}
+	for _, s := range soft.List.Data {
+		if s.Name == "phpmyadmin" && s.Setup {
+			ret.Installed = true
+		}
+	}
+	if !ret.Installed {
+		return ret
+	}
+	resp, err := c.btAPI(ctx, map[string][]string{}, "/ajax?action=get_phpmyadmin_ssl")
+	if err != nil {
+		ret.Err = err
+		return ret
+	}
+	var dec struct {
+		Status bool        `json:"status"`
+		Port   interface{} `json:"port"`
+	}
+	if err := c.unmarshal(ctx, resp, &dec); err != nil {
+		ret.Err = err
+		return ret
+	}
+	ret.TLS = dec.Status
+	ret.Port = defaultPHPMyAdminPort
+	if n, err := strconv.ParseInt(fmt.Sprint(dec.Port), 10, 64); err == nil && dec.Status {
+		ret.Port = n
+	}
+	ret.DefaultPort = ret.Port == defaultPHPMyAdminPort
+	return ret
+}
+
+func (c *Client) auditFTP(ctx context.Context) ServiceExposure {
+	ret := ServiceExposure{Service: "ftp"}
+	conf, err := c.GetFile(ctx, pureFTPdConf)
+	if err != nil {
+		ret.Err = err
+		return ret
+	}
+	if !conf.Status {
+		return ret
+	}
+	ret.Installed = true
+	ret.Port = defaultFTPPort
+	sc := bufio.NewScanner(strings.NewReader(conf.Data))
+	for sc.Scan() {
+		fields := strings.Fields(sc.Text())
+		if len(fields) != 2 {
+			continue
+		}
+		switch fields[0] {
+		case "Bind":
+			// Bind 0.0.0.0,21 或 Bind ,21
+			addr := fields[1]
+			if i := strings.LastIndex(addr, ","); i >= 0 {
+				addr = addr[i+1:]
+			}
+			if n, err := strconv.ParseInt(addr, 10, 64); err == nil {
+				ret.Port = n
+			}
+		case "TLS":
+			// 0 关闭 1 可选 2 强制 只有强制才视为启用
+			ret.TLS = fields[1] == "2"
+		}
+	}
+	ret.DefaultPort = ret.Port == defaultFTPPort
+	return ret
+}
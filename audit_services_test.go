@@ -0,0 +1,36 @@
+package bt
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestAuditPanelServices(t *testing.T) {
+	files := map[string]string{
+		panelPortFile: "8888\n",
+		pureFTPdConf:  "# Bind 127.0.0.1,21\nBind                        0.0.0.0,2121\nTLS                         1\n",
+	}
+	c := newFakePanel(t, map[string]http.HandlerFunc{
+		"/files?action=GetFileBody": func(w http.ResponseWriter, r *http.Request) {
+			body, ok := files[r.FormValue("path")]
+			b, _ := json.Marshal(RespGetFile{Status: ok, Data: body})
+			_, _ = w.Write(b)
+		},
+		"/plugin?action=get_soft_list":    reply(`{"list":{"data":[{"name":"phpmyadmin","setup":true}]}}`),
+		"/ajax?action=get_phpmyadmin_ssl": reply(`{"status":true,"port":"8443"}`),
+	})
+	a := c.AuditPanelServices(ctx)
+	want := []ServiceExposure{
+		{Service: "panel", Installed: true, Port: 8888, DefaultPort: true},
+		{Service: "phpmyadmin", Installed: true, Port: 8443, TLS: true},
+		{Service: "ftp", Installed: true, Port: 2121},
+	}
+	for i, w := range want {
+		if a.Services[i] != w {
+			t.Errorf("got %+v want %+v", a.Services[i], w)
+		}
+	}
+	if len(a.Insecure()) != 2 {
+		t.Fatalf("insecure %+v", a.Insecure())
+	}
+}
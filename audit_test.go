@@ -0,0 +1,54 @@
+package bt
+
+import (
+	"bufio"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestAuditRecorder(t *testing.T) {
+	c := newFakePanel(t, map[string]http.HandlerFunc{
+		"/site?action=SetHasPwd":        reply(`{"status":false,"msg":"密码格式错误"}`),
+		"/site?action=CloseHasPwd":      reply(`{"status":true,"msg":"操作成功"}`),
+		"/system?action=GetSystemTotal": reply(`{}`),
+	})
+	file, err := OpenAuditFile(filepath.Join(t.TempDir(), "audit.log"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	recorder := &AuditRecorder{Sink: file, Actor: "deploy-bot"}
+	c.Hooks = append(c.Hooks, recorder.Hook(c))
+	_, _ = c.SetHasPwd(WithAuditActor(ctx, "alice"), 11, "admin", "secret")
+	_, _ = c.CloseHasPwd(ctx, 11)
+	_, _ = c.GetSystemTotal(ctx)
+	if err := file.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	in, err := os.Open(file.file.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer in.Close()
+	var records []AuditRecord
+	scanner := bufio.NewScanner(in)
+	for scanner.Scan() {
+		var r AuditRecord
+		if err := json.Unmarshal(scanner.Bytes(), &r); err != nil {
+			t.Fatal(err)
+		}
+		records = append(records, r)
+	}
+	if len(records) != 2 {
+		t.Fatalf("got %d records", len(records))
+	}
+	failed, ok := records[0], records[1]
+	if failed.Actor != "alice" || failed.Success || failed.Error != "密码格式错误" || failed.Params["password"] != "[REDACTED]" || failed.Panel != c.BTAddress {
+		t.Errorf("failed record %+v", failed)
+	}
+	if ok.Actor != "deploy-bot" || !ok.Success || ok.Action != "CloseHasPwd" || ok.StatusCode != 200 {
+		t.Errorf("ok record %+v", ok)
+	}
+}
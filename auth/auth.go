@@ -0,0 +1,42 @@
+// Package auth 宝塔面板 API 的签名算法
+// 供自行实现传输层（eg. websocket 终端、文件下载）时复用 与 bt.Client 使用的签名逻辑一致
+package auth
+
+import (
+	"crypto/md5"
+	"encoding/hex"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// 签名字段名
+const (
+	TokenField = "request_token" // MD5(request_time + MD5(api key))
+	TimeField  = "request_time"  // 当前 Unix 时间戳（秒） 与面板时间相差过大时签名无效
+)
+
+// MD5 Generate 32-bit MD5 strings
+func MD5(s string) string {
+	h := md5.New()
+	h.Write([]byte(s))
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// Token 计算 t 时刻的 request_token
+func Token(key string, t time.Time) string {
+	return MD5(strconv.FormatInt(t.Unix(), 10) + MD5(key))
+}
+
+// BuildSignedForm 生成当前时刻的签名字段 可直接作为表单或 URL 查询参数
+func BuildSignedForm(key string) url.Values {
+	return BuildSignedFormAt(key, time.Now())
+}
+
+// BuildSignedFormAt 生成 t 时刻的签名字段
+func BuildSignedFormAt(key string, t time.Time) url.Values {
+	return url.Values{
+		TokenField: {Token(key, t)},
+		TimeField:  {strconv.FormatInt(t.Unix(), 10)},
+	}
+}
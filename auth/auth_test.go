@@ -0,0 +1,19 @@
+package auth
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBuildSignedFormAt(t *testing.T) {
+	if got := MD5("test-key"); got != "53136271c432a1af377c3806c3112ddf" {
+		t.Fatalf("MD5 = %q", got)
+	}
+	form := BuildSignedFormAt("test-key", time.Unix(1700000000, 0))
+	if form.Get(TimeField) != "1700000000" || form.Get(TokenField) != MD5("1700000000"+MD5("test-key")) {
+		t.Fatalf("form %v", form)
+	}
+	if len(form) != 2 {
+		t.Errorf("unexpected fields %v", form)
+	}
+}
@@ -0,0 +1,129 @@
+package bt
+
+import (
+	"context"
+	"strings"
+	"sync"
+)
+
+// SiteFilter 批量操作的网站筛选条件 各条件同时满足才会被选中
+type SiteFilter struct {
+	Search      string              // 面板搜索关键字 匹配网站名/备注
+	IDs         []int64             // 限定网站 ID 为空时不限制
+	PathPrefix  string              // 限定网站目录前缀 eg. /www/wwwroot/tenant-x/
+	Match       func(SiteInfo) bool // 自定义条件
+	Concurrency int                 // 并发数 默认 4
+}
+
+func (f *SiteFilter) match(s SiteInfo) bool {
+	if len(f.IDs) > 0 {
+		found := false
+		for _, id := range f.IDs {
+			if int64(s.ID) == id {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if f.PathPrefix != "" && !strings.HasPrefix(s.Path, f.PathPrefix) {
+		return false
+	}
+	return f.Match == nil || f.Match(s)
+}
+
+// SiteOpResult 批量操作中单个网站的结果
+type SiteOpResult struct {
+	Site SiteInfo
+	Resp RespMSG
+	Err  error
+}
+
+// OK 操作成功
+func (r SiteOpResult) OK() bool {
+	return r.Err == nil && r.Resp.Status
+}
+
+// BulkReport 批量操作结果
+type BulkReport struct {
+	Results []SiteOpResult // 与网站列表顺序一致
+}
+
+// Failed 返回失败的结果
+func (r BulkReport) Failed() []SiteOpResult {
+	var ret []SiteOpResult
+	for _, v := range r.Results {
+		if !v.OK() {
+			ret = append(ret, v)
+		}
+	}
+	return ret
+}
+
+// ListAllSites 分页获取全部网站 search 为搜索关键字 每页条数根据响应速度自动调整
+func (c *Client) ListAllSites(ctx context.Context, search string) ([]SiteInfo, error) {
+	var ret []SiteInfo
+	err := fetchPages(func(p int64, limit int64) (int, error) {
+		page, err := c.GetSites(ctx, &ReqSites{P: p, Limit: limit, Search: search})
+		if err != nil {
+			return 0, err
+		}
+		ret = append(ret, page.Data...)
+		return len(page.Data), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+// StopSites 停止所有符合条件的网站
+func (c *Client) StopSites(ctx context.Context, filter SiteFilter) (BulkReport, error) {
+	return c.bulkSites(ctx, filter, func(s SiteInfo) (RespMSG, error) {
+		return c.StopSite(ctx, int64(s.ID), s.Name)
+	})
+}
+
+// StartSites 启动所有符合条件的网站
+func (c *Client) StartSites(ctx context.Context, filter SiteFilter) (BulkReport, error) {
+	return c.bulkSites(ctx, filter, func(s SiteInfo) (RespMSG, error) {
+		return c.StartSite(ctx, int64(s.ID), s.Name)
+	})
+}
+
+// bulkSites 以有限并发对符合条件的网站执行 op 列表获取失败时返回错误 单个网站失败记录在结果中
+func (c *Client) bulkSites(ctx context.Context, filter SiteFilter, op func(SiteInfo) (RespMSG, error)) (BulkReport, error) {
+	sites, err := c.ListAllSites(ctx, filter.Search)
+	if err != nil {
+		return BulkReport{}, err
+	}
+	var selected []SiteInfo
+	for _, s := range sites {
+		if filter.match(s) {
+			selected = append(selected, s)
+		}
+	}
+	workers := filter.Concurrency
+	if workers <= 0 {
+		workers = 4
+	}
+	report := BulkReport{Results: make([]SiteOpResult, len(selected))}
+	sem := make(chan struct{}, workers)
+	var wg sync.WaitGroup
+	for i, s := range selected {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, s SiteInfo) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			resp, err := op(s)
+			report.Results[i] = SiteOpResult{Site: s, Resp: resp, Err: err}
+		}(i, s)
+	}
+	wg.Wait()
+	return report, nil
+}
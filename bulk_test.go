@@ -0,0 +1,39 @@
+package bt
+
+import (
+	"net/http"
+	"sync"
+	"testing"
+)
+
+func TestStopSites(t *testing.T) {
+	var mu sync.Mutex
+	stopped := map[string]bool{}
+	c := newFakePanel(t, map[string]http.HandlerFunc{
+		"/data?action=getData": reply(`{"data":[
+			{"id":1,"name":"a.tenant.com","path":"/www/wwwroot/tenant-x/a"},
+			{"id":2,"name":"b.tenant.com","path":"/www/wwwroot/tenant-x/b"},
+			{"id":3,"name":"c.other.com","path":"/www/wwwroot/other/c"}
+		]}`),
+		"/site?action=SiteStop": func(w http.ResponseWriter, r *http.Request) {
+			mu.Lock()
+			stopped[r.FormValue("name")] = true
+			mu.Unlock()
+			if r.FormValue("id") == "2" {
+				_, _ = w.Write([]byte(`{"status":false,"msg":"停止失败"}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"status":true,"msg":"站点已停用"}`))
+		},
+	})
+	report, err := c.StopSites(ctx, SiteFilter{PathPrefix: "/www/wwwroot/tenant-x/", Concurrency: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Results) != 2 || stopped["c.other.com"] || !stopped["a.tenant.com"] {
+		t.Fatalf("report %+v stopped %v", report, stopped)
+	}
+	if failed := report.Failed(); len(failed) != 1 || failed[0].Site.ID != 2 {
+		t.Fatalf("failed %+v", failed)
+	}
+}
@@ -0,0 +1,85 @@
+package bt
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// ResponseCache 缓存变化很少的目录类接口（软件商店列表、伪静态模板列表）的原始返回
+// 缓存键包含面板版本 面板升级后自动失效 需要跨进程共享时可自行基于 Redis 等实现
+type ResponseCache interface {
+	Get(key string) ([]byte, bool)
+	Set(key string, resp []byte)
+}
+
+// MemoryResponseCache 基于内存的 ResponseCache 并发安全
+type MemoryResponseCache struct {
+	m sync.Map
+}
+
+// NewMemoryResponseCache 实例化内存响应缓存
+func NewMemoryResponseCache() *MemoryResponseCache {
+	return &MemoryResponseCache{}
+}
+
+// Get 实现 ResponseCache
+func (s *MemoryResponseCache) Get(key string) ([]byte, bool) {
+	v, ok := s.m.Load(key)
+	if !ok {
+		return nil, false
+	}
+	return v.([]byte), true
+}
+
+// Set 实现 ResponseCache
+func (s *MemoryResponseCache) Set(key string, resp []byte) {
+	s.m.Store(key, resp)
+}
+
+// cacheVersionTTL 面板版本的复核间隔 超过后重新获取版本以判断缓存是否仍然有效
+var cacheVersionTTL = time.Minute
+
+// cacheVersion 获取用于缓存键的面板版本 在 cacheVersionTTL 内复用上次的结果
+func (c *Client) cacheVersion(ctx context.Context) (string, error) {
+	c.mu.Lock()
+	if c.cacheVer != "" && time.Since(c.cacheVerAt) < cacheVersionTTL {
+		ver := c.cacheVer
+		c.mu.Unlock()
+		return ver, nil
+	}
+	c.mu.Unlock()
+	total, err := c.GetSystemTotal(ctx)
+	if err != nil {
+		return "", err
+	}
+	c.mu.Lock()
+	c.cacheVer, c.cacheVerAt = total.Version, time.Now()
+	c.mu.Unlock()
+	return total.Version, nil
+}
+
+// cached 未配置 Cache 时直接调用 call
+// 否则以面板地址、版本和 key 查找缓存 未命中时调用 call 并在 valid 判定有效后写入缓存
+// 获取面板版本失败时不使用缓存
+func (c *Client) cached(ctx context.Context, key string, call func() ([]byte, error), valid func([]byte) bool) ([]byte, error) {
+	if c.Cache == nil {
+		return call()
+	}
+	ver, err := c.cacheVersion(ctx)
+	if err != nil {
+		return call()
+	}
+	key = c.BTAddress + "|" + ver + "|" + key
+	if resp, ok := c.Cache.Get(key); ok {
+		return resp, nil
+	}
+	resp, err := call()
+	if err != nil {
+		return resp, err
+	}
+	if valid(resp) {
+		c.Cache.Set(key, resp)
+	}
+	return resp, nil
+}
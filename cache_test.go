@@ -0,0 +1,37 @@
+package bt
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestCache(t *testing.T) {
+	version, calls := "7.9.0", 0
+	c := newFakePanel(t, map[string]http.HandlerFunc{
+		"/system?action=GetSystemTotal": func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(`{"version":"` + version + `"}`))
+		},
+		"/plugin?action=get_soft_list": func(w http.ResponseWriter, r *http.Request) {
+			calls++
+			_, _ = w.Write([]byte(`{"list":{"data":[{"name":"nginx"}]}}`))
+		},
+	})
+	c.Cache = NewMemoryResponseCache()
+	for i := 0; i < 3; i++ {
+		if _, err := c.GetSoftList(ctx, ""); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if calls != 1 {
+		t.Fatalf("calls = %d", calls)
+	}
+	// 面板升级后缓存失效
+	version = "8.0.0"
+	c.cacheVerAt = c.cacheVerAt.Add(-2 * cacheVersionTTL)
+	if _, err := c.GetSoftList(ctx, ""); err != nil {
+		t.Fatal(err)
+	}
+	if calls != 2 {
+		t.Fatalf("calls = %d", calls)
+	}
+}
@@ -0,0 +1,71 @@
+package bt
+
+import "context"
+
+// 可选插件在软件商店中的名称
+const (
+	pluginWAF    = "btwaf"
+	pluginDocker = "docker"
+	pluginNode   = "nodejs"
+	pluginMail   = "mail_sys"
+)
+
+// Capabilities 面板已安装的可选功能 由 Client.Capabilities 探测
+type Capabilities struct {
+	WAF    bool // Nginx 防火墙
+	Docker bool // Docker 管理器
+	Node   bool // Node.js 版本管理器
+	Mail   bool // 宝塔邮局
+	// WebServer 已安装的 Web 服务器 nginx/apache/openlitespeed 均未安装时为空
+	WebServer string
+	// Plugins 软件商店中全部已安装软件 键为软件名 值为是否运行中
+	Plugins map[string]bool
+}
+
+// Installed 判断软件商店中的软件是否已安装 name 为软件名 eg. tamper_proof
+func (c Capabilities) Installed(name string) bool {
+	_, ok := c.Plugins[name]
+	return ok
+}
+
+// Capabilities 探测面板已安装的可选插件 结果缓存在 Client 上 之后的调用不再请求面板
+// 安装或卸载插件后可调用 ResetCapabilities 重新探测
+func (c *Client) Capabilities(ctx context.Context) (Capabilities, error) {
+	c.capMu.Lock()
+	defer c.capMu.Unlock()
+	if c.capabilities != nil {
+		return *c.capabilities, nil
+	}
+	if err := ctx.Err(); err != nil {
+		return Capabilities{}, err
+	}
+	soft, err := c.GetSoftList(ctx, "")
+	if err != nil {
+		return Capabilities{}, err
+	}
+	ret := Capabilities{Plugins: map[string]bool{}}
+	for _, s := range soft.List.Data {
+		if s.Setup {
+			ret.Plugins[s.Name] = s.Status
+		}
+	}
+	ret.WAF = ret.Installed(pluginWAF)
+	ret.Docker = ret.Installed(pluginDocker)
+	ret.Node = ret.Installed(pluginNode)
+	ret.Mail = ret.Installed(pluginMail)
+	for _, name := range []string{"nginx", "apache", "openlitespeed"} {
+		if ret.Installed(name) {
+			ret.WebServer = name
+			break
+		}
+	}
+	c.capabilities = &ret
+	return ret, nil
+}
+
+// ResetCapabilities 清除 Capabilities 的缓存结果
+func (c *Client) ResetCapabilities() {
+	c.capMu.Lock()
+	c.capabilities = nil
+	c.capMu.Unlock()
+}
@@ -0,0 +1,38 @@
+package bt
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestCapabilities(t *testing.T) {
+	calls := 0
+	c := newFakePanel(t, map[string]http.HandlerFunc{
+		"/plugin?action=get_soft_list": func(w http.ResponseWriter, r *http.Request) {
+			calls++
+			_, _ = w.Write([]byte(`{"list":{"data":[
+				{"name":"btwaf","setup":true,"status":true},
+				{"name":"docker","setup":false},
+				{"name":"nodejs","setup":true,"status":false}
+			]}}`))
+		},
+	})
+	for i := 0; i < 2; i++ {
+		r, err := c.Capabilities(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !r.WAF || r.Docker || !r.Node || r.Mail || r.Plugins["nodejs"] {
+			t.Fatalf("unexpected %+v", r)
+		}
+	}
+	if calls != 1 {
+		t.Fatalf("calls = %d", calls)
+	}
+	c.ResetCapabilities()
+	_, _ = c.Capabilities(context.Background())
+	if calls != 2 {
+		t.Fatalf("calls = %d", calls)
+	}
+}
@@ -0,0 +1,181 @@
+package bt
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// EmptyRecycleBin 清空文件回收站
+func (c *Client) EmptyRecycleBin(ctx context.Context) (RespMSG, error) {
+	return c.btResult(ctx, map[string][]string{}, "/files?action=Close_Recycle_Bin")
+}
+
+// ClearLogs 清理网站访问日志及面板日志
+func (c *Client) ClearLogs(ctx context.Context) (RespMSG, error) {
+	return c.btResult(ctx, map[string][]string{}, "/files?action=CloseLogs")
+}
+
+// DiskUsage 单个分区的使用情况
+type DiskUsage struct {
+	Path    string
+	Percent float64 // 已用百分比 0-100
+	Used    int64   // 已用字节数 由面板返回的 eg. 20G 换算 精度有限
+}
+
+// GetDiskUsage 获取挂载点的使用情况 path 为空时为 /
+func (c *Client) GetDiskUsage(ctx context.Context, path string) (DiskUsage, error) {
+	if path == "" {
+		path = "/"
+	}
+	disks, err := c.GetDiskInfo(ctx)
+	if err != nil {
+		return DiskUsage{}, err
+	}
+	for _, d := range disks {
+		if d.Path != path {
+			continue
+		}
+		if len(d.Size) < 4 {
+			return DiskUsage{}, errors.New("unexpected disk info for " + path)
+		}
+		percent, err := strconv.ParseFloat(strings.TrimSuffix(d.Size[3], "%"), 64)
+		if err != nil {
+			return DiskUsage{}, err
+		}
+		return DiskUsage{Path: path, Percent: percent, Used: parseHumanSize(d.Size[1])}, nil
+	}
+	return DiskUsage{}, errors.New("disk not found: " + path)
+}
+
+// parseHumanSize 解析 df -h 风格的容量 eg. 512M 1.5G 无法解析时返回 0
+func parseHumanSize(s string) int64 {
+	s = strings.TrimSuffix(strings.TrimSpace(s), "B")
+	if s == "" {
+		return 0
+	}
+	mul := float64(1)
+	if i := strings.IndexByte("KMGTP", s[len(s)-1]); i >= 0 {
+		for ; i >= 0; i-- {
+			mul *= 1024
+		}
+		s = s[:len(s)-1]
+	}
+	n, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0
+	}
+	return int64(n * mul)
+}
+
+// CleanupPlan 磁盘清理计划 使用率达到 Threshold 时依次执行已开启的步骤
+type CleanupPlan struct {
+	Path            string  // 监控的挂载点 默认 /
+	Threshold       float64 // 触发清理的使用率 0-100 为 0 时总是执行
+	KeepBackups     int     // 每个网站保留最新的备份数 为 0 时不清理备份
+	ClearLogs       bool    // 清理网站及面板日志
+	EmptyRecycleBin bool    // 清空回收站
+}
+
+// CleanupStep 清理步骤的执行结果
+type CleanupStep struct {
+	Name  string // prune_backups/clear_logs/empty_recycle_bin
+	Freed int64  // 确切释放的字节数 仅备份清理可统计
+	Err   error
+}
+
+// CleanupReport 磁盘清理结果
+type CleanupReport struct {
+	Panel     string
+	Triggered bool // 是否达到阈值并执行了清理
+	Before    DiskUsage
+	After     DiskUsage // 未触发时与 Before 相同
+	Steps     []CleanupStep
+	Reclaimed int64 // 释放的空间 取分区用量变化与各步骤统计中较大者
+}
+
+// CleanupDisk 检查磁盘使用率 达到阈值时执行清理计划并报告释放的空间
+// 单个步骤失败不会中断后续步骤 错误记录在 Steps 中
+func (c *Client) CleanupDisk(ctx context.Context, plan CleanupPlan) (CleanupReport, error) {
+	report := CleanupReport{Panel: c.BTAddress}
+	before, err := c.GetDiskUsage(ctx, plan.Path)
+	if err != nil {
+		return report, err
+	}
+	report.Before, report.After = before, before
+	if before.Percent < plan.Threshold {
+		return report, nil
+	}
+	report.Triggered = true
+	var exact int64
+	if plan.KeepBackups > 0 {
+		freed, err := c.pruneBackups(ctx, plan.KeepBackups)
+		report.Steps = append(report.Steps, CleanupStep{Name: "prune_backups", Freed: freed, Err: err})
+		exact += freed
+	}
+	if plan.ClearLogs {
+		report.Steps = append(report.Steps, msgStep(ctx, "clear_logs", c.ClearLogs))
+	}
+	if plan.EmptyRecycleBin {
+		report.Steps = append(report.Steps, msgStep(ctx, "empty_recycle_bin", c.EmptyRecycleBin))
+	}
+	after, err := c.GetDiskUsage(ctx, plan.Path)
+	if err != nil {
+		return report, err
+	}
+	report.After = after
+	report.Reclaimed = before.Used - after.Used
+	if report.Reclaimed < exact {
+		report.Reclaimed = exact
+	}
+	return report, nil
+}
+
+// CleanupDisks 并发检查多台面板的磁盘 返回结果与 clients 顺序一致
+func CleanupDisks(ctx context.Context, clients []*Client, plan CleanupPlan) ([]CleanupReport, []error) {
+	reports := make([]CleanupReport, len(clients))
+	errs := make([]error, len(clients))
+	var wg sync.WaitGroup
+	for i, c := range clients {
+		wg.Add(1)
+		go func(i int, c *Client) {
+			defer wg.Done()
+			reports[i], errs[i] = c.CleanupDisk(ctx, plan)
+		}(i, c)
+	}
+	wg.Wait()
+	return reports, errs
+}
+
+func msgStep(ctx context.Context, name string, call func(context.Context) (RespMSG, error)) CleanupStep {
+	step := CleanupStep{Name: name}
+	_, step.Err = call(ctx)
+	return step
+}
+
+// pruneBackups 每个网站只保留最新的 keep 份备份 返回删除的备份总大小
+func (c *Client) pruneBackups(ctx context.Context, keep int) (int64, error) {
+	sites, err := c.ListAllSites(ctx, "")
+	if err != nil {
+		return 0, err
+	}
+	var freed int64
+	for _, s := range sites {
+		backups, err := c.GetSiteBackups(ctx, &ReqSiteBackups{P: 1, Limit: 1000, Search: int64(s.ID)})
+		if err != nil {
+			return freed, err
+		}
+		files := backups.Data
+		sort.Slice(files, func(i, j int) bool { return files[i].ID > files[j].ID })
+		for i := keep; i < len(files); i++ {
+			if _, err := c.DeleteSiteBackup(ctx, int64(files[i].ID)); err != nil {
+				return freed, err
+			}
+			freed += int64(files[i].Size)
+		}
+	}
+	return freed, nil
+}
@@ -0,0 +1,58 @@
+package bt
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestParseHumanSize(t *testing.T) {
+	cases := map[string]int64{"512M": 512 << 20, "1.5G": 3 << 29, "20K": 20 << 10, "100": 100, "": 0, "x": 0}
+	for s, want := range cases {
+		if got := parseHumanSize(s); got != want {
+			t.Errorf("parseHumanSize(%q) = %d", s, got)
+		}
+	}
+}
+
+func TestCleanupDisk(t *testing.T) {
+	disk := `[{"path":"/","size":["50G","45G","5G","90%"]}]`
+	var deleted []string
+	c := newFakePanel(t, map[string]http.HandlerFunc{
+		"/system?action=GetDiskInfo": func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(disk))
+		},
+		"/data?action=getData": func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Query().Get("table") == "sites" {
+				_, _ = w.Write([]byte(`{"data":[{"id":1,"name":"a.com"}]}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"data":[{"id":3,"size":100},{"id":5,"size":200},{"id":4,"size":300}]}`))
+		},
+		"/site?action=DelBackup": func(w http.ResponseWriter, r *http.Request) {
+			deleted = append(deleted, r.FormValue("id"))
+			_, _ = w.Write([]byte(`{"status":true,"msg":"删除成功"}`))
+		},
+		"/files?action=CloseLogs": func(w http.ResponseWriter, r *http.Request) {
+			disk = `[{"path":"/","size":["50G","40G","10G","80%"]}]`
+			_, _ = w.Write([]byte(`{"status":true,"msg":"已清理"}`))
+		},
+		"/files?action=Close_Recycle_Bin": reply(`{"status":false,"msg":"回收站为空"}`),
+	})
+	r, err := c.CleanupDisk(ctx, CleanupPlan{Threshold: 85, KeepBackups: 2, ClearLogs: true, EmptyRecycleBin: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !r.Triggered || len(deleted) != 1 || deleted[0] != "3" {
+		t.Fatalf("unexpected %+v deleted %v", r, deleted)
+	}
+	if len(r.Steps) != 3 || r.Steps[0].Freed != 100 || r.Steps[1].Err != nil || r.Steps[2].Err == nil {
+		t.Fatalf("steps %+v", r.Steps)
+	}
+	if r.After.Percent != 80 || r.Reclaimed != 5<<30 {
+		t.Fatalf("unexpected %+v", r)
+	}
+	r, err = c.CleanupDisk(ctx, CleanupPlan{Threshold: 85, ClearLogs: true})
+	if err != nil || r.Triggered {
+		t.Fatalf("unexpected %+v, %v", r, err)
+	}
+}
@@ -1,15 +1,18 @@
 package bt
 
 import (
-	"crypto/md5"
-	"encoding/hex"
+	"context"
 	"errors"
 	jsoniter "github.com/json-iterator/go"
+	"github.com/noahlsl/bt/auth"
+	"io"
 	"io/ioutil"
 	"net/http"
 	"net/http/cookiejar"
 	"net/url"
 	"strconv"
+	"strings"
+	"sync"
 	"time"
 )
 
@@ -17,10 +20,53 @@ var json = jsoniter.ConfigCompatibleWithStandardLibrary
 
 // Client 每个 Client 对象对应一个宝塔面板 先实例化再调用接口
 type Client struct {
-	BTAddress string         // 目标宝塔面板地址 eg.http://10.0.0.14:8888 结尾不要有斜杠
-	BTKey     string         // API Key 还需要添加 IP 白名单
-	cookies   []*http.Cookie // 根据文档建议保存每次返回的 cookies 来提高效率
+	BTAddress string                    // 目标宝塔面板地址 eg.http://10.0.0.14:8888 结尾不要有斜杠
+	BTKey     string                    // API Key 还需要添加 IP 白名单
+	cookies   map[string]*cookiejar.Jar // 根据文档建议按面板 host 保存返回的 cookies 来提高效率
 	Timeout   time.Duration
+	// Translator 可选 用于将 RespMSG.Msg 翻译为其他语言或映射为机器可读代码
+	Translator Translator
+	// Hooks 每次调用面板 API 结束后依次触发
+	Hooks []Hook
+	// RequestIDFunc 可选 自定义请求关联 ID 的生成方式 默认为 16 位随机十六进制串
+	RequestIDFunc func() string
+	// Idempotency 可选 配置后 AddSite/AddDomain 成功的结果会被记录 相同幂等键的重复调用直接返回记录的结果
+	// DeleteSite/DelDomain 成功后删除对应的默认幂等键记录 自定义的 IdempotencyKey 需自行调用 Delete
+	Idempotency IdempotencyStore
+	// Now 可选 用于计算签名中的 request_time 默认为 time.Now 可用于测试固定签名或模拟时钟偏差
+	Now func() time.Time
+	// DomainListeners AddDomain/DelDomain 成功后依次通知 可用于联动 DNS/CDN
+	DomainListeners []DomainChangeListener
+	// DialAddress 可选 固定连接的 IP 或 IP:端口 URL 与 Host/SNI 仍使用 BTAddress 中的域名
+	// 用于面板只能通过内网（如 WireGuard）IP 访问而证书签发给公网域名的情况 需在首次请求前设置
+	DialAddress string
+	// Cache 可选 配置后软件商店列表、伪静态模板列表等目录类接口的结果按面板版本缓存
+	Cache ResponseCache
+	// DisableCookies 可选 为 true 时不保存也不发送面板返回的 cookies 每次请求只依靠签名认证
+	DisableCookies bool
+	// Decoder 可选 解析响应所用的 JSON 实现 默认为 json-iterator
+	Decoder Decoder
+	// StrictDecode 可选 响应中出现模型未定义的字段时返回错误 用于在测试中及时发现面板接口变化
+	StrictDecode bool
+	// HTTPClient 可选 自定义代理、TLS 等 需在首次请求前设置 设置后 DialAddress 不生效
+	// Client 使用其副本 未设置 Jar 时加入面板 cookies 单次请求的超时仍由 Timeout 控制
+	// Close 不会释放其连接
+	HTTPClient *http.Client
+	// RewriteEndpoint 可选 拼接请求 URL 前改写接口路径 eg. /site?action=AddSite
+	// 用于面板位于反向代理的路径前缀下等情况 Hooks 等处记录的仍是改写前的路径
+	RewriteEndpoint func(endpoint string) string
+
+	mu        sync.Mutex
+	client    *http.Client    // 首次请求时创建 之后的请求复用连接和 TLS 会话
+	transport *http.Transport // client 的连接池 Close 时释放空闲连接 使用 HTTPClient 时为空
+	closers   []*func()       // Close 时依次调用 用于停止使用 Client 的后台任务
+	closed    bool
+
+	cacheVer   string    // 最近一次获取的面板版本 用于 Cache 的键
+	cacheVerAt time.Time // cacheVer 的获取时间
+
+	capMu        sync.Mutex
+	capabilities *Capabilities // Capabilities 的探测结果
 }
 
 // NewClient 填入两个参数来实例化 Client 对象
@@ -35,160 +81,312 @@ func NewClient(address string, key string, timeout ...time.Duration) *Client {
 	return ret
 }
 
-func (c *Client) btAPI(data map[string][]string, endpoint string) ([]byte, error) {
-	requestURL, err := url.Parse(c.BTAddress + endpoint)
+func (c *Client) btAPI(ctx context.Context, data map[string][]string, endpoint string) ([]byte, error) {
+	return c.call(ctx, data, endpoint, c.send)
+}
+
+// call 以 send 发送请求并读取响应 记录请求信息并触发 Hooks
+func (c *Client) call(ctx context.Context, data map[string][]string, endpoint string, send sendFunc) ([]byte, error) {
+	info := &RequestInfo{
+		ctx:      ctx,
+		ID:       c.newRequestID(),
+		Endpoint: endpoint,
+		Params:   data,
+		Start:    time.Now(),
+	}
+	info.Mutation = IsMutation(endpoint)
+	respBody, err := c.do(info, send)
+	info.Duration = time.Since(info.Start)
+	info.Err = err
+	if err == nil {
+		inspectPanelStatus(info, respBody)
+	}
+	info.Outcome = classifyOutcome(info, respBody)
+	for _, hook := range c.Hooks {
+		hook(info)
+	}
+	return respBody, err
+}
+
+// sendFunc 发送签名请求 状态码正常时返回未读取的响应
+type sendFunc func(info *RequestInfo, timeout time.Duration) (*http.Response, error)
+
+func (c *Client) do(info *RequestInfo, send sendFunc) ([]byte, error) {
+	resp, err := send(info, c.Timeout)
 	if err != nil {
-		panic(err)
+		return nil, err
 	}
-	nowTime := strconv.FormatInt(time.Now().Unix(), 10)
-	requestToken, requestTime := MD5(nowTime+MD5(c.BTKey)), nowTime
-	body := url.Values{
-		"request_token": {requestToken},
-		"request_time":  {requestTime},
+	defer resp.Body.Close()
+	respBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	return respBody, nil
+}
+
+// signedForm 在 data 基础上加入 request_token 和 request_time 签名字段 见 auth.BuildSignedFormAt
+func (c *Client) signedForm(data map[string][]string) url.Values {
+	now := time.Now
+	if c.Now != nil {
+		now = c.Now
 	}
+	// Close 会清除 BTKey 因此在锁内读取
+	c.mu.Lock()
+	key := c.BTKey
+	c.mu.Unlock()
+	body := auth.BuildSignedFormAt(key, now())
 	for k, v := range data {
 		body[k] = v
 	}
-	jar, err := cookiejar.New(nil)
+	return body
+}
+
+// send 发送签名请求 状态码正常时返回未读取的响应 由调用方关闭 Body
+func (c *Client) send(info *RequestInfo, timeout time.Duration) (*http.Response, error) {
+	body := c.signedForm(info.Params)
+	info.form = body
+	return c.post(info, timeout, "application/x-www-form-urlencoded", strings.NewReader(body.Encode()))
+}
+
+// endpointURL 返回接口的完整 URL 配置了 RewriteEndpoint 时先改写路径
+func (c *Client) endpointURL(endpoint string) string {
+	if c.RewriteEndpoint != nil {
+		endpoint = c.RewriteEndpoint(endpoint)
+	}
+	return c.BTAddress + endpoint
+}
+
+// post 以 contentType 发送已包含签名的 body
+func (c *Client) post(info *RequestInfo, timeout time.Duration, contentType string, body io.Reader) (*http.Response, error) {
+	client, err := c.httpClient()
+	if err != nil {
+		return nil, err
+	}
+	requestURL, err := url.Parse(c.endpointURL(info.Endpoint))
 	if err != nil {
 		panic(err)
 	}
-	client := &http.Client{
-		Jar:     jar,
-		Timeout: c.Timeout,
+	info.url = requestURL.String()
+	ctx, cancel := info.Context(), context.CancelFunc(func() {})
+	if timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, timeout)
 	}
-	if len(c.cookies) != 0 {
-		client.Jar.SetCookies(requestURL, c.cookies)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, requestURL.String(), body)
+	if err != nil {
+		cancel()
+		return nil, err
 	}
-	resp, err := client.PostForm(requestURL.String(), body)
+	req.Header.Set("Content-Type", contentType)
+	req.Header.Set(RequestIDHeader, info.ID)
+	resp, err := client.Do(req)
 	if err != nil {
+		cancel()
 		return nil, err
 	}
+	// 超时覆盖到读取完 Body 关闭 Body 时释放
+	resp.Body = &cancelBody{ReadCloser: resp.Body, cancel: cancel}
+	info.StatusCode = resp.StatusCode
 	if resp.StatusCode >= 400 {
-		return nil, errors.New(resp.Status)
+		defer resp.Body.Close()
+		body, _ := ioutil.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+		return nil, &APIError{
+			HTTPStatus: resp.StatusCode,
+			Msg:        resp.Status,
+			Endpoint:   info.Endpoint,
+			Body:       body,
+		}
 	}
-	// 保存每次返回的 cookies
-	c.cookies = resp.Cookies()
-	respBody, err := ioutil.ReadAll(resp.Body)
+	return resp, nil
+}
+
+// cancelBody 关闭时取消请求的 ctx
+type cancelBody struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
+
+// decodeMSG 解析通用消息结构 配置了 Translator 时一并翻译 Msg
+func (c *Client) decodeMSG(resp []byte) (RespMSG, error) {
+	var dec RespMSG
+	if err := c.decode(resp, &dec); err != nil {
+		return RespMSG{}, err
+	}
+	if c.Translator != nil {
+		dec.Translate(c.Translator)
+	}
+	return dec, nil
+}
+
+// decodeResult 解析变更类接口 endpoint 返回的通用消息 面板返回 status 为 false 时一并返回 *APIError
+func (c *Client) decodeResult(resp []byte, endpoint string) (RespMSG, error) {
+	dec, err := c.decodeMSG(resp)
 	if err != nil {
-		return nil, err
+		return RespMSG{}, err
 	}
-	return respBody, nil
+	if !dec.Status {
+		return dec, newPanelError(endpoint, dec.Msg, resp)
+	}
+	return dec, nil
+}
+
+// btResult 调用变更类接口并解析返回的通用消息 见 decodeResult
+func (c *Client) btResult(ctx context.Context, data map[string][]string, endpoint string) (RespMSG, error) {
+	resp, err := c.btAPI(ctx, data, endpoint)
+	if err != nil {
+		return RespMSG{}, err
+	}
+	return c.decodeResult(resp, endpoint)
 }
 
 // Deprecated: Used only for debug
 // 执行无封装 API 调用
-func (c *Client) Raw(data map[string][]string, endpoint string) ([]byte, error) {
-	return c.btAPI(data, endpoint)
+func (c *Client) Raw(ctx context.Context, data map[string][]string, endpoint string) ([]byte, error) {
+	return c.btAPI(ctx, data, endpoint)
 }
 
 // GetNetWork 获取实时状态信息(CPU、内存、网络、负载)
-func (c *Client) GetNetWork() (NetWork, error) {
-	resp, err := c.btAPI(map[string][]string{}, "/system?action=GetNetWork")
+func (c *Client) GetNetWork(ctx context.Context) (NetWork, error) {
+	resp, err := c.btAPI(ctx, map[string][]string{}, "/system?action=GetNetWork")
 	if err != nil {
 		return NetWork{}, err
 	}
 	var dec NetWork
-	if err := json.Unmarshal(resp, &dec); err != nil {
+	if err := c.unmarshal(ctx, resp, &dec); err != nil {
 		return NetWork{}, err
 	}
 	return dec, nil
 }
 
+// GetNetWorkByInterface 获取指定网卡的实时流量 面板未返回分网卡数据或网卡不存在时返回错误
+func (c *Client) GetNetWorkByInterface(ctx context.Context, name string) (NetWorkIO, error) {
+	dec, err := c.GetNetWork(ctx)
+	if err != nil {
+		return NetWorkIO{}, err
+	}
+	if len(dec.Network) == 0 {
+		return NetWorkIO{}, errors.New("panel does not report per-interface traffic")
+	}
+	nic, ok := dec.Network[name]
+	if !ok {
+		return NetWorkIO{}, errors.New("network interface not found: " + name)
+	}
+	return nic, nil
+}
+
 // GetSystemTotal 获取系统基础统计
-func (c *Client) GetSystemTotal() (SystemTotal, error) {
-	resp, err := c.btAPI(map[string][]string{}, "/system?action=GetSystemTotal")
+func (c *Client) GetSystemTotal(ctx context.Context) (SystemTotal, error) {
+	resp, err := c.btAPI(ctx, map[string][]string{}, "/system?action=GetSystemTotal")
 	if err != nil {
 		return SystemTotal{}, err
 	}
 	var dec SystemTotal
-	if err := json.Unmarshal(resp, &dec); err != nil {
+	if err := c.unmarshal(ctx, resp, &dec); err != nil {
 		return SystemTotal{}, err
 	}
 	return dec, nil
 }
 
 // GetDiskInfo 获取磁盘分区信息
-func (c *Client) GetDiskInfo() (DiskInfo, error) {
-	resp, err := c.btAPI(map[string][]string{}, "/system?action=GetDiskInfo")
+func (c *Client) GetDiskInfo(ctx context.Context) (DiskInfo, error) {
+	resp, err := c.btAPI(ctx, map[string][]string{}, "/system?action=GetDiskInfo")
 	if err != nil {
 		return DiskInfo{}, err
 	}
 	var dec DiskInfo
-	if err := json.Unmarshal(resp, &dec); err != nil {
+	if err := c.unmarshal(ctx, resp, &dec); err != nil {
 		return DiskInfo{}, err
 	}
 	return dec, nil
 }
 
-// GetTaskCount 检查是否有安装任务
-func (c *Client) GetTaskCount() int {
-	resp, err := c.btAPI(map[string][]string{}, "/ajax?action=GetTaskCount")
+// GetTaskCount 检查是否有安装任务 返回正在进行的任务数
+func (c *Client) GetTaskCount(ctx context.Context) (int, error) {
+	resp, err := c.btAPI(ctx, map[string][]string{}, "/ajax?action=GetTaskCount")
 	if err != nil {
-		return 0
+		return 0, err
 	}
-	dec, err := strconv.Atoi(string(resp))
-	if err != nil {
-		return 0
+	var dec int
+	if err := DecodeBare(resp, &dec); err != nil {
+		// 面板拒绝请求时（eg. IP 校验失败）返回通用消息
+		var apiErr *APIError
+		if _, perr := c.decodeResult(resp, "/ajax?action=GetTaskCount"); errors.As(perr, &apiErr) {
+			return 0, perr
+		}
+		return 0, newDecodeError(&dec, resp, err)
 	}
-	return dec
+	return dec, nil
 }
 
 // GetPHPVersion 获取已安装的 PHP 版本列表
-func (c *Client) GetPHPVersion() (PHPVersions, error) {
-	resp, err := c.btAPI(map[string][]string{}, "/site?action=GetPHPVersion")
+func (c *Client) GetPHPVersion(ctx context.Context) (PHPVersions, error) {
+	resp, err := c.btAPI(ctx, map[string][]string{}, "/site?action=GetPHPVersion")
 	if err != nil {
 		return PHPVersions{}, err
 	}
 	var dec PHPVersions
-	if err := json.Unmarshal(resp, &dec); err != nil {
+	if err := c.unmarshal(ctx, resp, &dec); err != nil {
 		return PHPVersions{}, err
 	}
 	return dec, nil
 }
 
 // GetUpdateStatus 检查面板更新
-func (c *Client) GetUpdateStatus(check bool, force bool) (UpdateStatus, error) {
+func (c *Client) GetUpdateStatus(ctx context.Context, check bool, force bool) (UpdateStatus, error) {
 	data := map[string][]string{
 		"check": {strconv.FormatBool(check)},
 		"force": {strconv.FormatBool(force)},
 	}
-	resp, err := c.btAPI(data, "/ajax?action=UpdatePanel")
+	resp, err := c.btAPI(ctx, data, "/ajax?action=UpdatePanel")
 	if err != nil {
 		return UpdateStatus{}, err
 	}
 	var dec UpdateStatus
-	if err := json.Unmarshal(resp, &dec); err != nil {
+	if err := c.unmarshal(ctx, resp, &dec); err != nil {
 		return UpdateStatus{}, err
 	}
 	return dec, nil
 }
 
 // GetSites 获取网站列表
-func (c *Client) GetSites(params *ReqSites) (RespSites, error) {
-	data := map[string][]string{
-		"p":      {strconv.FormatInt(params.P, 10)},
-		"limit":  {strconv.FormatInt(params.Limit, 10)},
-		"type":   {strconv.FormatInt(params.Type, 10)},
-		"order":  {params.Order},
-		"tojs":   {params.ToJS},
-		"search": {params.Search},
+func (c *Client) GetSites(ctx context.Context, params *ReqSites) (RespSites, error) {
+	data, err := params.form()
+	if err != nil {
+		return RespSites{}, err
 	}
-	resp, err := c.btAPI(data, "/data?action=getData&table=sites")
+	resp, err := c.btAPI(ctx, data, "/data?action=getData&table=sites")
 	if err != nil {
 		return RespSites{}, err
 	}
 	var dec RespSites
-	if err := json.Unmarshal(resp, &dec); err != nil {
+	if err := c.unmarshal(ctx, resp, &dec); err != nil {
 		return RespSites{}, err
 	}
 	return dec, nil
 }
 
+// PHPVersionStatic 纯静态网站（不使用 PHP）的版本号
+const PHPVersionStatic int64 = 0
+
+// phpVersionParam 面板以 00 表示纯静态
+func phpVersionParam(version int64) string {
+	if version == PHPVersionStatic {
+		return "00"
+	}
+	return strconv.FormatInt(version, 10)
+}
+
 // AddSite 创建网站
-func (c *Client) AddSite(params *ReqAddSite) (RespAddSite, error) {
+func (c *Client) AddSite(ctx context.Context, params *ReqAddSite) (RespAddSite, error) {
+	if err := params.WebName.Validate(); err != nil {
+		return RespAddSite{}, err
+	}
 	webname, err := json.Marshal(params.WebName)
-	// fmt.Println(string(webname))
 	if err != nil {
 		return RespAddSite{}, err
 	}
@@ -197,7 +395,7 @@ func (c *Client) AddSite(params *ReqAddSite) (RespAddSite, error) {
 		"path":         {params.Path},
 		"type_id":      {strconv.FormatInt(params.TypeID, 10)},
 		"type":         {params.Type},
-		"version":      {strconv.FormatInt(params.Version, 10)},
+		"version":      {phpVersionParam(params.Version)},
 		"port":         {strconv.FormatInt(params.Port, 10)},
 		"ps":           {params.PS},
 		"ftp":          {strconv.FormatBool(params.FTP)},
@@ -208,19 +406,33 @@ func (c *Client) AddSite(params *ReqAddSite) (RespAddSite, error) {
 		"datauser":     {params.DataUser},
 		"datapassword": {params.DataPassword},
 	}
-	resp, err := c.btAPI(data, "/site?action=AddSite")
+	key := params.IdempotencyKey
+	if key == "" {
+		key = addSiteIdempotencyKey(params.WebName.Domain)
+	}
+	resp, err := c.idempotent(key, func() ([]byte, error) {
+		return c.btAPI(ctx, data, "/site?action=AddSite")
+	}, func(resp []byte) bool {
+		var dec RespAddSite
+		return c.unmarshal(ctx, resp, &dec) == nil && dec.SiteStatus
+	})
 	if err != nil {
 		return RespAddSite{}, err
 	}
 	var dec RespAddSite
-	if err := json.Unmarshal(resp, &dec); err != nil {
+	if err := c.unmarshal(ctx, resp, &dec); err != nil {
 		return RespAddSite{}, err
 	}
+	if !dec.SiteStatus {
+		// 创建失败时面板返回的是通用消息
+		msg, _ := c.decodeMSG(resp)
+		return dec, newPanelError("/site?action=AddSite", msg.Msg, resp)
+	}
 	return dec, nil
 }
 
 // DeleteSite 删除网站
-func (c *Client) DeleteSite(params *ReqDeleteSite) (RespMSG, error) {
+func (c *Client) DeleteSite(ctx context.Context, params *ReqDeleteSite) (RespMSG, error) {
 	data := map[string][]string{
 		"id":      {strconv.FormatInt(params.ID, 10)},
 		"webname": {params.WebName},
@@ -234,72 +446,74 @@ func (c *Client) DeleteSite(params *ReqDeleteSite) (RespMSG, error) {
 	if params.Path {
 		data["path"] = []string{"1"}
 	}
-	resp, _ := c.btAPI(data, "/site?action=DeleteSite")
-	var dec RespMSG
-	if err := json.Unmarshal(resp, &dec); err != nil {
-		return RespMSG{}, err
+	ret, err := c.btResult(ctx, data, "/site?action=DeleteSite")
+	if err == nil {
+		// 删除后允许以相同的默认幂等键重新创建
+		c.forgetIdempotent(addSiteIdempotencyKey(params.WebName))
 	}
-	return dec, nil
+	return ret, err
 }
 
 // StopSite 停止网站
-func (c *Client) StopSite(id int64, name string) (RespMSG, error) {
+func (c *Client) StopSite(ctx context.Context, id int64, name string) (RespMSG, error) {
 	data := map[string][]string{
 		"id":   {strconv.FormatInt(id, 10)},
 		"name": {name},
 	}
-	resp, _ := c.btAPI(data, "/site?action=SiteStop")
-	var dec RespMSG
-	if err := json.Unmarshal(resp, &dec); err != nil {
-		return RespMSG{}, err
-	}
-	return dec, nil
+	return c.btResult(ctx, data, "/site?action=SiteStop")
 }
 
 // StartSite 启动网站
-func (c *Client) StartSite(id int64, name string) (RespMSG, error) {
+func (c *Client) StartSite(ctx context.Context, id int64, name string) (RespMSG, error) {
 	data := map[string][]string{
 		"id":   {strconv.FormatInt(id, 10)},
 		"name": {name},
 	}
-	resp, _ := c.btAPI(data, "/site?action=SiteStart")
-	var dec RespMSG
-	if err := json.Unmarshal(resp, &dec); err != nil {
-		return RespMSG{}, err
-	}
-	return dec, nil
+	return c.btResult(ctx, data, "/site?action=SiteStart")
 }
 
 // SetSiteEdate 设置网站过期时间 格式 “0000-00-00”（全 0 为永久）
-func (c *Client) SetSiteEdate(id int64, edate string) (RespMSG, error) {
+// 推荐使用 SetSiteExpiration/ClearSiteExpiration
+func (c *Client) SetSiteEdate(ctx context.Context, id int64, edate string) (RespMSG, error) {
 	data := map[string][]string{
 		"id":    {strconv.FormatInt(id, 10)},
 		"edate": {edate},
 	}
-	resp, _ := c.btAPI(data, "/site?action=SetEdate")
-	var dec RespMSG
-	if err := json.Unmarshal(resp, &dec); err != nil {
-		return RespMSG{}, err
+	return c.btResult(ctx, data, "/site?action=SetEdate")
+}
+
+// SiteNeverExpires 面板表示网站永不过期的日期
+const SiteNeverExpires = "0000-00-00"
+
+// SetSiteExpiration 设置网站到期时间 仅取 t 在其时区下的日期部分 不能早于今天
+func (c *Client) SetSiteExpiration(ctx context.Context, id int64, t time.Time) (RespMSG, error) {
+	if t.IsZero() {
+		return RespMSG{}, errors.New("expiration time is zero, use ClearSiteExpiration instead")
 	}
-	return dec, nil
+	now := time.Now().In(t.Location())
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, t.Location())
+	if t.Before(today) {
+		return RespMSG{}, errors.New("expiration date is in the past: " + t.Format("2006-01-02"))
+	}
+	return c.SetSiteEdate(ctx, id, t.Format("2006-01-02"))
+}
+
+// ClearSiteExpiration 设置网站永不过期
+func (c *Client) ClearSiteExpiration(ctx context.Context, id int64) (RespMSG, error) {
+	return c.SetSiteEdate(ctx, id, SiteNeverExpires)
 }
 
 // SetSitePS 设置网站备注
-func (c *Client) SetSitePS(id int64, ps string) (RespMSG, error) {
+func (c *Client) SetSitePS(ctx context.Context, id int64, ps string) (RespMSG, error) {
 	data := map[string][]string{
 		"id": {strconv.FormatInt(id, 10)},
 		"ps": {ps},
 	}
-	resp, _ := c.btAPI(data, "/data?action=setPs&table=sites")
-	var dec RespMSG
-	if err := json.Unmarshal(resp, &dec); err != nil {
-		return RespMSG{}, err
-	}
-	return dec, nil
+	return c.btResult(ctx, data, "/data?action=setPs&table=sites")
 }
 
 // GetSiteBackups 获取网站备份列表
-func (c *Client) GetSiteBackups(params *ReqSiteBackups) (RespSiteBackups, error) {
+func (c *Client) GetSiteBackups(ctx context.Context, params *ReqSiteBackups) (RespSiteBackups, error) {
 	data := map[string][]string{
 		"p":      {strconv.FormatInt(params.P, 10)},
 		"limit":  {strconv.FormatInt(params.Limit, 10)},
@@ -307,58 +521,48 @@ func (c *Client) GetSiteBackups(params *ReqSiteBackups) (RespSiteBackups, error)
 		"tojs":   {params.ToJS},
 		"search": {strconv.FormatInt(params.Search, 10)},
 	}
-	resp, err := c.btAPI(data, "/data?action=getData&table=backup")
+	resp, err := c.btAPI(ctx, data, "/data?action=getData&table=backup")
 	// fmt.Println(string(resp))
 	if err != nil {
 		return RespSiteBackups{}, err
 	}
 	var dec RespSiteBackups
-	if err := json.Unmarshal(resp, &dec); err != nil {
+	if err := c.unmarshal(ctx, resp, &dec); err != nil {
 		return RespSiteBackups{}, err
 	}
 	return dec, nil
 }
 
 // SiteBackup 创建网站备份
-func (c *Client) SiteBackup(id int64) (RespMSG, error) {
+func (c *Client) SiteBackup(ctx context.Context, id int64) (RespMSG, error) {
 	data := map[string][]string{
 		"id": {strconv.FormatInt(id, 10)},
 	}
-	resp, _ := c.btAPI(data, "/site?action=ToBackup")
-	var dec RespMSG
-	if err := json.Unmarshal(resp, &dec); err != nil {
-		return RespMSG{}, err
-	}
-	return dec, nil
+	return c.btResult(ctx, data, "/site?action=ToBackup")
 }
 
 // DeleteSiteBackup 删除网站备份
-func (c *Client) DeleteSiteBackup(id int64) (RespMSG, error) {
+func (c *Client) DeleteSiteBackup(ctx context.Context, id int64) (RespMSG, error) {
 	data := map[string][]string{
 		"id": {strconv.FormatInt(id, 10)},
 	}
-	resp, _ := c.btAPI(data, "/site?action=DelBackup")
-	var dec RespMSG
-	if err := json.Unmarshal(resp, &dec); err != nil {
-		return RespMSG{}, err
-	}
-	return dec, nil
+	return c.btResult(ctx, data, "/site?action=DelBackup")
 }
 
 // GetSiteDomains 获取网站域名列表
-func (c *Client) GetSiteDomains(keyWords ...string) (SiteDomains, error) {
+func (c *Client) GetSiteDomains(ctx context.Context, keyWords ...string) (SiteDomains, error) {
 	data := map[string][]string{
 		"list": {"true"},
 	}
 	if len(keyWords) != 0 {
 		data["search"] = keyWords
 	}
-	resp, err := c.btAPI(data, "/data?action=getData&table=domain")
+	resp, err := c.btAPI(ctx, data, "/data?action=getData&table=domain")
 	if err != nil {
 		return SiteDomains{}, err
 	}
 	var dec SiteDomains
-	if err := json.Unmarshal(resp, &dec); err != nil {
+	if err := c.unmarshal(ctx, resp, &dec); err != nil {
 		return SiteDomains{}, err
 	}
 	return dec, nil
@@ -368,236 +572,208 @@ func (c *Client) GetSiteDomains(keyWords ...string) (SiteDomains, error) {
 // id 网站ID-必填
 // webname 网站名称-必填
 // domain 域名-必填
-func (c *Client) AddDomain(id int64, webname string, domain string) (RespMSG, error) {
+func (c *Client) AddDomain(ctx context.Context, id int64, webname string, domain string) (RespMSG, error) {
 	data := map[string][]string{
 		"id":      {strconv.FormatInt(id, 10)},
 		"webname": {webname},
 		"domain":  {domain},
 	}
-	resp, _ := c.btAPI(data, "/site?action=AddDomain")
-	var dec RespMSG
-	if err := json.Unmarshal(resp, &dec); err != nil {
+	resp, err := c.idempotent(addDomainIdempotencyKey(id, domain), func() ([]byte, error) {
+		return c.btAPI(ctx, data, "/site?action=AddDomain")
+	}, func(resp []byte) bool {
+		var dec RespMSG
+		return c.unmarshal(ctx, resp, &dec) == nil && dec.Status
+	})
+	if err != nil {
 		return RespMSG{}, err
 	}
-	return dec, nil
+	dec, err := c.decodeResult(resp, "/site?action=AddDomain")
+	if err != nil {
+		return dec, err
+	}
+	return dec, c.notifyDomainChange(id, webname, domain, 0, true)
 }
 
 // DelDomain 网站删除域名
 // id 网站ID-必填
 // webname 网站名称-必填
 // domain 域名-必填
-func (c *Client) DelDomain(id int64, webname string, domain string, port int64) (RespMSG, error) {
+func (c *Client) DelDomain(ctx context.Context, id int64, webname string, domain string, port int64) (RespMSG, error) {
 	data := map[string][]string{
 		"id":      {strconv.FormatInt(id, 10)},
 		"webname": {webname},
 		"domain":  {domain},
 		"port":    {strconv.FormatInt(port, 10)},
 	}
-	resp, _ := c.btAPI(data, "/site?action=DelDomain")
-	var dec RespMSG
-	if err := json.Unmarshal(resp, &dec); err != nil {
+	resp, err := c.btAPI(ctx, data, "/site?action=DelDomain")
+	if err != nil {
 		return RespMSG{}, err
 	}
-	return dec, nil
+	dec, err := c.decodeResult(resp, "/site?action=DelDomain")
+	if err != nil {
+		return dec, err
+	}
+	c.forgetIdempotent(addDomainIdempotencyKey(id, domain))
+	return dec, c.notifyDomainChange(id, webname, domain, port, false)
 }
 
 // GetRewriteList 获取网站可选伪静态列表
-func (c *Client) GetRewriteList(siteName string) (RewriteList, error) {
+func (c *Client) GetRewriteList(ctx context.Context, siteName string) (RewriteList, error) {
 	data := map[string][]string{
 		"siteName": {siteName},
 	}
-	resp, err := c.btAPI(data, "/site?action=GetRewriteList")
+	resp, err := c.cached(ctx, "GetRewriteList:"+siteName, func() ([]byte, error) {
+		return c.btAPI(ctx, data, "/site?action=GetRewriteList")
+	}, func(b []byte) bool {
+		var v RewriteList
+		return json.Unmarshal(b, &v) == nil && v.Rewrites != nil
+	})
 	if err != nil {
 		return RewriteList{}, err
 	}
 	var dec RewriteList
-	if err := json.Unmarshal(resp, &dec); err != nil {
+	if err := c.unmarshal(ctx, resp, &dec); err != nil {
 		return RewriteList{}, err
 	}
 	return dec, nil
 }
 
 // GetFile 获取文件
-func (c *Client) GetFile(path string) (RespGetFile, error) {
+func (c *Client) GetFile(ctx context.Context, path string) (RespGetFile, error) {
 	data := map[string][]string{
 		"path": {path},
 	}
-	resp, err := c.btAPI(data, "/files?action=GetFileBody")
+	resp, err := c.btAPI(ctx, data, "/files?action=GetFileBody")
 	if err != nil {
 		return RespGetFile{}, err
 	}
 	var dec RespGetFile
-	if err := json.Unmarshal(resp, &dec); err != nil {
+	if err := c.unmarshal(ctx, resp, &dec); err != nil {
 		return RespGetFile{}, err
 	}
 	return dec, nil
 }
 
 // SetFile 修改文件（无法新建文件）
-func (c *Client) SetFile(path string, body string) (RespMSG, error) {
+func (c *Client) SetFile(ctx context.Context, path string, body string) (RespMSG, error) {
 	data := map[string][]string{
 		"path":     {path},
 		"data":     {body},
 		"encoding": {"utf-8"},
 	}
-	resp, _ := c.btAPI(data, "/files?action=SaveFileBody")
-	var dec RespMSG
-	if err := json.Unmarshal(resp, &dec); err != nil {
-		return RespMSG{}, err
-	}
-	return dec, nil
+	return c.btResult(ctx, data, "/files?action=SaveFileBody")
 }
 
 // GetDirUserINI 取回防跨站配置/运行目录/日志开关状态/可设置的运行目录列表/密码访问状态
-func (c *Client) GetDirUserINI(id int64, path string) (RespUserINI, error) {
+func (c *Client) GetDirUserINI(ctx context.Context, id int64, path string) (RespUserINI, error) {
 	data := map[string][]string{
 		"id":   {strconv.FormatInt(id, 10)},
 		"path": {path},
 	}
-	resp, err := c.btAPI(data, "/site?action=GetDirUserINI")
+	resp, err := c.btAPI(ctx, data, "/site?action=GetDirUserINI")
 	if err != nil {
 		return RespUserINI{}, err
 	}
 	var dec RespUserINI
-	if err := json.Unmarshal(resp, &dec); err != nil {
+	if err := c.unmarshal(ctx, resp, &dec); err != nil {
 		return RespUserINI{}, err
 	}
 	return dec, nil
 }
 
 // SetDirUserINI 设置防跨站状态（自动取反）
-func (c *Client) SetDirUserINI(path string) (RespMSG, error) {
+func (c *Client) SetDirUserINI(ctx context.Context, path string) (RespMSG, error) {
 	data := map[string][]string{
 		"path": {path},
 	}
-	resp, _ := c.btAPI(data, "/site?action=SetDirUserINI")
-	var dec RespMSG
-	if err := json.Unmarshal(resp, &dec); err != nil {
-		return RespMSG{}, err
-	}
-	return dec, nil
+	return c.btResult(ctx, data, "/site?action=SetDirUserINI")
 }
 
 // SetLogsOpen 设置是否写访问日志
-func (c *Client) SetLogsOpen(id int64) (RespMSG, error) {
+func (c *Client) SetLogsOpen(ctx context.Context, id int64) (RespMSG, error) {
 	data := map[string][]string{
 		"id": {strconv.FormatInt(id, 10)},
 	}
-	resp, _ := c.btAPI(data, "/site?action=logsOpen")
-	var dec RespMSG
-	if err := json.Unmarshal(resp, &dec); err != nil {
-		return RespMSG{}, err
-	}
-	return dec, nil
+	return c.btResult(ctx, data, "/site?action=logsOpen")
 }
 
 // SetPath 修改网站根目录
-func (c *Client) SetPath(id int64, path string) (RespMSG, error) {
+func (c *Client) SetPath(ctx context.Context, id int64, path string) (RespMSG, error) {
 	data := map[string][]string{
 		"id":   {strconv.FormatInt(id, 10)},
 		"path": {path},
 	}
-	resp, _ := c.btAPI(data, "/site?action=SetPath")
-	var dec RespMSG
-	if err := json.Unmarshal(resp, &dec); err != nil {
-		return RespMSG{}, err
-	}
-	return dec, nil
+	return c.btResult(ctx, data, "/site?action=SetPath")
 }
 
 // SetRunPath 修改网站运行目录 path 填相对目录 比如 "/public"
-func (c *Client) SetRunPath(id int64, path string) (RespMSG, error) {
+func (c *Client) SetRunPath(ctx context.Context, id int64, path string) (RespMSG, error) {
 	data := map[string][]string{
 		"id":      {strconv.FormatInt(id, 10)},
 		"runPath": {path},
 	}
-	resp, _ := c.btAPI(data, "/site?action=SetSiteRunPath")
-	var dec RespMSG
-	if err := json.Unmarshal(resp, &dec); err != nil {
-		return RespMSG{}, err
-	}
-	return dec, nil
+	return c.btResult(ctx, data, "/site?action=SetSiteRunPath")
 }
 
 // SetHasPwd 打开并设置网站密码访问
-func (c *Client) SetHasPwd(id int64, user string, pwd string) (RespMSG, error) {
+func (c *Client) SetHasPwd(ctx context.Context, id int64, user string, pwd string) (RespMSG, error) {
 	data := map[string][]string{
 		"id":       {strconv.FormatInt(id, 10)},
 		"username": {user},
 		"password": {pwd},
 	}
-	resp, _ := c.btAPI(data, "/site?action=SetHasPwd")
-	var dec RespMSG
-	if err := json.Unmarshal(resp, &dec); err != nil {
-		return RespMSG{}, err
-	}
-	return dec, nil
+	return c.btResult(ctx, data, "/site?action=SetHasPwd")
 }
 
 // CloseHasPwd 关闭网站密码访问
-func (c *Client) CloseHasPwd(id int64) (RespMSG, error) {
+func (c *Client) CloseHasPwd(ctx context.Context, id int64) (RespMSG, error) {
 	data := map[string][]string{
 		"id": {strconv.FormatInt(id, 10)},
 	}
-	resp, _ := c.btAPI(data, "/site?action=CloseHasPwd")
-	var dec RespMSG
-	if err := json.Unmarshal(resp, &dec); err != nil {
-		return RespMSG{}, err
-	}
-	return dec, nil
+	return c.btResult(ctx, data, "/site?action=CloseHasPwd")
 }
 
 // GetLimitNet 获取流量限制相关配置（仅支持 nginx）
-func (c *Client) GetLimitNet(id int64) (RespLimitNet, error) {
+func (c *Client) GetLimitNet(ctx context.Context, id int64) (RespLimitNet, error) {
 	data := map[string][]string{
 		"id": {strconv.FormatInt(id, 10)},
 	}
-	resp, err := c.btAPI(data, "/site?action=GetLimitNet")
+	resp, err := c.btAPI(ctx, data, "/site?action=GetLimitNet")
 	if err != nil {
-		return RespLimitNet{}, errors.New(string(resp))
+		return RespLimitNet{}, err
 	}
 	var dec RespLimitNet
-	if err := json.Unmarshal(resp, &dec); err != nil {
+	if err := c.unmarshal(ctx, resp, &dec); err != nil {
 		return RespLimitNet{}, err
 	}
 	return dec, nil
 }
 
-// SetLimitNet 开启或保存流量限制配置（仅支持 nginx）
-func (c *Client) SetLimitNet(id int64, perServer int64, perIP int64, limitRate int64) (RespMSG, error) {
+// SetLimitNet 开启或保存流量限制配置（仅支持 nginx）只需限制下载速度时使用 SetSiteLimitRate
+func (c *Client) SetLimitNet(ctx context.Context, id int64, perServer int64, perIP int64, limitRate int64) (RespMSG, error) {
 	data := map[string][]string{
 		"id":         {strconv.FormatInt(id, 10)},
 		"perserver":  {strconv.FormatInt(perServer, 10)},
 		"perip":      {strconv.FormatInt(perIP, 10)},
 		"limit_rate": {strconv.FormatInt(limitRate, 10)},
 	}
-	resp, _ := c.btAPI(data, "/site?action=SetLimitNet")
-	var dec RespMSG
-	if err := json.Unmarshal(resp, &dec); err != nil {
-		return RespMSG{}, err
-	}
-	return dec, nil
+	return c.btResult(ctx, data, "/site?action=SetLimitNet")
 }
 
 // CloseLimitNet 关闭流量限制
-func (c *Client) CloseLimitNet(id int64) (RespMSG, error) {
+func (c *Client) CloseLimitNet(ctx context.Context, id int64) (RespMSG, error) {
 	data := map[string][]string{
 		"id": {strconv.FormatInt(id, 10)},
 	}
-	resp, _ := c.btAPI(data, "/site?action=CloseLimitNet")
-	var dec RespMSG
-	if err := json.Unmarshal(resp, &dec); err != nil {
-		return RespMSG{}, err
-	}
-	return dec, nil
+	return c.btResult(ctx, data, "/site?action=CloseLimitNet")
 }
 
 // GetIndex 取默认文档信息
-func (c *Client) GetIndex(id int64) (string, error) {
+func (c *Client) GetIndex(ctx context.Context, id int64) (string, error) {
 	data := map[string][]string{
 		"id": {strconv.FormatInt(id, 10)},
 	}
-	resp, err := c.btAPI(data, "/site?action=GetIndex")
+	resp, err := c.btAPI(ctx, data, "/site?action=GetIndex")
 	if err != nil {
 		return "", err
 	}
@@ -605,22 +781,15 @@ func (c *Client) GetIndex(id int64) (string, error) {
 }
 
 // SetIndex 设置默认文档 ep. Index : "index.php,index.html,index.htm,default.php,default.htm,default.html"
-func (c *Client) SetIndex(id int64, Index string) (RespMSG, error) {
+func (c *Client) SetIndex(ctx context.Context, id int64, Index string) (RespMSG, error) {
 	data := map[string][]string{
 		"id":    {strconv.FormatInt(id, 10)},
 		"Index": {Index},
 	}
-	resp, _ := c.btAPI(data, "/site?action=SetIndex")
-	var dec RespMSG
-	if err := json.Unmarshal(resp, &dec); err != nil {
-		return RespMSG{}, err
-	}
-	return dec, nil
+	return c.btResult(ctx, data, "/site?action=SetIndex")
 }
 
-// MD5 Generate 32-bit MD5 strings
+// MD5 Generate 32-bit MD5 strings 同 auth.MD5
 func MD5(s string) string {
-	h := md5.New()
-	h.Write([]byte(s))
-	return hex.EncodeToString(h.Sum(nil))
+	return auth.MD5(s)
 }
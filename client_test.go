@@ -1,34 +1,41 @@
+//go:build integration
+
 package bt
 
 import (
+	"context"
 	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
 	"testing"
 	"time"
 )
 
 var client *Client
 
-// 运行全部测试时请先填写对应信息
-// 提供所有成员函数调用示例
+// 连接真实面板的集成测试 提供所有成员函数调用示例
+// 运行方式见 integration_test.go
 
 func init() {
-	client = NewClient("http://10.0.0.14:8888", "qviqWLiiUB623bfzJqQ37OGUEXwOXtVN", 1*time.Second)
+	client = integrationClient()
 }
 
 func Test(t *testing.T) {
-	r1, _ := client.Raw(map[string][]string{
+	r1, _ := client.Raw(ctx, map[string][]string{
 		"id": {"24"},
 	}, "/site?action=GetIndex")
 	fmt.Println(string(r1))
 }
 
 func TestClient_GetNetWork(t *testing.T) {
-	r, err := client.GetNetWork()
+	r, err := client.GetNetWork(ctx)
 	fmt.Println("return:", r, err)
 }
 
 func TestClient_GetSystemTotal(t *testing.T) {
-	r, err := client.GetSystemTotal()
+	r, err := client.GetSystemTotal(ctx)
 	if err != nil {
 		fmt.Println(err)
 		t.Fail()
@@ -37,7 +44,7 @@ func TestClient_GetSystemTotal(t *testing.T) {
 }
 
 func TestClient_GetDiskInfo(t *testing.T) {
-	r, err := client.GetDiskInfo()
+	r, err := client.GetDiskInfo(ctx)
 	if err != nil {
 		fmt.Println(err)
 		t.Fail()
@@ -46,7 +53,7 @@ func TestClient_GetDiskInfo(t *testing.T) {
 }
 
 func TestClient_GetRewriteList(t *testing.T) {
-	r, err := client.GetRewriteList("10.0.0.14")
+	r, err := client.GetRewriteList(ctx, "10.0.0.14")
 	if err != nil {
 		fmt.Println(err)
 		t.Fail()
@@ -55,7 +62,7 @@ func TestClient_GetRewriteList(t *testing.T) {
 }
 
 func TestClient_GetSites(t *testing.T) {
-	r, err := client.GetSites(&ReqSites{
+	r, err := client.GetSites(ctx, &ReqSites{
 		P:     1,
 		Limit: 15,
 	})
@@ -67,7 +74,7 @@ func TestClient_GetSites(t *testing.T) {
 }
 
 func TestClient_GetSiteDomains(t *testing.T) {
-	r, err := client.GetSiteDomains()
+	r, err := client.GetSiteDomains(ctx)
 	if err != nil {
 		fmt.Println(err)
 		t.Fail()
@@ -76,12 +83,16 @@ func TestClient_GetSiteDomains(t *testing.T) {
 }
 
 func TestClient_GetTaskCount(t *testing.T) {
-	r := client.GetTaskCount()
+	r, err := client.GetTaskCount(ctx)
+	if err != nil {
+		fmt.Println(err)
+		t.Fail()
+	}
 	fmt.Println(r)
 }
 
 func TestClient_GetUpdateStatus(t *testing.T) {
-	r, err := client.GetUpdateStatus(true, false)
+	r, err := client.GetUpdateStatus(ctx, true, false)
 	if err != nil {
 		fmt.Println(err)
 		t.Fail()
@@ -90,7 +101,7 @@ func TestClient_GetUpdateStatus(t *testing.T) {
 }
 
 func TestClient_GetPHPVersion(t *testing.T) {
-	r, err := client.GetPHPVersion()
+	r, err := client.GetPHPVersion(ctx)
 	if err != nil {
 		fmt.Println(err)
 		t.Fail()
@@ -99,16 +110,8 @@ func TestClient_GetPHPVersion(t *testing.T) {
 }
 
 func TestClient_AddSite(t *testing.T) {
-	r, err := client.AddSite(&ReqAddSite{
-		WebName: struct {
-			Domain     string   `json:"domain"`
-			DomainList []string `json:"domainlist"`
-			Count      int      `json:"count"`
-		}{
-			Domain:     "w1.hao.com",
-			DomainList: []string{},
-			Count:      0,
-		},
+	r, err := client.AddSite(ctx, &ReqAddSite{
+		WebName:      NewWebName("w1.hao.com"),
 		Path:         "/www/wwwroot/w1.hao.com",
 		TypeID:       0,
 		Type:         "PHP",
@@ -131,7 +134,7 @@ func TestClient_AddSite(t *testing.T) {
 }
 
 func TestClient_AddDomain(t *testing.T) {
-	r2, err := client.AddDomain(11, "w1.hao.com", "w2.hao.com")
+	r2, err := client.AddDomain(ctx, 11, "w1.hao.com", "w2.hao.com")
 	if err != nil {
 		fmt.Println(err)
 		t.Fail()
@@ -140,7 +143,7 @@ func TestClient_AddDomain(t *testing.T) {
 }
 
 func TestClient_DelDomain(t *testing.T) {
-	r2, err := client.DelDomain(11, "w1.hao.com", "w2.hao.com", 80)
+	r2, err := client.DelDomain(ctx, 11, "w1.hao.com", "w2.hao.com", 80)
 	if err != nil {
 		fmt.Println(err)
 		t.Fail()
@@ -149,7 +152,7 @@ func TestClient_DelDomain(t *testing.T) {
 }
 
 func TestClient_SiteBackup(t *testing.T) {
-	r2, err := client.SiteBackup(11)
+	r2, err := client.SiteBackup(ctx, 11)
 	if err != nil {
 		fmt.Println(err)
 		t.Fail()
@@ -158,7 +161,7 @@ func TestClient_SiteBackup(t *testing.T) {
 }
 
 func TestClient_GetSiteBackups(t *testing.T) {
-	r2, err := client.GetSiteBackups(&ReqSiteBackups{
+	r2, err := client.GetSiteBackups(ctx, &ReqSiteBackups{
 		P:      1,
 		Limit:  15,
 		Search: 11,
@@ -171,7 +174,7 @@ func TestClient_GetSiteBackups(t *testing.T) {
 }
 
 func TestClient_DeleteSiteBackup(t *testing.T) {
-	r2, err := client.DeleteSiteBackup(540)
+	r2, err := client.DeleteSiteBackup(ctx, 540)
 	if err != nil {
 		fmt.Println(err)
 		t.Fail()
@@ -180,7 +183,7 @@ func TestClient_DeleteSiteBackup(t *testing.T) {
 }
 
 func TestClient_SetSitePS(t *testing.T) {
-	r2, err := client.SetSitePS(11, "testps")
+	r2, err := client.SetSitePS(ctx, 11, "testps")
 	if err != nil {
 		fmt.Println(err)
 		t.Fail()
@@ -189,7 +192,7 @@ func TestClient_SetSitePS(t *testing.T) {
 }
 
 func TestClient_SetSiteEdate(t *testing.T) {
-	r2, err := client.SetSiteEdate(11, "0000-00-00")
+	r2, err := client.SetSiteEdate(ctx, 11, "0000-00-00")
 	if err != nil {
 		fmt.Println(err)
 		t.Fail()
@@ -198,7 +201,7 @@ func TestClient_SetSiteEdate(t *testing.T) {
 }
 
 func TestClient_StopSite(t *testing.T) {
-	r2, err := client.StopSite(11, "w1.hao.com")
+	r2, err := client.StopSite(ctx, 11, "w1.hao.com")
 	if err != nil {
 		fmt.Println(err)
 		t.Fail()
@@ -207,7 +210,7 @@ func TestClient_StopSite(t *testing.T) {
 }
 
 func TestClient_StartSite(t *testing.T) {
-	r2, err := client.StartSite(11, "w1.hao.com")
+	r2, err := client.StartSite(ctx, 11, "w1.hao.com")
 	if err != nil {
 		fmt.Println(err)
 		t.Fail()
@@ -216,7 +219,7 @@ func TestClient_StartSite(t *testing.T) {
 }
 
 func TestClient_DeleteSite(t *testing.T) {
-	r2, err := client.DeleteSite(&ReqDeleteSite{
+	r2, err := client.DeleteSite(ctx, &ReqDeleteSite{
 		ID:       10,
 		WebName:  "w1.hao.com",
 		FTP:      true,
@@ -231,7 +234,7 @@ func TestClient_DeleteSite(t *testing.T) {
 }
 
 func TestClient_GetFile(t *testing.T) {
-	r2, err := client.GetFile("/www/wwwroot/w1.hao.com/index.html")
+	r2, err := client.GetFile(ctx, "/www/wwwroot/w1.hao.com/index.html")
 	if err != nil {
 		fmt.Println(err)
 		t.Fail()
@@ -240,7 +243,7 @@ func TestClient_GetFile(t *testing.T) {
 }
 
 func TestClient_SetFile(t *testing.T) {
-	r2, err := client.SetFile("/www/wwwroot/w1.hao.com/404.html", "new body")
+	r2, err := client.SetFile(ctx, "/www/wwwroot/w1.hao.com/404.html", "new body")
 	if err != nil {
 		fmt.Println(err)
 		t.Fail()
@@ -251,7 +254,7 @@ func TestClient_SetFile(t *testing.T) {
 // 取回防跨站配置/运行目录/日志开关状态/可设置的运行目录列表/密码访问状态
 // URI 地址：/site?action=GetDirUserINI
 func TestClient_GetDirUserINI(t *testing.T) {
-	r2, err := client.GetDirUserINI(11, "/www/wwwroot/w1.hao.com")
+	r2, err := client.GetDirUserINI(ctx, 11, "/www/wwwroot/w1.hao.com")
 	if err != nil {
 		fmt.Println(err)
 		t.Fail()
@@ -260,7 +263,7 @@ func TestClient_GetDirUserINI(t *testing.T) {
 }
 
 func TestClient_SetDirUserINI(t *testing.T) {
-	r2, err := client.SetDirUserINI("/www/wwwroot/w1.hao.com")
+	r2, err := client.SetDirUserINI(ctx, "/www/wwwroot/w1.hao.com")
 	if err != nil {
 		fmt.Println(err)
 		t.Fail()
@@ -269,7 +272,7 @@ func TestClient_SetDirUserINI(t *testing.T) {
 }
 
 func TestClient_SetLogsOpen(t *testing.T) {
-	r2, err := client.SetLogsOpen(11)
+	r2, err := client.SetLogsOpen(ctx, 11)
 	if err != nil {
 		fmt.Println(err)
 		t.Fail()
@@ -278,7 +281,7 @@ func TestClient_SetLogsOpen(t *testing.T) {
 }
 
 func TestClient_SetPath(t *testing.T) {
-	r2, err := client.SetPath(11, "/www/wwwroot/w1.hao.com")
+	r2, err := client.SetPath(ctx, 11, "/www/wwwroot/w1.hao.com")
 	if err != nil {
 		fmt.Println(err)
 		t.Fail()
@@ -287,7 +290,7 @@ func TestClient_SetPath(t *testing.T) {
 }
 
 func TestClient_SetRunPath(t *testing.T) {
-	r2, err := client.SetRunPath(11, "/wwpppp")
+	r2, err := client.SetRunPath(ctx, 11, "/wwpppp")
 	if err != nil {
 		fmt.Println(err)
 		t.Fail()
@@ -296,7 +299,7 @@ func TestClient_SetRunPath(t *testing.T) {
 }
 
 func TestClient_SetHasPwd(t *testing.T) {
-	r2, err := client.SetHasPwd(11, "wwpppp", "sss")
+	r2, err := client.SetHasPwd(ctx, 11, "wwpppp", "sss")
 	if err != nil {
 		fmt.Println(err)
 		t.Fail()
@@ -305,7 +308,7 @@ func TestClient_SetHasPwd(t *testing.T) {
 }
 
 func TestClient_CloseHasPwd(t *testing.T) {
-	r2, err := client.CloseHasPwd(11)
+	r2, err := client.CloseHasPwd(ctx, 11)
 	if err != nil {
 		fmt.Println(err)
 		t.Fail()
@@ -314,7 +317,7 @@ func TestClient_CloseHasPwd(t *testing.T) {
 }
 
 func TestClient_SetLimitNet(t *testing.T) {
-	r2, err := client.SetLimitNet(24, 300, 25, 512)
+	r2, err := client.SetLimitNet(ctx, 24, 300, 25, 512)
 	if err != nil {
 		fmt.Println(err)
 		t.Fail()
@@ -323,7 +326,7 @@ func TestClient_SetLimitNet(t *testing.T) {
 }
 
 func TestClient_CloseLimitNet(t *testing.T) {
-	r2, err := client.CloseLimitNet(24)
+	r2, err := client.CloseLimitNet(ctx, 24)
 	if err != nil {
 		fmt.Println(err)
 		t.Fail()
@@ -332,7 +335,7 @@ func TestClient_CloseLimitNet(t *testing.T) {
 }
 
 func TestClient_GetIndex(t *testing.T) {
-	r2, err := client.GetIndex(24)
+	r2, err := client.GetIndex(ctx, 24)
 	if err != nil {
 		fmt.Println(err)
 		t.Fail()
@@ -341,10 +344,972 @@ func TestClient_GetIndex(t *testing.T) {
 }
 
 func TestClient_SetIndex(t *testing.T) {
-	r2, err := client.SetIndex(11, "index.php")
+	r2, err := client.SetIndex(ctx, 11, "index.php")
+	if err != nil {
+		fmt.Println(err)
+		t.Fail()
+	}
+	fmt.Println(r2)
+}
+
+func TestClient_SetFTPDirectory(t *testing.T) {
+	r2, err := client.SetFTPDirectory(ctx, 3, "/www/wwwroot/w1.hao.com/public")
+	if err != nil {
+		fmt.Println(err)
+		t.Fail()
+	}
+	fmt.Println(r2)
+}
+
+func TestClient_SetFTPQuota(t *testing.T) {
+	r2, err := client.SetFTPQuota(ctx, 3, 1024)
+	if err != nil {
+		fmt.Println(err)
+		t.Fail()
+	}
+	fmt.Println(r2)
+}
+
+func TestClient_StartDNSManualCert(t *testing.T) {
+	flow, err := client.StartDNSManualCert(ctx, 11, []string{"w1.hao.com"})
+	if err != nil {
+		fmt.Println(err)
+		t.Fail()
+		return
+	}
+	fmt.Println(flow.Records)
+	r2, err := flow.Verify(ctx)
+	if err != nil {
+		fmt.Println(err)
+		t.Fail()
+	}
+	fmt.Println(r2)
+}
+
+func TestClient_GetPluginConfig(t *testing.T) {
+	r2, err := client.GetPluginConfig(ctx, "btwaf")
+	if err != nil {
+		fmt.Println(err)
+		t.Fail()
+	}
+	fmt.Println(r2)
+}
+
+func TestClient_SetPluginConfig(t *testing.T) {
+	r2, err := client.SetPluginConfig(ctx, "btwaf", map[string]string{"open": "1"}, "set_open")
+	if err != nil {
+		fmt.Println(err)
+		t.Fail()
+	}
+	fmt.Println(r2)
+}
+
+func TestClient_GetHasPwd(t *testing.T) {
+	r2, err := client.GetHasPwd(ctx, 11)
+	if err != nil {
+		fmt.Println(err)
+		t.Fail()
+	}
+	fmt.Println(r2)
+}
+
+func TestClient_AddDatabase(t *testing.T) {
+	r2, err := client.AddDatabase(ctx, &ReqAddDatabase{
+		Name:      "w1_hao_com",
+		Password:  "datapassword",
+		Charset:   "utf8mb4",
+		Collation: "utf8mb4_unicode_ci",
+	})
+	if err != nil {
+		fmt.Println(err)
+		t.Fail()
+	}
+	fmt.Println(r2)
+}
+
+func TestClient_AddRemoteDatabaseServer(t *testing.T) {
+	r2, err := client.AddRemoteDatabaseServer(ctx, &ReqDatabaseServer{
+		Host:     "10.0.0.15",
+		User:     "root",
+		Password: "rootpassword",
+		PS:       "db1",
+	})
+	if err != nil {
+		fmt.Println(err)
+		t.Fail()
+	}
+	fmt.Println(r2)
+}
+
+func TestClient_ListDatabaseServers(t *testing.T) {
+	r2, err := client.ListDatabaseServers(ctx)
+	if err != nil {
+		fmt.Println(err)
+		t.Fail()
+	}
+	fmt.Println(r2)
+}
+
+func TestClient_DeleteDatabaseServer(t *testing.T) {
+	r2, err := client.DeleteDatabaseServer(ctx, 1)
+	if err != nil {
+		fmt.Println(err)
+		t.Fail()
+	}
+	fmt.Println(r2)
+}
+
+func TestClient_RunCrontabNow(t *testing.T) {
+	r2, err := client.RunCrontabNow(ctx, 1)
+	if err != nil {
+		fmt.Println(err)
+		t.Fail()
+	}
+	fmt.Println(r2)
+}
+
+func TestClient_GetCrontabLogs(t *testing.T) {
+	r2, err := client.GetCrontabLogs(ctx, 1)
+	if err != nil {
+		fmt.Println(err)
+		t.Fail()
+	}
+	fmt.Println(r2)
+}
+
+func TestClient_RunCrontabNowWithLog(t *testing.T) {
+	r2, err := client.RunCrontabNowWithLog(ctx, 1, 30*time.Second)
+	if err != nil {
+		fmt.Println(err)
+		t.Fail()
+	}
+	fmt.Println(r2)
+}
+
+func TestClient_CopyFile(t *testing.T) {
+	r2, err := client.CopyFile(ctx, "/www/wwwroot/w1.hao.com/index.html", "/www/wwwroot/w1.hao.com/index.html.bak")
+	if err != nil {
+		fmt.Println(err)
+		t.Fail()
+	}
+	fmt.Println(r2)
+}
+
+func TestClient_SafeEditConfig(t *testing.T) {
+	r2, err := client.SafeEditConfig(ctx, "/www/server/panel/vhost/nginx/w1.hao.com.conf", func(s string) (string, error) {
+		return strings.Replace(s, "listen 80;", "listen 8080;", 1), nil
+	}, &EditOptions{Backup: true, DryRun: true})
+	if err != nil {
+		fmt.Println(err)
+		t.Fail()
+	}
+	fmt.Println(r2)
+}
+
+func TestClient_GetSiteReport(t *testing.T) {
+	r2, err := client.GetSiteReport(ctx, "w1.hao.com", time.Now())
+	if err != nil {
+		fmt.Println(err)
+		t.Fail()
+	}
+	fmt.Println(r2)
+}
+
+func TestClient_GetSiteTopIPs(t *testing.T) {
+	r2, err := client.GetSiteTopIPs(ctx, "w1.hao.com", time.Now(), 10)
+	if err != nil {
+		fmt.Println(err)
+		t.Fail()
+	}
+	fmt.Println(r2)
+}
+
+func TestClient_GetSiteTopURIs(t *testing.T) {
+	r2, err := client.GetSiteTopURIs(ctx, "w1.hao.com", time.Now(), 10)
+	if err != nil {
+		fmt.Println(err)
+		t.Fail()
+	}
+	fmt.Println(r2)
+}
+
+func TestClient_GetSiteSpiders(t *testing.T) {
+	r2, err := client.GetSiteSpiders(ctx, "w1.hao.com", time.Now())
+	if err != nil {
+		fmt.Println(err)
+		t.Fail()
+	}
+	fmt.Println(r2)
+}
+
+func TestClient_GetNetWorkList(t *testing.T) {
+	r2, err := client.GetNetWorkList(ctx)
+	if err != nil {
+		fmt.Println(err)
+		t.Fail()
+	}
+	fmt.Println(r2)
+}
+
+func TestClient_CheckPort(t *testing.T) {
+	r2, err := client.CheckPort(ctx, 8080)
+	if err != nil {
+		fmt.Println(err)
+		t.Fail()
+	}
+	fmt.Println(r2)
+}
+
+func TestClient_GetSiteUser(t *testing.T) {
+	r2, err := client.GetSiteUser(ctx, "w1.hao.com")
+	if err != nil {
+		fmt.Println(err)
+		t.Fail()
+	}
+	fmt.Println(r2)
+}
+
+func TestClient_SetSiteUser(t *testing.T) {
+	r2, err := client.SetSiteUser(ctx, "w1.hao.com", "www")
+	if err != nil {
+		fmt.Println(err)
+		t.Fail()
+	}
+	fmt.Println(r2)
+}
+
+func TestClient_SetSiteExpiration(t *testing.T) {
+	r2, err := client.SetSiteExpiration(ctx, 11, time.Now().AddDate(1, 0, 0))
+	if err != nil {
+		fmt.Println(err)
+		t.Fail()
+	}
+	fmt.Println(r2)
+}
+
+func TestClient_ClearSiteExpiration(t *testing.T) {
+	r2, err := client.ClearSiteExpiration(ctx, 11)
+	if err != nil {
+		fmt.Println(err)
+		t.Fail()
+	}
+	fmt.Println(r2)
+}
+
+func TestClient_SetTamperProtection(t *testing.T) {
+	r2, err := client.SetTamperProtection(ctx, "w1.hao.com", true)
+	if err != nil {
+		fmt.Println(err)
+		t.Fail()
+	}
+	fmt.Println(r2)
+}
+
+func TestClient_GetTamperSite(t *testing.T) {
+	r2, err := client.GetTamperSite(ctx, "w1.hao.com")
+	if err != nil {
+		fmt.Println(err)
+		t.Fail()
+	}
+	fmt.Println(r2)
+}
+
+func TestClient_AddTamperExclusion(t *testing.T) {
+	r2, err := client.AddTamperExclusion(ctx, "w1.hao.com", "cache")
+	if err != nil {
+		fmt.Println(err)
+		t.Fail()
+	}
+	fmt.Println(r2)
+}
+
+func TestClient_RemoveTamperExclusion(t *testing.T) {
+	r2, err := client.RemoveTamperExclusion(ctx, "w1.hao.com", "cache")
+	if err != nil {
+		fmt.Println(err)
+		t.Fail()
+	}
+	fmt.Println(r2)
+}
+
+func TestClient_GetTamperLogs(t *testing.T) {
+	r2, err := client.GetTamperLogs(ctx, "w1.hao.com", 1)
+	if err != nil {
+		fmt.Println(err)
+		t.Fail()
+	}
+	fmt.Println(r2)
+}
+
+func TestClient_GetFirewallBackend(t *testing.T) {
+	r2, err := client.GetFirewallBackend(ctx)
+	if err != nil {
+		fmt.Println(err)
+		t.Fail()
+	}
+	fmt.Println(r2)
+}
+
+func TestClient_SetSiteCompression(t *testing.T) {
+	r2, err := client.SetSiteCompression(ctx, "w1.hao.com", Compression{Gzip: true})
+	if err != nil {
+		fmt.Println(err)
+		t.Fail()
+	}
+	fmt.Println(r2)
+}
+
+func TestClient_DisableSiteCompression(t *testing.T) {
+	r2, err := client.DisableSiteCompression(ctx, "w1.hao.com")
+	if err != nil {
+		fmt.Println(err)
+		t.Fail()
+	}
+	fmt.Println(r2)
+}
+
+func TestClient_QueryTable(t *testing.T) {
+	r2, err := client.QueryTable("sites").Search("hao.com").OrderBy("id", true).Limit(50).Result(ctx)
 	if err != nil {
 		fmt.Println(err)
 		t.Fail()
 	}
 	fmt.Println(r2)
 }
+
+func TestClient_GetSoftList(t *testing.T) {
+	r2, err := client.GetSoftList(ctx, "nginx")
+	if err != nil {
+		fmt.Println(err)
+		t.Fail()
+	}
+	fmt.Println(r2)
+}
+
+func TestClient_GetPHPVersionStatus(t *testing.T) {
+	r2, err := client.GetPHPVersionStatus(ctx)
+	if err != nil {
+		fmt.Println(err)
+		t.Fail()
+	}
+	fmt.Println(r2)
+}
+
+func TestClient_Close(t *testing.T) {
+	c := NewClient(client.BTAddress, client.BTKey)
+	r, err := c.GetSystemTotal(ctx)
+	fmt.Println(r, err)
+	if err := c.Close(); err != nil {
+		fmt.Println(err)
+		t.Fail()
+	}
+}
+
+func TestClient_AddShellCrontab(t *testing.T) {
+	r2, err := client.AddShellCrontab(ctx, "sdk-test", Daily(3, 30), "echo hello")
+	if err != nil {
+		fmt.Println(err)
+		t.Fail()
+	}
+	fmt.Println(r2)
+}
+
+func TestClient_DeleteCrontab(t *testing.T) {
+	r2, err := client.DeleteCrontab(ctx, 1)
+	if err != nil {
+		fmt.Println(err)
+		t.Fail()
+	}
+	fmt.Println(r2)
+}
+
+func TestClient_RunShellScript(t *testing.T) {
+	r2, err := client.RunShellScript(ctx, "uptime", 30*time.Second)
+	if err != nil {
+		fmt.Println(err)
+		t.Fail()
+	}
+	fmt.Println(r2)
+}
+
+func TestClient_DescribeSite(t *testing.T) {
+	r2, err := client.DescribeSite(ctx, 11)
+	if err != nil {
+		fmt.Println(err)
+		t.Fail()
+	}
+	y, _ := r2.YAML()
+	fmt.Println(string(y))
+}
+
+func TestClient_GetNetWorkByInterface(t *testing.T) {
+	r2, err := client.GetNetWorkByInterface(ctx, "eth0")
+	if err != nil {
+		fmt.Println(err)
+		t.Fail()
+	}
+	fmt.Println(r2)
+}
+
+func TestClient_CreateFile(t *testing.T) {
+	r2, err := client.CreateFile(ctx, "/www/wwwroot/w1.hao.com/new.html")
+	if err != nil {
+		fmt.Println(err)
+		t.Fail()
+	}
+	fmt.Println(r2)
+}
+
+func TestClient_SetSiteErrorPage(t *testing.T) {
+	err := client.SetSiteErrorPage(ctx, 11, 404, "<h1>404 Not Found</h1>")
+	if err != nil {
+		fmt.Println(err)
+		t.Fail()
+	}
+}
+
+func TestClient_GetSiteErrorPage(t *testing.T) {
+	r2, err := client.GetSiteErrorPage(ctx, 11, 404)
+	if err != nil {
+		fmt.Println(err)
+		t.Fail()
+	}
+	fmt.Println(r2)
+}
+
+func TestClient_DeleteSiteErrorPage(t *testing.T) {
+	err := client.DeleteSiteErrorPage(ctx, 11, 404)
+	if err != nil {
+		fmt.Println(err)
+		t.Fail()
+	}
+}
+
+func TestClient_FetchBackup(t *testing.T) {
+	r, err := client.GetSiteBackups(ctx, &ReqSiteBackups{
+		P:      1,
+		Limit:  15,
+		Search: 11,
+	})
+	if err != nil || len(r.Data) == 0 {
+		fmt.Println(err)
+		t.Fail()
+		return
+	}
+	fmt.Println(client.BackupDownloadURL(r.Data[0]))
+	rc, err := client.FetchBackup(ctx, r.Data[0])
+	if err != nil {
+		fmt.Println(err)
+		t.Fail()
+		return
+	}
+	defer rc.Close()
+	n, err := io.Copy(io.Discard, rc)
+	fmt.Println(n, err)
+}
+
+func TestClient_GetNginxStatus(t *testing.T) {
+	r2, err := client.GetNginxStatus(ctx)
+	if err != nil {
+		fmt.Println(err)
+		t.Fail()
+	}
+	fmt.Println(r2)
+}
+
+func TestClient_GetSiteConnections(t *testing.T) {
+	r2, err := client.GetSiteConnections(ctx, 11)
+	if err != nil {
+		fmt.Println(err)
+		t.Fail()
+	}
+	fmt.Println(r2)
+}
+
+func TestClient_StopSites(t *testing.T) {
+	r2, err := client.StopSites(ctx, SiteFilter{IDs: []int64{11}})
+	if err != nil {
+		fmt.Println(err)
+		t.Fail()
+	}
+	fmt.Println(r2)
+}
+
+func TestClient_StartSites(t *testing.T) {
+	r2, err := client.StartSites(ctx, SiteFilter{IDs: []int64{11}})
+	if err != nil {
+		fmt.Println(err)
+		t.Fail()
+	}
+	fmt.Println(r2)
+}
+
+func TestClient_GetPanelVersion(t *testing.T) {
+	r2, err := client.GetPanelVersion(ctx)
+	if err != nil {
+		fmt.Println(err)
+		t.Fail()
+	}
+	fmt.Println(r2, r2.IsAtLeast("7.9"))
+}
+
+func TestClient_AddRegionBlock(t *testing.T) {
+	r2, err := client.AddRegionBlock(ctx, RegionBlock{Country: "美国", Brief: "test"})
+	if err != nil {
+		fmt.Println(err)
+		t.Fail()
+	}
+	fmt.Println(r2)
+}
+
+func TestClient_GetRegionBlocks(t *testing.T) {
+	r2, err := client.GetRegionBlocks(ctx)
+	if err != nil {
+		fmt.Println(err)
+		t.Fail()
+	}
+	fmt.Println(r2)
+}
+
+func TestClient_GetRewriteTemplate(t *testing.T) {
+	r2, err := client.GetRewriteTemplate(ctx, "wordpress")
+	if err != nil {
+		fmt.Println(err)
+		t.Fail()
+	}
+	fmt.Println(r2)
+}
+
+func TestClient_GetTimezone(t *testing.T) {
+	r2, err := client.GetTimezone(ctx)
+	if err != nil {
+		fmt.Println(err)
+		t.Fail()
+	}
+	fmt.Println(r2.Name())
+}
+
+func TestClient_SyncTime(t *testing.T) {
+	r2, err := client.SyncTime(ctx)
+	if err != nil {
+		fmt.Println(err)
+		t.Fail()
+	}
+	fmt.Println(r2)
+}
+
+func TestClient_SetSiteAccessLogFormat(t *testing.T) {
+	r2, err := client.SetSiteAccessLogFormat(ctx, "w1.hao.com", AccessLogJSON)
+	if err != nil {
+		fmt.Println(err)
+		t.Fail()
+	}
+	fmt.Println(r2)
+}
+
+func TestClient_CleanupDisk(t *testing.T) {
+	r2, err := client.CleanupDisk(ctx, CleanupPlan{Threshold: 90, EmptyRecycleBin: true})
+	if err != nil {
+		fmt.Println(err)
+		t.Fail()
+	}
+	fmt.Println(r2)
+}
+
+func TestClient_GetSecurityEvents(t *testing.T) {
+	r2, err := client.GetSecurityEvents(ctx, 1, 20)
+	if err != nil {
+		fmt.Println(err)
+		t.Fail()
+	}
+	fmt.Println(r2)
+}
+
+func TestClient_WriteFile(t *testing.T) {
+	r2, err := client.WriteFile(ctx, "/www/wwwroot/w1.hao.com/test.txt", "test", true, true)
+	if err != nil {
+		fmt.Println(err)
+		t.Fail()
+	}
+	fmt.Println(r2)
+}
+
+func TestClient_UploadChunked(t *testing.T) {
+	content := strings.Repeat("bt", 1<<20)
+	u := &ChunkedUpload{Dir: "/www/wwwroot/w1.hao.com", Name: "upload.txt", Size: int64(len(content))}
+	err := client.UploadChunked(ctx, u, strings.NewReader(content))
+	if err != nil {
+		fmt.Println(err)
+		t.Fail()
+	}
+	fmt.Println(u.Offset)
+}
+
+func TestClient_GetSwap(t *testing.T) {
+	r2, err := client.GetSwap(ctx)
+	if err != nil {
+		fmt.Println(err)
+		t.Fail()
+	}
+	fmt.Println(r2)
+}
+
+func TestClient_GetTopProcesses(t *testing.T) {
+	r2, err := client.GetTopProcesses(ctx, 5, false)
+	if err != nil {
+		fmt.Println(err)
+		t.Fail()
+	}
+	fmt.Println(r2)
+}
+
+func TestClient_GetFileFavorites(t *testing.T) {
+	r2, err := client.GetFileFavorites(ctx)
+	if err != nil {
+		fmt.Println(err)
+		t.Fail()
+	}
+	fmt.Println(r2)
+}
+
+func TestClient_AuditPanelServices(t *testing.T) {
+	r2 := client.AuditPanelServices(ctx)
+	for _, s := range r2.Services {
+		if s.Err != nil {
+			fmt.Println(s.Err)
+			t.Fail()
+		}
+	}
+	fmt.Println(r2)
+}
+
+func TestClient_GetRunPathOptions(t *testing.T) {
+	r2, err := client.GetRunPathOptions(ctx, 1)
+	if err != nil {
+		fmt.Println(err)
+		t.Fail()
+	}
+	fmt.Println(r2)
+}
+
+func TestClient_ListAllDomains(t *testing.T) {
+	r2, err := client.ListAllDomains(ctx)
+	if err != nil {
+		fmt.Println(err)
+		t.Fail()
+	}
+	fmt.Println(len(r2))
+}
+
+func TestClient_GetSiteCC(t *testing.T) {
+	r2, err := client.GetSiteCC(ctx, "w1.hao.com")
+	if err != nil {
+		fmt.Println(err)
+		t.Fail()
+	}
+	fmt.Println(r2)
+}
+
+func TestClient_ListSQLiteTables(t *testing.T) {
+	r2, err := client.ListSQLiteTables(ctx, "/www/wwwroot/w1.hao.com/data.db")
+	if err != nil {
+		fmt.Println(err)
+		t.Fail()
+	}
+	fmt.Println(r2)
+}
+
+func TestClient_ListFileVersions(t *testing.T) {
+	r2, err := client.ListFileVersions(ctx, "/www/wwwroot/w1.hao.com/index.html")
+	if err != nil {
+		fmt.Println(err)
+		t.Fail()
+	}
+	fmt.Println(r2)
+}
+
+func TestClient_CreateFullSite(t *testing.T) {
+	r2, err := client.CreateFullSite(ctx, &FullSite{
+		Site: ReqAddSite{
+			WebName: NewWebName("w3.hao.com"),
+			Path:    "/www/wwwroot/w3.hao.com",
+			Type:    "PHP",
+			Version: 74,
+			Port:    80,
+			PS:      "w3.hao.com",
+		},
+		Rewrite: "wordpress",
+		Backup:  &CronSchedule{Type: "day", Hour: 2, Minute: 30},
+	})
+	if err != nil {
+		fmt.Println(err)
+		t.Fail()
+	}
+	fmt.Println(r2)
+}
+
+func TestClient_GetPHPUsage(t *testing.T) {
+	r2, err := client.GetPHPUsage(ctx)
+	if err != nil {
+		fmt.Println(err)
+		t.Fail()
+	}
+	fmt.Println(r2)
+}
+
+func TestClient_EnsureDir(t *testing.T) {
+	r2, err := client.EnsureDir(ctx, "/www/wwwroot/w1.hao.com/releases/v1", 0755, "www")
+	if err != nil {
+		fmt.Println(err)
+		t.Fail()
+	}
+	fmt.Println(r2)
+}
+
+func TestClient_SetSiteLimitRate(t *testing.T) {
+	r2, err := client.SetSiteLimitRate(ctx, "w1.hao.com", 512)
+	if err != nil {
+		fmt.Println(err)
+		t.Fail()
+	}
+	fmt.Println(r2)
+}
+
+func TestClient_GetFTPAccounts(t *testing.T) {
+	r2, err := client.GetFTPAccounts(ctx, "")
+	if err != nil {
+		fmt.Println(err)
+		t.Fail()
+	}
+	fmt.Println(r2)
+}
+
+func TestClient_GetSSLInfo(t *testing.T) {
+	r2, err := client.GetSSLInfo(ctx, "w1.hao.com")
+	if err != nil {
+		fmt.Println(err)
+		t.Fail()
+	}
+	fmt.Println(r2)
+}
+
+func TestClient_ApplyLetsEncryptCert(t *testing.T) {
+	r2, err := client.ApplyLetsEncryptCert(ctx, 24, []string{"w1.hao.com"})
+	if err != nil {
+		fmt.Println(err)
+		t.Fail()
+	}
+	fmt.Println(r2)
+}
+
+func TestClient_GetCrontabList(t *testing.T) {
+	r2, err := client.GetCrontabList(ctx)
+	if err != nil {
+		fmt.Println(err)
+		t.Fail()
+	}
+	fmt.Println(r2)
+}
+
+func TestClient_GetFirewallRules(t *testing.T) {
+	r2, err := client.GetFirewallRules(ctx)
+	if err != nil {
+		fmt.Println(err)
+		t.Fail()
+	}
+	fmt.Println(r2)
+}
+
+func TestClient_GetSSHStatus(t *testing.T) {
+	r2, err := client.GetSSHStatus(ctx)
+	if err != nil {
+		fmt.Println(err)
+		t.Fail()
+	}
+	fmt.Println(r2)
+}
+
+func TestClient_EnableMaintenance(t *testing.T) {
+	err := client.EnableMaintenance(ctx, 11, "<h1>维护中</h1>")
+	if err != nil {
+		fmt.Println(err)
+		t.Fail()
+	}
+	err = client.DisableMaintenance(ctx, 11)
+	if err != nil {
+		fmt.Println(err)
+		t.Fail()
+	}
+}
+
+func TestClient_SnapshotServer(t *testing.T) {
+	err := client.SnapshotServer(ctx, os.Stdout)
+	if err != nil {
+		fmt.Println(err)
+		t.Fail()
+	}
+}
+
+func TestClient_GetPanelAccess(t *testing.T) {
+	r2, err := client.GetPanelAccess(ctx)
+	if err != nil {
+		fmt.Println(err)
+		t.Fail()
+	}
+	fmt.Println(r2)
+}
+
+func TestClient_GetProxyList(t *testing.T) {
+	r, err := client.GetProxyList(ctx, "w1.hao.com")
+	if err != nil {
+		fmt.Println(err)
+		t.Fail()
+	}
+	fmt.Println(r)
+}
+
+func TestClient_GetRedirectList(t *testing.T) {
+	r, err := client.GetRedirectList(ctx, "w1.hao.com")
+	if err != nil {
+		fmt.Println(err)
+		t.Fail()
+	}
+	fmt.Println(r)
+}
+
+func TestClient_GetDirIndexStatus(t *testing.T) {
+	r, err := client.GetDirIndexStatus(ctx, 24)
+	if err != nil {
+		fmt.Println(err)
+		t.Fail()
+	}
+	fmt.Println(r)
+}
+
+func TestClient_GetSiteRewrite(t *testing.T) {
+	r, err := client.GetSiteRewrite(ctx, "w1.hao.com")
+	if err != nil {
+		fmt.Println(err)
+		t.Fail()
+	}
+	fmt.Println(r)
+}
+
+func TestClient_DownloadBackup(t *testing.T) {
+	r, err := client.GetSiteBackups(ctx, &ReqSiteBackups{
+		P:      1,
+		Limit:  15,
+		Search: 11,
+	})
+	if err != nil || len(r.Data) == 0 {
+		fmt.Println(err)
+		t.Fail()
+		return
+	}
+	n, err := client.DownloadBackup(ctx, r.Data[0], io.Discard, func(p TransferProgress) {
+		fmt.Printf("%d/%d %.0fB/s\n", p.Done, p.Total, p.Rate)
+	})
+	fmt.Println(n, err)
+}
+
+func TestClient_UploadFile(t *testing.T) {
+	err := client.UploadFile(ctx, "/www/wwwroot/w1.hao.com/upload.txt", strings.NewReader("hello"))
+	if err != nil {
+		fmt.Println(err)
+		t.Fail()
+		return
+	}
+	n, err := client.DownloadFile(ctx, "/www/wwwroot/w1.hao.com/upload.txt", os.Stdout)
+	fmt.Println(n, err)
+}
+
+func TestClient_GetTaskLog(t *testing.T) {
+	r, err := client.GetTasks(ctx, 10)
+	if err != nil || len(r) == 0 {
+		fmt.Println(err)
+		t.Fail()
+		return
+	}
+	log, err := client.GetTaskLog(ctx, strconv.FormatInt(r[0].ID, 10))
+	fmt.Println(log, err)
+}
+
+func TestClient_ListDir(t *testing.T) {
+	r, err := client.ListDir(ctx, "/www/wwwroot", 1, "")
+	if err != nil {
+		fmt.Println(err)
+		t.Fail()
+	}
+	for _, e := range r.Entries() {
+		fmt.Println(e.Name, e.IsDir, e.Size, e.Mode, e.Owner, e.ModTime)
+	}
+}
+
+func TestClient_RenewExpiringCertificates(t *testing.T) {
+	r, err := client.RenewExpiringCertificates(ctx, 30*24*time.Hour)
+	if err != nil {
+		fmt.Println(err)
+		t.Fail()
+	}
+	for _, v := range r.Results {
+		fmt.Println(v.Site.Name, v.Expiry, v.Renewed, v.Skipped, v.Err)
+	}
+}
+
+func TestClient_AwaitPanelReady(t *testing.T) {
+	c, cancel := context.WithTimeout(ctx, time.Minute)
+	defer cancel()
+	if err := client.AwaitPanelReady(c); err != nil {
+		fmt.Println(err)
+		t.Fail()
+	}
+}
+
+func TestClient_Zip(t *testing.T) {
+	r, err := client.Zip(ctx, &ReqZip{
+		Dir:     "/www/wwwroot/w1.hao.com",
+		Names:   []string{"index.html"},
+		Archive: "/www/backup/w1.zip",
+	})
+	if err != nil {
+		fmt.Println(err)
+		t.Fail()
+	}
+	fmt.Println(r)
+}
+
+func TestClient_GetBackupTargets(t *testing.T) {
+	r, err := client.GetBackupTargets(ctx)
+	if err != nil {
+		fmt.Println(err)
+		t.Fail()
+	}
+	fmt.Println(r)
+}
+
+func TestClient_GetSiteConf(t *testing.T) {
+	r, err := client.GetSiteConf(ctx, "w1.hao.com")
+	if err != nil {
+		fmt.Println(err)
+		t.Fail()
+	}
+	fmt.Println(r)
+}
+
+func TestClient_GetSiteDisableFunctions(t *testing.T) {
+	v, r, err := client.GetSiteDisableFunctions(ctx, "w1.hao.com")
+	if err != nil {
+		fmt.Println(err)
+		t.Fail()
+	}
+	fmt.Println(v, r)
+}
+
+func TestClient_GetSitePHPVersion(t *testing.T) {
+	r, err := client.GetSitePHPVersion(ctx, "w1.hao.com")
+	if err != nil {
+		fmt.Println(err)
+		t.Fail()
+	}
+	fmt.Println(r)
+}
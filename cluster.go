@@ -0,0 +1,197 @@
+package bt
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// NodeRole 节点在集群中的角色
+type NodeRole string
+
+// 节点角色
+const (
+	NodeMain  NodeRole = "main"  // 主面板
+	NodeAgent NodeRole = "agent" // 子面板/节点
+)
+
+// ErrNoHealthyNode 没有符合条件的健康节点
+var ErrNoHealthyNode = errors.New("bt: no healthy node available")
+
+// Node 集群中的一个面板
+type Node struct {
+	Name   string
+	Role   NodeRole
+	Tags   []string
+	Client *Client
+
+	mu        sync.Mutex
+	healthy   bool
+	lastCheck time.Time
+	lastErr   error
+}
+
+// Healthy 最近一次健康检查是否通过 未检查过的节点视为健康
+func (n *Node) Healthy() bool {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return n.healthy
+}
+
+// LastCheck 最近一次健康检查的时间及错误
+func (n *Node) LastCheck() (time.Time, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return n.lastCheck, n.lastErr
+}
+
+// HasTag 节点是否带有 tag
+func (n *Node) HasTag(tag string) bool {
+	for _, t := range n.Tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// Cluster 由主面板和若干子面板组成的逻辑集群 各节点使用各自的地址和密钥
+// 提供按名称、角色、标签选择节点 以及健康检查和批量调用
+type Cluster struct {
+	mu    sync.RWMutex
+	nodes []*Node
+	next  int // 轮询位置
+}
+
+// NewCluster 实例化集群
+func NewCluster() *Cluster {
+	return &Cluster{}
+}
+
+// AddNode 注册节点 名称不能重复
+func (cl *Cluster) AddNode(name string, role NodeRole, c *Client, tags ...string) (*Node, error) {
+	if name == "" || c == nil {
+		return nil, errors.New("node name and client are required")
+	}
+	cl.mu.Lock()
+	defer cl.mu.Unlock()
+	for _, n := range cl.nodes {
+		if n.Name == name {
+			return nil, errors.New("node already exists: " + name)
+		}
+	}
+	n := &Node{Name: name, Role: role, Tags: tags, Client: c, healthy: true}
+	cl.nodes = append(cl.nodes, n)
+	return n, nil
+}
+
+// RemoveNode 移除节点 不会关闭节点的 Client
+func (cl *Cluster) RemoveNode(name string) bool {
+	cl.mu.Lock()
+	defer cl.mu.Unlock()
+	for i, n := range cl.nodes {
+		if n.Name == name {
+			cl.nodes = append(cl.nodes[:i], cl.nodes[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// Node 按名称获取节点
+func (cl *Cluster) Node(name string) (*Node, bool) {
+	cl.mu.RLock()
+	defer cl.mu.RUnlock()
+	for _, n := range cl.nodes {
+		if n.Name == name {
+			return n, true
+		}
+	}
+	return nil, false
+}
+
+// Nodes 返回符合 match 的节点 match 为空时返回全部
+func (cl *Cluster) Nodes(match func(*Node) bool) []*Node {
+	cl.mu.RLock()
+	defer cl.mu.RUnlock()
+	var ret []*Node
+	for _, n := range cl.nodes {
+		if match == nil || match(n) {
+			ret = append(ret, n)
+		}
+	}
+	return ret
+}
+
+// Main 返回第一个健康的主面板
+func (cl *Cluster) Main() (*Node, error) {
+	return cl.Pick(func(n *Node) bool { return n.Role == NodeMain })
+}
+
+// Pick 在符合 match 的健康节点中轮询选择一个 match 为空时不限制
+func (cl *Cluster) Pick(match func(*Node) bool) (*Node, error) {
+	cl.mu.Lock()
+	defer cl.mu.Unlock()
+	for i := 0; i < len(cl.nodes); i++ {
+		n := cl.nodes[(cl.next+i)%len(cl.nodes)]
+		if n.Healthy() && (match == nil || match(n)) {
+			cl.next = (cl.next + i + 1) % len(cl.nodes)
+			return n, nil
+		}
+	}
+	return nil, ErrNoHealthyNode
+}
+
+// HealthCheck 并发检查全部节点 以能否获取系统信息判断健康状态 返回失败节点的错误
+// ctx 结束时不再等待未完成的检查 这些节点的状态保持不变
+func (cl *Cluster) HealthCheck(ctx context.Context) map[string]error {
+	nodes := cl.Nodes(nil)
+	type result struct {
+		node *Node
+		err  error
+	}
+	ch := make(chan result, len(nodes))
+	for _, n := range nodes {
+		go func(n *Node) {
+			_, err := n.Client.GetSystemTotal(ctx)
+			ch <- result{n, err}
+		}(n)
+	}
+	ret := map[string]error{}
+	for range nodes {
+		select {
+		case r := <-ch:
+			r.node.mu.Lock()
+			r.node.healthy, r.node.lastCheck, r.node.lastErr = r.err == nil, time.Now(), r.err
+			r.node.mu.Unlock()
+			if r.err != nil {
+				ret[r.node.Name] = r.err
+			}
+		case <-ctx.Done():
+			return ret
+		}
+	}
+	return ret
+}
+
+// Each 在符合 match 的全部节点上并发执行 fn 返回失败节点的错误 不检查健康状态
+func (cl *Cluster) Each(match func(*Node) bool, fn func(*Node) error) map[string]error {
+	nodes := cl.Nodes(match)
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	ret := map[string]error{}
+	for _, n := range nodes {
+		wg.Add(1)
+		go func(n *Node) {
+			defer wg.Done()
+			if err := fn(n); err != nil {
+				mu.Lock()
+				ret[n.Name] = err
+				mu.Unlock()
+			}
+		}(n)
+	}
+	wg.Wait()
+	return ret
+}
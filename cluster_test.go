@@ -0,0 +1,52 @@
+package bt
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestCluster(t *testing.T) {
+	ok := func() *Client {
+		return newFakePanel(t, map[string]http.HandlerFunc{
+			"/system?action=GetSystemTotal": reply(`{"version":"7.9.0"}`),
+		})
+	}
+	down := newFakePanel(t, map[string]http.HandlerFunc{
+		"/system?action=GetSystemTotal": func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, "bad gateway", http.StatusBadGateway)
+		},
+	})
+	cl := NewCluster()
+	_, _ = cl.AddNode("main", NodeMain, ok())
+	_, _ = cl.AddNode("hk-1", NodeAgent, ok(), "hk")
+	_, _ = cl.AddNode("hk-2", NodeAgent, down, "hk")
+	if _, err := cl.AddNode("main", NodeMain, ok()); err == nil {
+		t.Fatal("expected duplicate error")
+	}
+	errs := cl.HealthCheck(context.Background())
+	if len(errs) != 1 || errs["hk-2"] == nil {
+		t.Fatalf("HealthCheck = %v", errs)
+	}
+	hk := func(n *Node) bool { return n.HasTag("hk") }
+	for i := 0; i < 3; i++ {
+		n, err := cl.Pick(hk)
+		if err != nil || n.Name != "hk-1" {
+			t.Fatalf("Pick = %v, %v", n, err)
+		}
+	}
+	if n, err := cl.Main(); err != nil || n.Name != "main" {
+		t.Fatalf("Main = %v, %v", n, err)
+	}
+	cl.RemoveNode("hk-1")
+	if _, err := cl.Pick(hk); err != ErrNoHealthyNode {
+		t.Fatalf("Pick err = %v", err)
+	}
+	errs = cl.Each(nil, func(n *Node) error {
+		_, err := n.Client.GetSystemTotal(ctx)
+		return err
+	})
+	if len(errs) != 1 {
+		t.Fatalf("Each = %v", errs)
+	}
+}
@@ -0,0 +1,41 @@
+// btschema 从 SDK 的请求/响应模型生成 JSON Schema 供非 Go 的调用方和校验层共享同一份契约
+//
+// 用法：
+//
+//	go run ./cmd/btschema -dir . -o schema.json
+//
+// 导出 request_models.go 与 respond_models.go 中的全部类型 以及它们引用到的包内类型
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+)
+
+func main() {
+	dir := flag.String("dir", ".", "SDK 源码目录")
+	out := flag.String("o", "", "输出文件 为空时输出到标准输出")
+	flag.Parse()
+
+	schema, err := Generate(*dir)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	b, err := json.MarshalIndent(schema, "", "  ")
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	b = append(b, '\n')
+	if *out == "" {
+		_, _ = os.Stdout.Write(b)
+		return
+	}
+	if err := os.WriteFile(*out, b, 0o644); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,183 @@
+package main
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// modelFiles 需要导出的模型文件 其中的导出类型均会生成定义
+var modelFiles = []string{"request_models.go", "respond_models.go"}
+
+// Schema JSON Schema 的子集
+type Schema struct {
+	SchemaURI            string             `json:"$schema,omitempty"`
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	Defs                 map[string]*Schema `json:"$defs,omitempty"`
+}
+
+type generator struct {
+	types map[string]*ast.TypeSpec // 包内全部类型声明
+	docs  map[string]string        // 类型的文档注释
+	defs  map[string]*Schema
+}
+
+// Generate 解析 dir 下的 Go 源码 返回包含全部模型定义的 Schema
+func Generate(dir string) (*Schema, error) {
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, dir, func(fi os.FileInfo) bool {
+		return !strings.HasSuffix(fi.Name(), "_test.go")
+	}, parser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+	g := &generator{
+		types: map[string]*ast.TypeSpec{},
+		docs:  map[string]string{},
+		defs:  map[string]*Schema{},
+	}
+	var roots []string
+	for _, pkg := range pkgs {
+		for path, file := range pkg.Files {
+			model := false
+			for _, name := range modelFiles {
+				if strings.HasSuffix(path, string(os.PathSeparator)+name) || path == name {
+					model = true
+				}
+			}
+			for _, decl := range file.Decls {
+				gd, ok := decl.(*ast.GenDecl)
+				if !ok || gd.Tok != token.TYPE {
+					continue
+				}
+				for _, spec := range gd.Specs {
+					ts := spec.(*ast.TypeSpec)
+					g.types[ts.Name.Name] = ts
+					doc := ts.Doc
+					if doc == nil && len(gd.Specs) == 1 {
+						doc = gd.Doc
+					}
+					g.docs[ts.Name.Name] = docText(doc)
+					if model && ts.Name.IsExported() {
+						roots = append(roots, ts.Name.Name)
+					}
+				}
+			}
+		}
+	}
+	sort.Strings(roots)
+	for _, name := range roots {
+		g.define(name)
+	}
+	return &Schema{
+		SchemaURI: "https://json-schema.org/draft/2020-12/schema",
+		Defs:      g.defs,
+	}, nil
+}
+
+// define 生成具名类型的定义 并返回对它的引用
+func (g *generator) define(name string) *Schema {
+	ref := &Schema{Ref: "#/$defs/" + name}
+	if _, ok := g.defs[name]; ok {
+		return ref
+	}
+	ts := g.types[name]
+	g.defs[name] = &Schema{} // 占位 避免递归类型无限展开
+	s := g.schema(ts.Type)
+	s.Description = g.docs[name]
+	g.defs[name] = s
+	return ref
+}
+
+func (g *generator) schema(expr ast.Expr) *Schema {
+	switch t := expr.(type) {
+	case *ast.Ident:
+		return g.ident(t.Name)
+	case *ast.StarExpr:
+		return g.schema(t.X)
+	case *ast.ArrayType:
+		if id, ok := t.Elt.(*ast.Ident); ok && id.Name == "byte" {
+			return &Schema{Type: "string", Format: "byte"}
+		}
+		return &Schema{Type: "array", Items: g.schema(t.Elt)}
+	case *ast.MapType:
+		return &Schema{Type: "object", AdditionalProperties: g.schema(t.Value)}
+	case *ast.StructType:
+		return g.object(t)
+	case *ast.SelectorExpr:
+		if pkg, ok := t.X.(*ast.Ident); ok && pkg.Name == "time" && t.Sel.Name == "Time" {
+			return &Schema{Type: "string", Format: "date-time"}
+		}
+		if pkg, ok := t.X.(*ast.Ident); ok && pkg.Name == "time" && t.Sel.Name == "Duration" {
+			return &Schema{Type: "integer"}
+		}
+		if pkg, ok := t.X.(*ast.Ident); ok && pkg.Name == "os" && t.Sel.Name == "FileMode" {
+			return &Schema{Type: "integer"}
+		}
+	}
+	return &Schema{}
+}
+
+func (g *generator) ident(name string) *Schema {
+	switch name {
+	case "string":
+		return &Schema{Type: "string"}
+	case "bool":
+		return &Schema{Type: "boolean"}
+	case "int", "int8", "int16", "int32", "int64", "uint", "uint8", "uint16", "uint32", "uint64":
+		return &Schema{Type: "integer"}
+	case "float32", "float64":
+		return &Schema{Type: "number"}
+	}
+	if _, ok := g.types[name]; ok {
+		return g.define(name)
+	}
+	return &Schema{}
+}
+
+func (g *generator) object(st *ast.StructType) *Schema {
+	s := &Schema{Type: "object", Properties: map[string]*Schema{}}
+	for _, f := range st.Fields.List {
+		if len(f.Names) == 0 {
+			continue
+		}
+		for _, n := range f.Names {
+			if !n.IsExported() {
+				continue
+			}
+			key := n.Name
+			if f.Tag != nil {
+				tag, _ := strconv.Unquote(f.Tag.Value)
+				jsonTag := strings.Split(reflect.StructTag(tag).Get("json"), ",")[0]
+				if jsonTag == "-" {
+					continue
+				}
+				if jsonTag != "" {
+					key = jsonTag
+				}
+			}
+			fs := g.schema(f.Type)
+			fs.Description = docText(f.Comment)
+			s.Properties[key] = fs
+		}
+	}
+	return s
+}
+
+func docText(cg *ast.CommentGroup) string {
+	if cg == nil {
+		return ""
+	}
+	return strings.TrimSpace(cg.Text())
+}
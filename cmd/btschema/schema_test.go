@@ -0,0 +1,50 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"testing"
+)
+
+func TestGenerate(t *testing.T) {
+	s, err := Generate("../..")
+	if err != nil {
+		t.Fatal(err)
+	}
+	site, ok := s.Defs["ReqAddSite"]
+	if !ok {
+		t.Fatal("ReqAddSite not exported")
+	}
+	if site.Properties["WebName"].Ref != "#/$defs/WebName" {
+		t.Fatalf("WebName = %+v", site.Properties["WebName"])
+	}
+	if s.Defs["WebName"].Properties["domainlist"].Type != "array" {
+		t.Fatalf("WebName = %+v", s.Defs["WebName"])
+	}
+	msg := s.Defs["RespMSG"]
+	if _, ok := msg.Properties["Code"]; ok {
+		t.Fatal(`json:"-" field exported`)
+	}
+	if msg.Properties["status"].Type != "boolean" {
+		t.Fatalf("RespMSG = %+v", msg)
+	}
+}
+
+func TestSchemaUpToDate(t *testing.T) {
+	s, err := Generate("../..")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := os.ReadFile("../../schema.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(bytes.TrimSpace(got), want) {
+		t.Fatal("schema.json is out of date, run go generate ./...")
+	}
+}
@@ -0,0 +1,77 @@
+package bt
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// DefaultCompressionTypes 默认压缩的 MIME 类型（text/html 始终会被压缩 无需列出）
+var DefaultCompressionTypes = []string{
+	"text/plain", "text/css", "text/xml", "text/javascript",
+	"application/javascript", "application/json", "application/xml",
+	"application/rss+xml", "image/svg+xml",
+}
+
+// Compression 网站压缩配置（仅支持 nginx）
+type Compression struct {
+	Gzip   bool
+	Brotli bool     // 需 nginx 已编译 ngx_brotli 模块 否则配置检测失败不会保存
+	Level  int      // 压缩级别 gzip 1-9 brotli 0-11 为 0 时 gzip 使用 6 brotli 使用 4
+	Types  []string // 为空时使用 DefaultCompressionTypes
+}
+
+func (cfg Compression) block() (string, error) {
+	types := cfg.Types
+	if len(types) == 0 {
+		types = DefaultCompressionTypes
+	}
+	var lines []string
+	if cfg.Gzip {
+		level := cfg.Level
+		if level == 0 {
+			level = 6
+		}
+		if level < 1 || level > 9 {
+			return "", errors.New("gzip level must be between 1 and 9")
+		}
+		lines = append(lines,
+			"gzip on;",
+			"gzip_comp_level "+strconv.Itoa(level)+";",
+			"gzip_min_length 1k;",
+			"gzip_vary on;",
+			"gzip_types "+strings.Join(types, " ")+";",
+		)
+	}
+	if cfg.Brotli {
+		level := cfg.Level
+		if level == 0 {
+			level = 4
+		}
+		if level < 0 || level > 11 {
+			return "", errors.New("brotli level must be between 0 and 11")
+		}
+		lines = append(lines,
+			"brotli on;",
+			"brotli_comp_level "+strconv.Itoa(level)+";",
+			"brotli_types "+strings.Join(types, " ")+";",
+		)
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+// SetSiteCompression 设置网站的 gzip/brotli 压缩 Gzip 和 Brotli 都为 false 时等同于 DisableSiteCompression
+// 返回配置文件变更的 diff 配置未变化时为空
+func (c *Client) SetSiteCompression(ctx context.Context, siteName string, cfg Compression) (string, error) {
+	block, err := cfg.block()
+	if err != nil {
+		return "", err
+	}
+	return c.editManagedBlock(ctx, siteName, "COMPRESSION", block)
+}
+
+// DisableSiteCompression 移除由 SetSiteCompression 写入的网站压缩配置 恢复使用 nginx 全局设置
+func (c *Client) DisableSiteCompression(ctx context.Context, siteName string) (string, error) {
+	return c.editManagedBlock(ctx, siteName, "COMPRESSION", "")
+}
@@ -0,0 +1,79 @@
+package bt
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+)
+
+// GetNginxStatus 获取 nginx 全局连接状态
+func (c *Client) GetNginxStatus(ctx context.Context) (NginxStatus, error) {
+	resp, err := c.btAPI(ctx, map[string][]string{}, "/ajax?action=GetNginxStatus")
+	if err != nil {
+		return NginxStatus{}, err
+	}
+	var dec NginxStatus
+	if err := c.unmarshal(ctx, resp, &dec); err != nil {
+		return NginxStatus{}, err
+	}
+	return dec, nil
+}
+
+// SiteConnections 网站的连接统计
+type SiteConnections struct {
+	SiteID      int64
+	Ports       []int64
+	Established int64 // 网站端口上已建立的 TCP 连接数
+	// Shared 为 true 表示网站端口与其他网站共用（如 80/443）
+	// 此时 Established 为所有共用该端口的网站之和 只能作为上限参考
+	Shared bool
+	Nginx  NginxStatus // nginx 全局 Reading/Writing/Waiting 状态
+}
+
+// GetSiteConnections 统计网站所绑定端口上的活动连接
+// nginx stub_status 不区分虚拟主机 因此按网站端口统计已建立的连接 并附带全局状态
+func (c *Client) GetSiteConnections(ctx context.Context, id int64) (SiteConnections, error) {
+	ret := SiteConnections{SiteID: id}
+	domains, err := c.GetSiteDomains(ctx)
+	if err != nil {
+		return SiteConnections{}, err
+	}
+	owners := map[int64]map[int64]bool{}
+	for _, d := range domains {
+		port := int64(d.Port)
+		if owners[port] == nil {
+			owners[port] = map[int64]bool{}
+		}
+		owners[port][int64(d.Pid)] = true
+	}
+	for port, sites := range owners {
+		if sites[id] {
+			ret.Ports = append(ret.Ports, port)
+			if len(sites) > 1 {
+				ret.Shared = true
+			}
+		}
+	}
+	if len(ret.Ports) == 0 {
+		return SiteConnections{}, fmt.Errorf("site %d has no bound domains", id)
+	}
+	conns, err := c.GetNetWorkList(ctx)
+	if err != nil {
+		return SiteConnections{}, err
+	}
+	for _, conn := range conns {
+		if conn.Status != "ESTABLISHED" || len(conn.Laddr) < 2 {
+			continue
+		}
+		for _, p := range ret.Ports {
+			if fmt.Sprint(conn.Laddr[1]) == strconv.FormatInt(p, 10) {
+				ret.Established++
+				break
+			}
+		}
+	}
+	if ret.Nginx, err = c.GetNginxStatus(ctx); err != nil {
+		return SiteConnections{}, err
+	}
+	return ret, nil
+}
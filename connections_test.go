@@ -0,0 +1,23 @@
+package bt
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestGetSiteConnections(t *testing.T) {
+	c := newFakePanel(t, map[string]http.HandlerFunc{
+		"/data?action=getData": reply(`[{"pid":11,"name":"w1.hao.com","port":8080},{"pid":11,"name":"w1.hao.com","port":80},{"pid":12,"name":"w2.hao.com","port":80}]`),
+		"/ajax?action=GetNetWorkList": reply(`[
+			{"laddr":["0.0.0.0",8080],"status":"LISTEN"},
+			{"laddr":["10.0.0.14",8080],"raddr":["1.1.1.1",5000],"status":"ESTABLISHED"},
+			{"laddr":["10.0.0.14",80],"raddr":["1.1.1.2",5001],"status":"ESTABLISHED"},
+			{"laddr":["10.0.0.14",22],"raddr":["1.1.1.3",5002],"status":"ESTABLISHED"}
+		]`),
+		"/ajax?action=GetNginxStatus": reply(`{"active":3,"Reading":0,"Writing":1,"Waiting":2}`),
+	})
+	r, err := c.GetSiteConnections(ctx, 11)
+	if err != nil || r.Established != 2 || !r.Shared || len(r.Ports) != 2 || r.Nginx.Waiting != 2 {
+		t.Fatalf("GetSiteConnections = %+v, %v", r, err)
+	}
+}
@@ -0,0 +1,54 @@
+package bt
+
+import (
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+)
+
+// panelJar Client 复用的 cookie jar 按面板 host（含端口）保存面板返回的 cookies
+// 只保存和发送面板 host 的 cookies 跟随重定向时不会把面板的 cookies 发给其他 host
+// 也不会保存其他 host 设置的 cookies
+type panelJar struct {
+	c *Client
+}
+
+// jar 返回 u 所属面板 host 的 jar 不是面板 host 或已关闭 cookies 时返回 nil
+func (j panelJar) jar(u *url.URL, create bool) *cookiejar.Jar {
+	c := j.c
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.DisableCookies || c.closed {
+		return nil
+	}
+	panel, err := url.Parse(c.BTAddress)
+	if err != nil || panel.Host != u.Host {
+		return nil
+	}
+	jar := c.cookies[u.Host]
+	if jar == nil && create {
+		if jar, err = cookiejar.New(nil); err != nil {
+			panic(err)
+		}
+		if c.cookies == nil {
+			c.cookies = map[string]*cookiejar.Jar{}
+		}
+		c.cookies[u.Host] = jar
+	}
+	return jar
+}
+
+// SetCookies 实现 http.CookieJar
+func (j panelJar) SetCookies(u *url.URL, cookies []*http.Cookie) {
+	if jar := j.jar(u, true); jar != nil {
+		jar.SetCookies(u, cookies)
+	}
+}
+
+// Cookies 实现 http.CookieJar
+func (j panelJar) Cookies(u *url.URL) []*http.Cookie {
+	if jar := j.jar(u, false); jar != nil {
+		return jar.Cookies(u)
+	}
+	return nil
+}
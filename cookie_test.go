@@ -0,0 +1,62 @@
+package bt
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestCookies(t *testing.T) {
+	var other []string
+	elsewhere := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if cookie, err := r.Cookie("SESSION"); err == nil {
+			other = append(other, cookie.Value)
+		}
+		http.SetCookie(w, &http.Cookie{Name: "TRACKER", Value: "x"})
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer elsewhere.Close()
+
+	var seen []string
+	c := newFakePanel(t, map[string]http.HandlerFunc{
+		"/system?action=GetNetWork": func(w http.ResponseWriter, r *http.Request) {
+			if cookie, err := r.Cookie("SESSION"); err == nil {
+				seen = append(seen, cookie.Value)
+			}
+			if _, err := r.Cookie("TRACKER"); err == nil {
+				t.Error("cookie from redirected host replayed to panel")
+			}
+			http.SetCookie(w, &http.Cookie{Name: "SESSION", Value: "s1"})
+			_, _ = w.Write([]byte(`{}`))
+		},
+		"/system?action=GetSystemTotal": func(w http.ResponseWriter, r *http.Request) {
+			// cookies 不区分端口 重定向到另一个主机名
+			http.Redirect(w, r, strings.Replace(elsewhere.URL, "127.0.0.1", "localhost", 1), http.StatusFound)
+		},
+	})
+	for i := 0; i < 2; i++ {
+		if _, err := c.GetNetWork(ctx); err != nil {
+			t.Fatal(err)
+		}
+	}
+	_, _ = c.GetSystemTotal(ctx)
+	if _, err := c.GetNetWork(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if len(seen) != 2 || seen[0] != "s1" {
+		t.Errorf("panel saw cookies %v", seen)
+	}
+	if len(other) != 0 {
+		t.Errorf("redirected host saw panel cookies %v", other)
+	}
+
+	seen = nil
+	c.DisableCookies = true
+	if _, err := c.GetNetWork(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if len(seen) != 0 {
+		t.Errorf("cookies sent with DisableCookies: %v", seen)
+	}
+}
@@ -0,0 +1,366 @@
+package bt
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CronSchedule 计划任务执行周期 推荐使用 Daily/Hourly 等函数构造
+type CronSchedule struct {
+	Type   string `json:"type"`   // day/day-n/hour/hour-n/minute-n/week/month
+	Where1 int64  `json:"where1"` // day-n/hour-n/minute-n 为间隔 month 为日期
+	Hour   int64  `json:"hour"`
+	Minute int64  `json:"minute"`
+	Week   int64  `json:"week"` // week 类型的星期 1-6 为周一至周六 0 为周日
+}
+
+// Daily 每天 hour:minute 执行
+func Daily(hour int64, minute int64) CronSchedule {
+	return CronSchedule{Type: "day", Hour: hour, Minute: minute}
+}
+
+// EveryNDays 每隔 n 天的 hour:minute 执行
+func EveryNDays(n int64, hour int64, minute int64) CronSchedule {
+	return CronSchedule{Type: "day-n", Where1: n, Hour: hour, Minute: minute}
+}
+
+// Hourly 每小时的第 minute 分钟执行
+func Hourly(minute int64) CronSchedule {
+	return CronSchedule{Type: "hour", Minute: minute}
+}
+
+// EveryNHours 每隔 n 小时的第 minute 分钟执行
+func EveryNHours(n int64, minute int64) CronSchedule {
+	return CronSchedule{Type: "hour-n", Where1: n, Minute: minute}
+}
+
+// EveryNMinutes 每隔 n 分钟执行
+func EveryNMinutes(n int64) CronSchedule {
+	return CronSchedule{Type: "minute-n", Where1: n}
+}
+
+// Weekly 每周 week 的 hour:minute 执行
+func Weekly(week int64, hour int64, minute int64) CronSchedule {
+	return CronSchedule{Type: "week", Week: week, Hour: hour, Minute: minute}
+}
+
+// Monthly 每月 day 日的 hour:minute 执行
+func Monthly(day int64, hour int64, minute int64) CronSchedule {
+	return CronSchedule{Type: "month", Where1: day, Hour: hour, Minute: minute}
+}
+
+// GetCrontabList 获取全部计划任务
+func (c *Client) GetCrontabList(ctx context.Context) ([]CrontabInfo, error) {
+	resp, err := c.btAPI(ctx, map[string][]string{}, "/crontab?action=GetCrontab")
+	if err != nil {
+		return nil, err
+	}
+	var dec []CrontabInfo
+	if err := c.unmarshal(ctx, resp, &dec); err != nil {
+		return nil, err
+	}
+	return dec, nil
+}
+
+// Schedule 计划任务的执行周期 week 类型的星期记录在 where1 中
+func (t CrontabInfo) Schedule() CronSchedule {
+	s := CronSchedule{Type: t.Type, Where1: atoi64(t.Where1), Hour: atoi64(t.WhereHour), Minute: atoi64(t.WhereMinute)}
+	if s.Type == "week" {
+		s.Week, s.Where1 = s.Where1, 0
+	}
+	return s
+}
+
+// AddCrontab 添加计划任务
+func (c *Client) AddCrontab(ctx context.Context, params *ReqAddCrontab) (RespAddCrontab, error) {
+	if params.Name == "" || params.SType == "" || params.Schedule.Type == "" {
+		return RespAddCrontab{}, errors.New("crontab name, type and schedule are required")
+	}
+	backupTo := params.BackupTo
+	if backupTo == "" {
+		backupTo = "localhost"
+	}
+	data := map[string][]string{
+		"name":       {params.Name},
+		"type":       {params.Schedule.Type},
+		"where1":     {strconv.FormatInt(params.Schedule.Where1, 10)},
+		"hour":       {strconv.FormatInt(params.Schedule.Hour, 10)},
+		"minute":     {strconv.FormatInt(params.Schedule.Minute, 10)},
+		"week":       {strconv.FormatInt(params.Schedule.Week, 10)},
+		"sType":      {params.SType},
+		"sName":      {params.SName},
+		"sBody":      {params.SBody},
+		"backupTo":   {backupTo},
+		"save":       {strconv.FormatInt(params.Save, 10)},
+		"urladdress": {params.URLAddress},
+	}
+	resp, err := c.btAPI(ctx, data, "/crontab?action=AddCrontab")
+	if err != nil {
+		return RespAddCrontab{}, err
+	}
+	var dec RespAddCrontab
+	if err := c.unmarshal(ctx, resp, &dec); err != nil {
+		return RespAddCrontab{}, err
+	}
+	if !dec.Status {
+		return dec, newPanelError("/crontab?action=AddCrontab", dec.Msg, resp)
+	}
+	return dec, nil
+}
+
+// DeleteCrontab 删除计划任务
+func (c *Client) DeleteCrontab(ctx context.Context, id int64) (RespMSG, error) {
+	data := map[string][]string{
+		"id": {strconv.FormatInt(id, 10)},
+	}
+	return c.btResult(ctx, data, "/crontab?action=DelCrontab")
+}
+
+// AddShellCrontab 添加 Shell 脚本类型的计划任务 script 为脚本内容
+func (c *Client) AddShellCrontab(ctx context.Context, name string, schedule CronSchedule, script string) (RespAddCrontab, error) {
+	return c.AddCrontab(ctx, &ReqAddCrontab{
+		Name:     name,
+		Schedule: schedule,
+		SType:    "toShell",
+		SBody:    script,
+	})
+}
+
+// AddSiteBackupCrontab 添加备份网站的计划任务 siteName 为 ALL 时备份全部网站 save 为保留的份数
+func (c *Client) AddSiteBackupCrontab(ctx context.Context, siteName string, schedule CronSchedule, save int64) (RespAddCrontab, error) {
+	return c.AddSiteBackupToCrontab(ctx, siteName, schedule, save, BackupLocal)
+}
+
+// AddDatabaseBackupCrontab 添加备份数据库的计划任务 dbName 为 ALL 时备份全部数据库 save 为保留的份数
+func (c *Client) AddDatabaseBackupCrontab(ctx context.Context, dbName string, schedule CronSchedule, save int64) (RespAddCrontab, error) {
+	return c.AddDatabaseBackupToCrontab(ctx, dbName, schedule, save, BackupLocal)
+}
+
+// BackupTarget 备份任务的存储位置 即计划任务的 backupTo 参数
+type BackupTarget string
+
+// 面板内置的存储位置 除 BackupLocal 外需先安装并配置对应的存储插件
+const (
+	BackupLocal      BackupTarget = "localhost"
+	BackupFTP        BackupTarget = "ftp"
+	BackupAliOSS     BackupTarget = "alioss"
+	BackupTencentCOS BackupTarget = "txcos"
+	BackupQiniu      BackupTarget = "qiniu"
+	BackupS3         BackupTarget = "aws_s3"
+	BackupUpyun      BackupTarget = "upyun"
+)
+
+// BackupTargetOption 面板中可选的备份存储位置
+type BackupTargetOption struct {
+	Name  string       `json:"name"` // eg. 阿里云OSS
+	Value BackupTarget `json:"value"`
+}
+
+// GetBackupTargets 获取已配置的备份存储位置 本地磁盘始终可用 不在结果中
+func (c *Client) GetBackupTargets(ctx context.Context) ([]BackupTargetOption, error) {
+	data := map[string][]string{
+		"type": {"sites"},
+	}
+	resp, err := c.btAPI(ctx, data, "/crontab?action=GetDataList")
+	if err != nil {
+		return nil, err
+	}
+	var dec struct {
+		OrderOpt []BackupTargetOption `json:"orderOpt"`
+	}
+	if err := c.unmarshal(ctx, resp, &dec); err != nil {
+		return nil, err
+	}
+	return dec.OrderOpt, nil
+}
+
+// checkBackupTarget 确认 to 为本地或已配置的存储位置
+func (c *Client) checkBackupTarget(ctx context.Context, to BackupTarget) error {
+	if to == BackupLocal {
+		return nil
+	}
+	targets, err := c.GetBackupTargets(ctx)
+	if err != nil {
+		return err
+	}
+	for _, t := range targets {
+		if t.Value == to {
+			return nil
+		}
+	}
+	return errors.New("backup target is not configured: " + string(to))
+}
+
+// AddSiteBackupToCrontab 添加备份网站到 to 的计划任务 siteName 为 ALL 时备份全部网站 save 为保留的份数
+// 远程存储需已在面板中配置 否则返回错误
+func (c *Client) AddSiteBackupToCrontab(ctx context.Context, siteName string, schedule CronSchedule, save int64, to BackupTarget) (RespAddCrontab, error) {
+	if err := c.checkBackupTarget(ctx, to); err != nil {
+		return RespAddCrontab{}, err
+	}
+	return c.AddCrontab(ctx, &ReqAddCrontab{
+		Name:     "备份网站[" + siteName + "]",
+		Schedule: schedule,
+		SType:    "site",
+		SName:    siteName,
+		BackupTo: string(to),
+		Save:     save,
+	})
+}
+
+// AddDatabaseBackupToCrontab 添加备份数据库到 to 的计划任务 dbName 为 ALL 时备份全部数据库 save 为保留的份数
+// 远程存储需已在面板中配置 否则返回错误
+func (c *Client) AddDatabaseBackupToCrontab(ctx context.Context, dbName string, schedule CronSchedule, save int64, to BackupTarget) (RespAddCrontab, error) {
+	if err := c.checkBackupTarget(ctx, to); err != nil {
+		return RespAddCrontab{}, err
+	}
+	return c.AddCrontab(ctx, &ReqAddCrontab{
+		Name:     "备份数据库[" + dbName + "]",
+		Schedule: schedule,
+		SType:    "database",
+		SName:    dbName,
+		BackupTo: string(to),
+		Save:     save,
+	})
+}
+
+// StartCrontab 启用计划任务 已启用时直接返回成功
+func (c *Client) StartCrontab(ctx context.Context, id int64) (RespMSG, error) {
+	return c.setCrontabStatus(ctx, id, true)
+}
+
+// StopCrontab 停用计划任务 已停用时直接返回成功
+func (c *Client) StopCrontab(ctx context.Context, id int64) (RespMSG, error) {
+	return c.setCrontabStatus(ctx, id, false)
+}
+
+// setCrontabStatus 面板的状态接口为取反 先查询当前状态 只在不一致时调用
+func (c *Client) setCrontabStatus(ctx context.Context, id int64, enabled bool) (RespMSG, error) {
+	list, err := c.GetCrontabList(ctx)
+	if err != nil {
+		return RespMSG{}, err
+	}
+	for _, t := range list {
+		if t.ID != id {
+			continue
+		}
+		if (t.Status == 1) == enabled {
+			return RespMSG{Status: true}, nil
+		}
+		data := map[string][]string{
+			"id": {strconv.FormatInt(id, 10)},
+		}
+		return c.btResult(ctx, data, "/crontab?action=set_cron_status")
+	}
+	return RespMSG{}, errors.New("crontab not found: " + strconv.FormatInt(id, 10))
+}
+
+// ClearCrontabLogs 清空计划任务的执行日志
+func (c *Client) ClearCrontabLogs(ctx context.Context, id int64) (RespMSG, error) {
+	data := map[string][]string{
+		"id": {strconv.FormatInt(id, 10)},
+	}
+	return c.btResult(ctx, data, "/crontab?action=DelLogs")
+}
+
+// RunShellScript 借助计划任务在服务器上执行一次 Shell 脚本并返回输出
+// 会临时创建一个每月执行的 Shell 任务 立即执行后删除 适用于没有终端接口的面板
+// wait 为等待脚本执行结束的最长时间 超时返回 ErrCrontabTimeout 时脚本可能仍在运行 临时任务同样会被删除
+func (c *Client) RunShellScript(ctx context.Context, script string, wait time.Duration) (out string, err error) {
+	task, err := c.AddShellCrontab(ctx, "btsdk-run-"+c.newRequestID(), Monthly(1, 0, 0), script)
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		// 即使 ctx 已取消也要删除临时任务
+		if _, derr := c.DeleteCrontab(context.WithoutCancel(ctx), task.ID); err == nil {
+			err = derr
+		}
+	}()
+	return c.RunCrontabNowWithLog(ctx, task.ID, wait)
+}
+
+// RunCrontabNow 立即执行一次计划任务
+func (c *Client) RunCrontabNow(ctx context.Context, id int64) (RespMSG, error) {
+	data := map[string][]string{
+		"id": {strconv.FormatInt(id, 10)},
+	}
+	return c.btResult(ctx, data, "/crontab?action=StartTask")
+}
+
+// GetCrontabLogs 获取计划任务的执行日志 面板将所有执行记录追加在同一日志中
+func (c *Client) GetCrontabLogs(ctx context.Context, id int64) (string, error) {
+	data := map[string][]string{
+		"id": {strconv.FormatInt(id, 10)},
+	}
+	resp, err := c.btAPI(ctx, data, "/crontab?action=GetLogs")
+	if err != nil {
+		return "", err
+	}
+	// 日志内容放在 msg 中 不经过 Translator
+	var dec RespMSG
+	if err := c.unmarshal(ctx, resp, &dec); err != nil {
+		return "", err
+	}
+	if !dec.Status {
+		return "", newPanelError("/crontab?action=GetLogs", dec.Msg, resp)
+	}
+	return dec.Msg, nil
+}
+
+// crontabLogPollInterval 等待计划任务执行时轮询日志的间隔
+var crontabLogPollInterval = time.Second
+
+// ErrCrontabTimeout RunCrontabNowWithLog 在 wait 时间内未等到任务执行结束时返回 任务可能仍在执行
+var ErrCrontabTimeout = errors.New("bt: crontab still running after wait")
+
+// crontabEndMark 面板在每次执行结束后写入日志的标记 eg. ★[2023-01-02 03:04:05] Successful
+var crontabEndMark = regexp.MustCompile(`★\[[^\]]*\]\s*Successful`)
+
+// RunCrontabNowWithLog 立即执行计划任务 并返回本次执行新增的日志
+// 本次新增的日志中出现面板写入的结束标记（★[时间] Successful）即视为执行结束
+// wait 时间内未结束时返回已产生的部分及 ErrCrontabTimeout
+func (c *Client) RunCrontabNowWithLog(ctx context.Context, id int64, wait time.Duration) (string, error) {
+	before, err := c.crontabLogsOrEmpty(ctx, id)
+	if err != nil {
+		return "", err
+	}
+	if _, err := c.RunCrontabNow(ctx, id); err != nil {
+		return "", err
+	}
+	deadline := time.Now().Add(wait)
+	for {
+		t := time.NewTimer(crontabLogPollInterval)
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return "", ctx.Err()
+		case <-t.C:
+		}
+		logs, err := c.crontabLogsOrEmpty(ctx, id)
+		if err != nil {
+			return "", err
+		}
+		out := strings.TrimPrefix(logs, before)
+		if crontabEndMark.MatchString(out) {
+			return out, nil
+		}
+		if time.Now().After(deadline) {
+			return out, ErrCrontabTimeout
+		}
+	}
+}
+
+// crontabLogsOrEmpty 获取计划任务日志 任务尚未产生日志时面板返回失败消息 视为空日志
+func (c *Client) crontabLogsOrEmpty(ctx context.Context, id int64) (string, error) {
+	logs, err := c.GetCrontabLogs(ctx, id)
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && !apiErr.PanelStatus && apiErr.HTTPStatus == http.StatusOK {
+		return "", nil
+	}
+	return logs, err
+}
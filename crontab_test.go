@@ -0,0 +1,192 @@
+package bt
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestRunShellScript(t *testing.T) {
+	crontabLogPollInterval = time.Millisecond
+	defer func() { crontabLogPollInterval = time.Second }()
+	const end = "----------\n★[2024-01-02 03:04:05] Successful\n----------\n"
+	logs, polls, started := "", 0, false
+	deleted := ""
+	c := newFakePanel(t, map[string]http.HandlerFunc{
+		"/crontab?action=AddCrontab": func(w http.ResponseWriter, r *http.Request) {
+			if r.FormValue("sType") != "toShell" || r.FormValue("sBody") != "uptime" || r.FormValue("type") != "month" {
+				t.Errorf("unexpected form %v", r.PostForm)
+			}
+			_, _ = w.Write([]byte(`{"status":true,"msg":"添加成功","id":7}`))
+		},
+		"/crontab?action=StartTask": func(w http.ResponseWriter, r *http.Request) {
+			started = true
+			_, _ = w.Write([]byte(`{"status":true,"msg":"任务已执行"}`))
+		},
+		"/crontab?action=GetLogs": func(w http.ResponseWriter, r *http.Request) {
+			if started {
+				// 输出后静默数次轮询才写入结束标记
+				polls++
+				switch polls {
+				case 1:
+					logs = "up 3 days\n"
+				case 5:
+					logs += end
+				}
+			}
+			if logs == "" {
+				_, _ = w.Write([]byte(`{"status":false,"msg":"当前日志为空!"}`))
+				return
+			}
+			_ = json.NewEncoder(w).Encode(RespMSG{Status: true, Msg: logs})
+		},
+		"/crontab?action=DelCrontab": func(w http.ResponseWriter, r *http.Request) {
+			deleted = r.FormValue("id")
+			_, _ = w.Write([]byte(`{"status":true,"msg":"删除成功"}`))
+		},
+	})
+	out, err := c.RunShellScript(ctx, "uptime", time.Minute)
+	if err != nil || out != "up 3 days\n"+end || deleted != "7" {
+		t.Fatalf("out %q err %v deleted %q", out, err, deleted)
+	}
+}
+
+func TestRunCrontabNowWithLog(t *testing.T) {
+	crontabLogPollInterval = time.Millisecond
+	defer func() { crontabLogPollInterval = time.Second }()
+	logsDown := true
+	c := newFakePanel(t, map[string]http.HandlerFunc{
+		"/crontab?action=StartTask": reply(`{"status":true,"msg":"任务已执行"}`),
+		"/crontab?action=GetLogs": func(w http.ResponseWriter, r *http.Request) {
+			if logsDown {
+				http.Error(w, "upstream down", http.StatusBadGateway)
+				return
+			}
+			_, _ = w.Write([]byte(`{"status":true,"msg":"old run\nstill running\n"}`))
+		},
+	})
+	var apiErr *APIError
+	if _, err := c.RunCrontabNowWithLog(ctx, 3, time.Minute); !errors.As(err, &apiErr) || apiErr.HTTPStatus != http.StatusBadGateway {
+		t.Fatalf("GetLogs error not returned: %v", err)
+	}
+	logsDown = false
+	if out, err := c.RunCrontabNowWithLog(ctx, 3, 5*time.Millisecond); !errors.Is(err, ErrCrontabTimeout) || out != "" {
+		t.Fatalf("out %q err %v", out, err)
+	}
+}
+
+func TestRunShellScriptCleanup(t *testing.T) {
+	crontabLogPollInterval = time.Hour
+	defer func() { crontabLogPollInterval = time.Second }()
+	deleted, logs := "", ""
+	delMsg := `{"status":true,"msg":"删除成功"}`
+	runCtx, cancel := context.WithCancel(ctx)
+	c := newFakePanel(t, map[string]http.HandlerFunc{
+		"/crontab?action=AddCrontab": reply(`{"status":true,"msg":"添加成功","id":7}`),
+		"/crontab?action=GetLogs": func(w http.ResponseWriter, r *http.Request) {
+			_ = json.NewEncoder(w).Encode(RespMSG{Status: true, Msg: logs})
+		},
+		"/crontab?action=StartTask": func(w http.ResponseWriter, r *http.Request) {
+			cancel()
+			logs += "★[2024-01-02 03:04:05] Successful\n"
+			_, _ = w.Write([]byte(`{"status":true,"msg":"任务已执行"}`))
+		},
+		"/crontab?action=DelCrontab": func(w http.ResponseWriter, r *http.Request) {
+			deleted = r.FormValue("id")
+			_, _ = w.Write([]byte(delMsg))
+		},
+	})
+	// ctx 取消后不再等待轮询间隔 且仍会删除临时任务
+	if _, err := c.RunShellScript(runCtx, "uptime", time.Hour); !errors.Is(err, context.Canceled) || deleted != "7" {
+		t.Fatalf("err %v deleted %q", err, deleted)
+	}
+
+	crontabLogPollInterval = time.Millisecond
+	delMsg = `{"status":false,"msg":"删除失败"}`
+	var apiErr *APIError
+	if _, err := c.RunShellScript(ctx, "uptime", time.Minute); !errors.As(err, &apiErr) || apiErr.Endpoint != "/crontab?action=DelCrontab" {
+		t.Fatalf("delete error not returned: %v", err)
+	}
+}
+
+func TestCrontabStatus(t *testing.T) {
+	toggled := 0
+	c := newFakePanel(t, map[string]http.HandlerFunc{
+		"/crontab?action=GetCrontab": reply(`[
+			{"id":1,"name":"备份网站[a.com]","type":"week","where1":"3","where_hour":"2","where_minute":"30","status":1,"sType":"site","sName":"a.com"},
+			{"id":2,"name":"shell","type":"day","where_hour":"4","where_minute":"0","status":0,"sType":"toShell"}
+		]`),
+		"/crontab?action=set_cron_status": func(w http.ResponseWriter, r *http.Request) {
+			toggled++
+			_, _ = w.Write([]byte(`{"status":true,"msg":"设置成功"}`))
+		},
+	})
+	list, err := c.GetCrontabList(ctx)
+	if err != nil || len(list) != 2 {
+		t.Fatalf("GetCrontabList = %+v, %v", list, err)
+	}
+	if s := list[0].Schedule(); s != Weekly(3, 2, 30) {
+		t.Errorf("Schedule = %+v", s)
+	}
+	if _, err := c.StartCrontab(ctx, 1); err != nil || toggled != 0 {
+		t.Fatalf("StartCrontab on enabled task: %v, toggled %d", err, toggled)
+	}
+	if _, err := c.StartCrontab(ctx, 2); err != nil || toggled != 1 {
+		t.Fatalf("StartCrontab: %v, toggled %d", err, toggled)
+	}
+	if _, err := c.StopCrontab(ctx, 9); err == nil {
+		t.Error("unknown crontab should fail")
+	}
+}
+
+func TestBackupToCrontab(t *testing.T) {
+	var backupTo []string
+	c := newFakePanel(t, map[string]http.HandlerFunc{
+		"/crontab?action=GetDataList": reply(`{"data":[{"name":"w1.hao.com","ps":""}],"orderOpt":[{"name":"阿里云OSS","value":"alioss"}]}`),
+		"/crontab?action=AddCrontab": func(w http.ResponseWriter, r *http.Request) {
+			backupTo = append(backupTo, r.FormValue("sType")+" "+r.FormValue("backupTo"))
+			_, _ = w.Write([]byte(`{"status":true,"msg":"添加成功","id":5}`))
+		},
+	})
+	if _, err := c.AddSiteBackupToCrontab(ctx, "ALL", Daily(2, 0), 7, BackupAliOSS); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.AddDatabaseBackupCrontab(ctx, "ALL", Daily(3, 0), 7); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.AddDatabaseBackupToCrontab(ctx, "ALL", Daily(3, 0), 7, BackupFTP); err == nil {
+		t.Error("unconfigured target accepted")
+	}
+	if len(backupTo) != 2 || backupTo[0] != "site alioss" || backupTo[1] != "database localhost" {
+		t.Errorf("backupTo = %q", backupTo)
+	}
+}
+
+func TestCrontabLogs(t *testing.T) {
+	var started string
+	c := newFakePanel(t, map[string]http.HandlerFunc{
+		"/crontab?action=StartTask": func(w http.ResponseWriter, r *http.Request) {
+			started = r.FormValue("id")
+			_, _ = w.Write([]byte(`{"status":true,"msg":"任务已执行"}`))
+		},
+		"/crontab?action=GetLogs": func(w http.ResponseWriter, r *http.Request) {
+			if r.FormValue("id") == "9" {
+				_, _ = w.Write([]byte(`{"status":false,"msg":"当前日志为空!"}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"status":true,"msg":"备份成功\n"}`))
+		},
+	})
+	if r, err := c.RunCrontabNow(ctx, 3); err != nil || !r.Status || started != "3" {
+		t.Fatalf("RunCrontabNow = %+v, %v, id %q", r, err, started)
+	}
+	if logs, err := c.GetCrontabLogs(ctx, 3); err != nil || logs != "备份成功\n" {
+		t.Fatalf("GetCrontabLogs = %q, %v", logs, err)
+	}
+	var apiErr *APIError
+	if logs, err := c.GetCrontabLogs(ctx, 9); !errors.As(err, &apiErr) || apiErr.Endpoint != "/crontab?action=GetLogs" || apiErr.Msg != "当前日志为空!" || logs != "" {
+		t.Fatalf("GetCrontabLogs = %q, %v", logs, err)
+	}
+}
@@ -0,0 +1,58 @@
+package bt
+
+import (
+	"log"
+	"sort"
+	"strings"
+)
+
+// Curl 将实际发送的签名请求渲染为等价的 curl 命令 便于手动复现失败的调用
+// includeSecrets 为 false 时隐藏 request_token 及密码等敏感参数 请求未发出时返回空字符串
+// 上传请求的文件内容以 @<文件名> 占位
+func (info *RequestInfo) Curl(includeSecrets bool) string {
+	if info.url == "" {
+		return ""
+	}
+	keys := make([]string, 0, len(info.form))
+	for k := range info.form {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	flag := "--data-urlencode"
+	if info.multipart {
+		flag = "-F"
+	}
+	args := []string{"curl", "-sS", "-X", "POST", "-H", shellQuote(RequestIDHeader + ": " + info.ID)}
+	for _, k := range keys {
+		for _, v := range info.form[k] {
+			if !includeSecrets && sensitiveParam(k) {
+				v = "[REDACTED]"
+			}
+			args = append(args, flag, shellQuote(k+"="+v))
+		}
+	}
+	if info.multipart {
+		name := info.form.Get("f_name")
+		args = append(args, "-F", shellQuote("blob=@"+name))
+	}
+	args = append(args, shellQuote(info.url))
+	return strings.Join(args, " ")
+}
+
+// CurlHook 以 curl 命令的形式记录每次调用 logger 为空时使用 log 默认 logger
+// includeSecrets 为 true 时日志中包含有效的签名 仅应在本地调试时开启
+func CurlHook(logger *log.Logger, includeSecrets bool) Hook {
+	if logger == nil {
+		logger = log.Default()
+	}
+	return func(info *RequestInfo) {
+		if cmd := info.Curl(includeSecrets); cmd != "" {
+			logger.Printf("bt: [%s] %s", info.ID, cmd)
+		}
+	}
+}
+
+// shellQuote 以单引号包裹 供 POSIX shell 使用
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
@@ -0,0 +1,40 @@
+package bt
+
+import (
+	"bytes"
+	"log"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestCurlHook(t *testing.T) {
+	c := newFakePanel(t, map[string]http.HandlerFunc{
+		"/site?action=SetHasPwd": reply(`{"status":true,"msg":"设置成功"}`),
+	})
+	var buf bytes.Buffer
+	c.Hooks = []Hook{CurlHook(log.New(&buf, "", 0), false)}
+	c.RequestIDFunc = func() string { return "req-1" }
+	if _, err := c.SetHasPwd(ctx, 1, "it's", "secret"); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+	for _, want := range []string{
+		"curl -sS -X POST -H 'X-Request-ID: req-1'",
+		"--data-urlencode 'password=[REDACTED]'",
+		"--data-urlencode 'request_token=[REDACTED]'",
+		`--data-urlencode 'username=it'\''s'`,
+		"'" + c.BTAddress + "/site?action=SetHasPwd'",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("missing %q in %s", want, out)
+		}
+	}
+	if strings.Contains(out, "secret") {
+		t.Errorf("secret leaked: %s", out)
+	}
+	info := &RequestInfo{}
+	if info.Curl(true) != "" {
+		t.Error("expected empty command for unsent request")
+	}
+}
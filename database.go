@@ -0,0 +1,98 @@
+package bt
+
+import (
+	"context"
+	"errors"
+	"strconv"
+)
+
+// DatabaseCharsets 面板支持的数据库字符集
+var DatabaseCharsets = []string{"utf8mb4", "utf8", "gbk", "big5"}
+
+// AddDatabase 添加 MySQL 数据库
+func (c *Client) AddDatabase(ctx context.Context, params *ReqAddDatabase) (RespMSG, error) {
+	if params.Name == "" || params.Password == "" {
+		return RespMSG{}, errors.New("database name and password are required")
+	}
+	charset := params.Charset
+	if charset == "" {
+		charset = "utf8mb4"
+	}
+	valid := false
+	for _, v := range DatabaseCharsets {
+		if v == charset {
+			valid = true
+			break
+		}
+	}
+	if !valid {
+		return RespMSG{}, errors.New("unsupported database charset: " + charset)
+	}
+	user := params.User
+	if user == "" {
+		user = params.Name
+	}
+	access := params.DataAccess
+	if access == "" {
+		access = "127.0.0.1"
+	}
+	data := map[string][]string{
+		"name":       {params.Name},
+		"db_user":    {user},
+		"password":   {params.Password},
+		"codeing":    {charset},
+		"dtype":      {"MySQL"},
+		"dataAccess": {access},
+		"address":    {access},
+		"ps":         {params.PS},
+		"sid":        {strconv.FormatInt(params.SID, 10)},
+	}
+	if params.Collation != "" {
+		data["collation"] = []string{params.Collation}
+	}
+	return c.btResult(ctx, data, "/database?action=AddDatabase")
+}
+
+// AddRemoteDatabaseServer 添加远程 MySQL 服务器 之后可通过 ReqAddDatabase.SID 在其上创建数据库
+func (c *Client) AddRemoteDatabaseServer(ctx context.Context, params *ReqDatabaseServer) (RespMSG, error) {
+	if params.Host == "" || params.User == "" {
+		return RespMSG{}, errors.New("database server host and user are required")
+	}
+	port := params.Port
+	if port == 0 {
+		port = 3306
+	}
+	data := map[string][]string{
+		"db_host":     {params.Host},
+		"db_port":     {strconv.FormatInt(port, 10)},
+		"db_user":     {params.User},
+		"db_password": {params.Password},
+		"db_ps":       {params.PS},
+		"type":        {"mysql"},
+	}
+	return c.btResult(ctx, data, "/database?action=AddCloudServer")
+}
+
+// ListDatabaseServers 获取已添加的 MySQL 服务器列表
+func (c *Client) ListDatabaseServers(ctx context.Context) (DatabaseServers, error) {
+	data := map[string][]string{
+		"type": {"mysql"},
+	}
+	resp, err := c.btAPI(ctx, data, "/database?action=GetCloudServer")
+	if err != nil {
+		return DatabaseServers{}, err
+	}
+	var dec DatabaseServers
+	if err := c.unmarshal(ctx, resp, &dec); err != nil {
+		return DatabaseServers{}, err
+	}
+	return dec, nil
+}
+
+// DeleteDatabaseServer 删除远程 MySQL 服务器 不会删除其上的数据库
+func (c *Client) DeleteDatabaseServer(ctx context.Context, id int64) (RespMSG, error) {
+	data := map[string][]string{
+		"id": {strconv.FormatInt(id, 10)},
+	}
+	return c.btResult(ctx, data, "/database?action=RemoveCloudServer")
+}
@@ -0,0 +1,86 @@
+package bt
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestAddDatabase(t *testing.T) {
+	var form map[string]string
+	c := newFakePanel(t, map[string]http.HandlerFunc{
+		"/database?action=AddDatabase": func(w http.ResponseWriter, r *http.Request) {
+			form = map[string]string{}
+			for _, k := range []string{"name", "db_user", "codeing", "collation", "dataAccess", "address", "sid"} {
+				form[k] = r.FormValue(k)
+			}
+			if r.FormValue("name") == "exists" {
+				_, _ = w.Write([]byte(`{"status":false,"msg":"数据库已存在!"}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"status":true,"msg":"添加成功"}`))
+		},
+	})
+	if _, err := c.AddDatabase(ctx, &ReqAddDatabase{Name: "shop", Password: "p"}); err != nil {
+		t.Fatal(err)
+	}
+	if form["db_user"] != "shop" || form["codeing"] != "utf8mb4" || form["collation"] != "" || form["dataAccess"] != "127.0.0.1" || form["sid"] != "0" {
+		t.Errorf("default form %v", form)
+	}
+	_, err := c.AddDatabase(ctx, &ReqAddDatabase{Name: "blog", User: "u", Password: "p", Charset: "gbk", Collation: "gbk_chinese_ci", DataAccess: "%", SID: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if form["db_user"] != "u" || form["codeing"] != "gbk" || form["collation"] != "gbk_chinese_ci" || form["address"] != "%" || form["sid"] != "2" {
+		t.Errorf("form %v", form)
+	}
+
+	var apiErr *APIError
+	if _, err := c.AddDatabase(ctx, &ReqAddDatabase{Name: "exists", Password: "p"}); !errors.As(err, &apiErr) || apiErr.Endpoint != "/database?action=AddDatabase" {
+		t.Errorf("AddDatabase error %v", err)
+	}
+	form = nil
+	if _, err := c.AddDatabase(ctx, &ReqAddDatabase{Name: "x", Password: "p", Charset: "latin1"}); err == nil || form != nil {
+		t.Error("unsupported charset sent to panel")
+	}
+	if _, err := c.AddDatabase(ctx, &ReqAddDatabase{Name: "x"}); err == nil {
+		t.Error("missing password accepted")
+	}
+}
+
+func TestDatabaseServers(t *testing.T) {
+	var added, removed string
+	c := newFakePanel(t, map[string]http.HandlerFunc{
+		"/database?action=AddCloudServer": func(w http.ResponseWriter, r *http.Request) {
+			added = r.FormValue("db_host") + ":" + r.FormValue("db_port") + " " + r.FormValue("db_user") + " " + r.FormValue("type")
+			_, _ = w.Write([]byte(`{"status":true,"msg":"添加成功"}`))
+		},
+		"/database?action=GetCloudServer": func(w http.ResponseWriter, r *http.Request) {
+			if r.FormValue("type") != "mysql" {
+				t.Errorf("unexpected form %v", r.Form)
+			}
+			_, _ = w.Write([]byte(`[{"id":2,"db_host":"10.0.0.5","db_port":3307,"db_user":"root","db_password":"p","ps":"db","db_type":"mysql"}]`))
+		},
+		"/database?action=RemoveCloudServer": func(w http.ResponseWriter, r *http.Request) {
+			removed = r.FormValue("id")
+			_, _ = w.Write([]byte(`{"status":false,"msg":"该服务器下存在数据库"}`))
+		},
+	})
+	if _, err := c.AddRemoteDatabaseServer(ctx, &ReqDatabaseServer{Host: "10.0.0.5", User: "root", Password: "p"}); err != nil {
+		t.Fatal(err)
+	}
+	if added != "10.0.0.5:3306 root mysql" {
+		t.Errorf("added %q", added)
+	}
+	if _, err := c.AddRemoteDatabaseServer(ctx, &ReqDatabaseServer{Host: "10.0.0.5"}); err == nil {
+		t.Error("missing user accepted")
+	}
+	servers, err := c.ListDatabaseServers(ctx)
+	if err != nil || len(servers) != 1 || servers[0].ID != 2 || servers[0].Port != 3307 || servers[0].Type != "mysql" {
+		t.Fatalf("ListDatabaseServers = %+v, %v", servers, err)
+	}
+	var apiErr *APIError
+	if _, err := c.DeleteDatabaseServer(ctx, 2); !errors.As(err, &apiErr) || removed != "2" {
+		t.Errorf("DeleteDatabaseServer = %v, removed %q", err, removed)
+	}
+}
@@ -0,0 +1,63 @@
+package bt
+
+import (
+	"bytes"
+	"context"
+	stdjson "encoding/json"
+	"errors"
+
+	jsoniter "github.com/json-iterator/go"
+)
+
+// Decoder 解析面板响应所用的 JSON 实现
+type Decoder int
+
+const (
+	DecoderJSONIter Decoder = iota // 默认 json-iterator（与标准库兼容的配置）
+	DecoderStd                     // 标准库 encoding/json 面板字段类型不一致时报错信息更准确
+)
+
+// strictJSONIter 在 ConfigCompatibleWithStandardLibrary 的基础上拒绝未知字段
+var strictJSONIter = jsoniter.Config{
+	EscapeHTML:             true,
+	SortMapKeys:            true,
+	ValidateJsonRawMessage: true,
+	DisallowUnknownFields:  true,
+}.Froze()
+
+// unmarshal 对面板响应应用已注册的 Migration 后解析到 v
+func (c *Client) unmarshal(ctx context.Context, data []byte, v interface{}) error {
+	migrated, err := c.migrate(ctx, data, v)
+	if err != nil {
+		return newDecodeError(v, data, err)
+	}
+	return c.decode(migrated, v)
+}
+
+// decode 按 Decoder 和 StrictDecode 的配置解析面板响应 失败时返回 *DecodeError
+func (c *Client) decode(data []byte, v interface{}) error {
+	if err := c.decodeWith(data, v); err != nil {
+		return newDecodeError(v, data, err)
+	}
+	return nil
+}
+
+func (c *Client) decodeWith(data []byte, v interface{}) error {
+	if c.Decoder == DecoderStd {
+		dec := stdjson.NewDecoder(bytes.NewReader(data))
+		if c.StrictDecode {
+			dec.DisallowUnknownFields()
+		}
+		if err := dec.Decode(v); err != nil {
+			return err
+		}
+		if dec.More() {
+			return errors.New("invalid character after top-level value")
+		}
+		return nil
+	}
+	if c.StrictDecode {
+		return strictJSONIter.Unmarshal(data, v)
+	}
+	return json.Unmarshal(data, v)
+}
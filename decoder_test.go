@@ -0,0 +1,22 @@
+package bt
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestStrictDecode(t *testing.T) {
+	c := newFakePanel(t, map[string]http.HandlerFunc{
+		"/files?action=GetFileBody": reply(`{"status":true,"data":"x","encoding":"utf-8","size":1}`),
+	})
+	for _, d := range []Decoder{DecoderJSONIter, DecoderStd} {
+		c.Decoder, c.StrictDecode = d, false
+		if f, err := c.GetFile(ctx, "/www/a"); err != nil || f.Data != "x" {
+			t.Errorf("decoder %d: GetFile = %+v, %v", d, f, err)
+		}
+		c.StrictDecode = true
+		if _, err := c.GetFile(ctx, "/www/a"); err == nil {
+			t.Errorf("decoder %d: unknown field should fail in strict mode", d)
+		}
+	}
+}
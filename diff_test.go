@@ -0,0 +1,25 @@
+package bt
+
+import "testing"
+
+func TestUnifiedDiff(t *testing.T) {
+	a := "server {\n    listen 80;\n    server_name a.com;\n    root /www/a;\n}\n"
+	b := "server {\n    listen 80;\n    listen 443 ssl;\n    server_name a.com;\n    root /www/a;\n}\n"
+	want := "--- a\n+++ b\n@@ -1,5 +1,6 @@\n server {\n     listen 80;\n+    listen 443 ssl;\n     server_name a.com;\n     root /www/a;\n }\n"
+	if got := UnifiedDiff(a, b, "a", "b"); got != want {
+		t.Fatalf("got:\n%s\nwant:\n%s", got, want)
+	}
+	if UnifiedDiff(a, a, "a", "b") != "" {
+		t.Fatal("expected empty diff")
+	}
+}
+
+func TestUnifiedDiff_Hunks(t *testing.T) {
+	a := "1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\n12\n"
+	b := "0\n1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\n"
+	got := UnifiedDiff(a, b, "a", "b")
+	want := "--- a\n+++ b\n@@ -1,3 +1,4 @@\n+0\n 1\n 2\n 3\n@@ -9,4 +10,3 @@\n 9\n 10\n 11\n-12\n"
+	if got != want {
+		t.Fatalf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
@@ -0,0 +1,65 @@
+package bt
+
+import (
+	"context"
+	"strings"
+)
+
+// dirIndexBlock 开启目录浏览的 nginx 配置
+const dirIndexBlock = "autoindex on;\nautoindex_exact_size off;\nautoindex_localtime on;"
+
+// dirIndexEnabled 配置中是否有生效的 autoindex on 指令
+func dirIndexEnabled(conf string) bool {
+	for _, line := range strings.Split(conf, "\n") {
+		fields := strings.Fields(strings.TrimSuffix(strings.TrimSpace(line), ";"))
+		if len(fields) == 2 && fields[0] == "autoindex" && fields[1] == "on" {
+			return true
+		}
+	}
+	return false
+}
+
+// dropStaticListing 移除 AddStaticSite 写入的 autoindex 指令 避免与 DIRINDEX 管理段重复
+func dropStaticListing(conf string) string {
+	start, end := strings.Index(conf, "#BTSDK-STATIC-START"), strings.Index(conf, "#BTSDK-STATIC-END")
+	if start < 0 || end < start {
+		return conf
+	}
+	var kept []string
+	for _, line := range strings.Split(conf[start:end], "\n") {
+		if !strings.HasPrefix(strings.TrimSpace(line), "autoindex") {
+			kept = append(kept, line)
+		}
+	}
+	return conf[:start] + strings.Join(kept, "\n") + conf[end:]
+}
+
+// GetDirIndexStatus 获取网站是否开启了目录浏览（仅支持 nginx）
+func (c *Client) GetDirIndexStatus(ctx context.Context, id int64) (bool, error) {
+	name, err := c.siteKey(ctx, id, "name")
+	if err != nil {
+		return false, err
+	}
+	path := NginxVhostPath(name)
+	conf, err := c.readFile(ctx, path)
+	if err != nil {
+		return false, err
+	}
+	return dirIndexEnabled(conf), nil
+}
+
+// SetDirIndex 开启或关闭网站的目录浏览（仅支持 nginx） 返回配置文件变更的 diff 配置未变化时为空
+// 需要批量检查或设置多台面板时可配合 Cluster.Each 使用
+func (c *Client) SetDirIndex(ctx context.Context, id int64, enabled bool) (string, error) {
+	name, err := c.siteKey(ctx, id, "name")
+	if err != nil {
+		return "", err
+	}
+	block := ""
+	if enabled {
+		block = dirIndexBlock
+	}
+	return c.SafeEditConfig(ctx, NginxVhostPath(name), func(conf string) (string, error) {
+		return setManagedBlock(dropStaticListing(conf), "DIRINDEX", block), nil
+	}, nil)
+}
@@ -0,0 +1,41 @@
+package bt
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestSetDirIndex(t *testing.T) {
+	vhost := NginxVhostPath("w1.hao.com")
+	conf := "server\n{\n    listen 80;\n    #BTSDK-STATIC-START\n    charset utf-8;\n    autoindex on;\n    autoindex_exact_size off;\n    autoindex_localtime on;\n    #BTSDK-STATIC-END\n}\n"
+	c := newFakePanel(t, map[string]http.HandlerFunc{
+		"/data?action=getKey": reply(`"w1.hao.com"`),
+		"/files?action=GetFileBody": func(w http.ResponseWriter, r *http.Request) {
+			_ = json.NewEncoder(w).Encode(RespGetFile{Status: r.FormValue("path") == vhost, Data: conf})
+		},
+		"/files?action=SaveFileBody": func(w http.ResponseWriter, r *http.Request) {
+			conf = r.FormValue("data")
+			_, _ = w.Write([]byte(`{"status":true,"msg":"文件已保存!"}`))
+		},
+	})
+	on, err := c.GetDirIndexStatus(ctx, 1)
+	if err != nil || !on {
+		t.Fatalf("GetDirIndexStatus = %v, %v", on, err)
+	}
+	if _, err := c.SetDirIndex(ctx, 1, false); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(conf, "autoindex") || !strings.Contains(conf, "charset utf-8;") {
+		t.Fatalf("disable:\n%s", conf)
+	}
+	if _, err := c.SetDirIndex(ctx, 1, true); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Count(conf, "autoindex on;") != 1 || !strings.Contains(conf, "#BTSDK-DIRINDEX-START") {
+		t.Fatalf("enable:\n%s", conf)
+	}
+	if on, _ := c.GetDirIndexStatus(ctx, 1); !on {
+		t.Error("GetDirIndexStatus after enable = false")
+	}
+}
@@ -0,0 +1,96 @@
+package bt
+
+import (
+	"errors"
+	"log"
+	"strconv"
+	"strings"
+)
+
+// DomainChange 网站域名变更
+type DomainChange struct {
+	SiteID   int64
+	SiteName string
+	Domain   string
+	Port     int64
+	Added    bool // true 为添加 false 为删除
+}
+
+// DomainChangeListener 域名变更监听器 在面板操作成功后调用
+// 同一域名可能因重试被多次通知 实现应保证幂等
+type DomainChangeListener interface {
+	DomainChanged(change DomainChange) error
+}
+
+// DomainListenerFunc 函数形式的 DomainChangeListener
+type DomainListenerFunc func(change DomainChange) error
+
+// DomainChanged 实现 DomainChangeListener
+func (f DomainListenerFunc) DomainChanged(change DomainChange) error {
+	return f(change)
+}
+
+// NopDomainListener 不做任何处理的监听器
+type NopDomainListener struct{}
+
+// DomainChanged 实现 DomainChangeListener
+func (NopDomainListener) DomainChanged(DomainChange) error {
+	return nil
+}
+
+// LogDomainListener 将域名变更写入日志的监听器 Logger 为空时使用 log 默认 logger
+type LogDomainListener struct {
+	Logger *log.Logger
+}
+
+// DomainChanged 实现 DomainChangeListener
+func (l LogDomainListener) DomainChanged(change DomainChange) error {
+	logger := l.Logger
+	if logger == nil {
+		logger = log.Default()
+	}
+	op := "removed from"
+	if change.Added {
+		op = "added to"
+	}
+	logger.Printf("bt: domain %s:%d %s site %s(%d)", change.Domain, change.Port, op, change.SiteName, change.SiteID)
+	return nil
+}
+
+// DomainListenerError 面板操作已成功但监听器返回错误
+type DomainListenerError struct {
+	Change DomainChange
+	Err    error
+}
+
+func (e *DomainListenerError) Error() string {
+	return "bt: domain listener failed for " + e.Change.Domain + ": " + e.Err.Error()
+}
+
+func (e *DomainListenerError) Unwrap() error {
+	return e.Err
+}
+
+// notifyDomainChange 通知所有监听器 domain 可为逗号或换行分隔的多个 "域名[:端口]"
+func (c *Client) notifyDomainChange(id int64, webname string, domain string, port int64, added bool) error {
+	if len(c.DomainListeners) == 0 {
+		return nil
+	}
+	var errs []error
+	for _, d := range strings.FieldsFunc(domain, func(r rune) bool { return r == ',' || r == '\n' }) {
+		change := DomainChange{SiteID: id, SiteName: webname, Domain: strings.TrimSpace(d), Port: port, Added: added}
+		if name, p, ok := strings.Cut(change.Domain, ":"); ok {
+			change.Domain = name
+			change.Port, _ = strconv.ParseInt(p, 10, 64)
+		}
+		if change.Port == 0 {
+			change.Port = 80
+		}
+		for _, l := range c.DomainListeners {
+			if err := l.DomainChanged(change); err != nil {
+				errs = append(errs, &DomainListenerError{Change: change, Err: err})
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,37 @@
+package bt
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestDomainListeners(t *testing.T) {
+	c := newFakePanel(t, map[string]http.HandlerFunc{
+		"/site?action=AddDomain": reply(`{"status":true,"msg":"添加成功"}`),
+		"/site?action=DelDomain": reply(`{"status":false,"msg":"指定域名不存在"}`),
+	})
+	var got []DomainChange
+	c.DomainListeners = []DomainChangeListener{NopDomainListener{}, DomainListenerFunc(func(change DomainChange) error {
+		got = append(got, change)
+		if change.Domain == "b.hao.com" {
+			return errors.New("cdn down")
+		}
+		return nil
+	})}
+	r, err := c.AddDomain(ctx, 11, "w1.hao.com", "a.hao.com,b.hao.com:8080")
+	var le *DomainListenerError
+	if !r.Status || !errors.As(err, &le) || le.Change.Domain != "b.hao.com" {
+		t.Fatalf("AddDomain = %+v, %v", r, err)
+	}
+	want := []DomainChange{
+		{SiteID: 11, SiteName: "w1.hao.com", Domain: "a.hao.com", Port: 80, Added: true},
+		{SiteID: 11, SiteName: "w1.hao.com", Domain: "b.hao.com", Port: 8080, Added: true},
+	}
+	if len(got) != 2 || got[0] != want[0] || got[1] != want[1] {
+		t.Fatalf("changes %+v", got)
+	}
+	if _, err := c.DelDomain(ctx, 11, "w1.hao.com", "a.hao.com", 80); err == nil || err.Error() != "指定域名不存在" || len(got) != 2 {
+		t.Fatalf("listener called on failed DelDomain: %v %+v", err, got)
+	}
+}
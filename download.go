@@ -0,0 +1,145 @@
+package bt
+
+import (
+	"context"
+	"errors"
+	"io"
+	"mime"
+	"time"
+)
+
+// btStream 发起签名请求并返回未读取的响应 Body 及其长度（未知时为 -1） 用于下载大文件
+// Timeout 不作用于读取 Body ctx 取消时读取 Body 会返回错误 面板以 JSON 返回错误时转换为 error
+func (c *Client) btStream(ctx context.Context, data map[string][]string, endpoint string) (io.ReadCloser, int64, error) {
+	info := &RequestInfo{
+		ctx:      ctx,
+		ID:       c.newRequestID(),
+		Endpoint: endpoint,
+		Params:   data,
+		Start:    time.Now(),
+	}
+	resp, err := c.send(info, 0)
+	if err == nil {
+		if ct, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type")); ct == "application/json" {
+			body, _ := io.ReadAll(resp.Body)
+			resp.Body.Close()
+			var dec RespMSG
+			if json.Unmarshal(body, &dec) != nil || dec.Msg == "" {
+				dec.Msg = string(body)
+			}
+			err = newPanelError(endpoint, dec.Msg, body)
+		}
+	}
+	info.Duration = time.Since(info.Start)
+	info.Err = err
+	info.Outcome = classifyOutcome(info, nil)
+	for _, hook := range c.Hooks {
+		hook(info)
+	}
+	if err != nil {
+		return nil, 0, err
+	}
+	return resp.Body, resp.ContentLength, nil
+}
+
+// DownloadURL 构造下载服务器文件的签名 URL 可交给浏览器或下载工具使用
+// URL 中包含签名 面板只在短时间内认可 且不应写入日志
+func (c *Client) DownloadURL(filename string) string {
+	return c.endpointURL("/download") + "?" + c.signedForm(map[string][]string{
+		"filename": {filename},
+	}).Encode()
+}
+
+// BackupDownloadURL 构造下载备份文件的签名 URL
+func (c *Client) BackupDownloadURL(b BackupFile) string {
+	return c.DownloadURL(b.Filename)
+}
+
+// FetchBackup 下载备份文件 调用方负责关闭返回的 ReadCloser
+func (c *Client) FetchBackup(ctx context.Context, b BackupFile) (io.ReadCloser, error) {
+	if b.Filename == "" {
+		return nil, errors.New("backup filename is empty")
+	}
+	rc, _, err := c.btStream(ctx, map[string][]string{
+		"filename": {b.Filename},
+	}, "/download")
+	return rc, err
+}
+
+// progressInterval 下载进度回调的最小间隔
+const progressInterval = 500 * time.Millisecond
+
+// TransferProgress 传输进度
+type TransferProgress struct {
+	Done    int64         // 已传输的字节数
+	Total   int64         // 总字节数 面板未返回长度时为 -1
+	Elapsed time.Duration // 已用时间
+	Rate    float64       // 平均速率 字节/秒
+}
+
+// progressWriter 统计写入的字节数并按间隔回调进度
+type progressWriter struct {
+	w     io.Writer
+	fn    func(TransferProgress)
+	start time.Time
+	last  time.Time
+	done  int64
+	total int64
+}
+
+func (p *progressWriter) Write(b []byte) (int, error) {
+	n, err := p.w.Write(b)
+	p.done += int64(n)
+	if now := time.Now(); now.Sub(p.last) >= progressInterval {
+		p.last = now
+		p.report(now)
+	}
+	return n, err
+}
+
+func (p *progressWriter) report(now time.Time) {
+	if p.fn == nil {
+		return
+	}
+	ret := TransferProgress{Done: p.done, Total: p.total, Elapsed: now.Sub(p.start)}
+	if secs := ret.Elapsed.Seconds(); secs > 0 {
+		ret.Rate = float64(p.done) / secs
+	}
+	p.fn(ret)
+}
+
+// Download 下载服务器文件写入 w 返回写入的字节数 ctx 取消时中断传输并返回 ctx 的错误
+// onProgress 可选 传输中至多每 500ms 回调一次 结束时（包括失败）总会回调一次
+func (c *Client) Download(ctx context.Context, filename string, w io.Writer, onProgress func(TransferProgress)) (int64, error) {
+	if filename == "" {
+		return 0, errors.New("filename is empty")
+	}
+	rc, total, err := c.btStream(ctx, map[string][]string{
+		"filename": {filename},
+	}, "/download")
+	if err != nil {
+		return 0, err
+	}
+	defer rc.Close()
+	start := time.Now()
+	pw := &progressWriter{w: w, fn: onProgress, start: start, last: start, total: total}
+	_, err = io.Copy(pw, rc)
+	pw.report(time.Now())
+	if err != nil && ctx.Err() != nil {
+		err = ctx.Err()
+	}
+	if err == nil && total >= 0 && pw.done != total {
+		err = io.ErrUnexpectedEOF
+	}
+	return pw.done, err
+}
+
+// DownloadFile 下载服务器上 remotePath 的文件写入 w 返回写入的字节数 需要进度时使用 Download
+func (c *Client) DownloadFile(ctx context.Context, remotePath string, w io.Writer) (int64, error) {
+	return c.Download(ctx, remotePath, w, nil)
+}
+
+// DownloadBackup 下载备份文件写入 w 见 Download
+func (c *Client) DownloadBackup(ctx context.Context, b BackupFile, w io.Writer, onProgress func(TransferProgress)) (int64, error) {
+	return c.Download(ctx, b.Filename, w, onProgress)
+}
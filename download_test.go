@@ -0,0 +1,74 @@
+package bt
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestFetchBackup(t *testing.T) {
+	c := newFakePanel(t, map[string]http.HandlerFunc{
+		"/download": func(w http.ResponseWriter, r *http.Request) {
+			if r.FormValue("filename") == "/www/backup/site/missing.zip" {
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte(`{"status":false,"msg":"指定文件不存在"}`))
+				return
+			}
+			w.Header().Set("Content-Type", "application/octet-stream")
+			_, _ = w.Write([]byte("PK-archive"))
+		},
+	})
+	rc, err := c.FetchBackup(ctx, BackupFile{Filename: "/www/backup/site/w1.zip"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := io.ReadAll(rc)
+	rc.Close()
+	if string(body) != "PK-archive" {
+		t.Fatalf("body %q", body)
+	}
+	if _, err := c.FetchBackup(ctx, BackupFile{Filename: "/www/backup/site/missing.zip"}); err == nil || err.Error() != "指定文件不存在" {
+		t.Fatalf("err = %v", err)
+	}
+	u, err := url.Parse(c.BackupDownloadURL(BackupFile{Filename: "/www/backup/site/w1.zip"}))
+	if err != nil || !strings.HasSuffix(u.Path, "/download") || u.Query().Get("filename") != "/www/backup/site/w1.zip" || u.Query().Get("request_token") == "" {
+		t.Fatalf("url %v %v", u, err)
+	}
+}
+
+func TestDownload_Progress(t *testing.T) {
+	canceled, cancel := context.WithCancel(ctx)
+	defer cancel()
+	c := newFakePanel(t, map[string]http.HandlerFunc{
+		"/download": func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/octet-stream")
+			if r.FormValue("filename") == "/www/backup/site/slow.zip" {
+				w.Header().Set("Content-Length", "100")
+				_, _ = w.Write([]byte("PK"))
+				w.(http.Flusher).Flush()
+				cancel()
+				<-r.Context().Done()
+				return
+			}
+			w.Header().Set("Content-Length", "10")
+			_, _ = w.Write([]byte("PK-archive"))
+		},
+	})
+	var buf bytes.Buffer
+	var last TransferProgress
+	n, err := c.Download(ctx, "/www/backup/site/w1.zip", &buf, func(p TransferProgress) { last = p })
+	if err != nil || n != 10 || buf.String() != "PK-archive" {
+		t.Fatalf("Download = %d, %v, %q", n, err, buf.String())
+	}
+	if last.Done != 10 || last.Total != 10 {
+		t.Fatalf("progress %+v", last)
+	}
+	if _, err := c.Download(canceled, "/www/backup/site/slow.zip", io.Discard, nil); !errors.Is(err, context.Canceled) {
+		t.Fatalf("canceled Download = %v", err)
+	}
+}
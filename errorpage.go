@@ -0,0 +1,99 @@
+package bt
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// ErrorPageCodes 支持自定义错误页的 HTTP 状态码
+var ErrorPageCodes = []int{403, 404, 500, 502, 503, 504}
+
+// maxErrorPageSize 错误页大小上限
+const maxErrorPageSize = 512 << 10
+
+func checkErrorPageCode(code int) error {
+	for _, v := range ErrorPageCodes {
+		if v == code {
+			return nil
+		}
+	}
+	return errors.New("unsupported error page code: " + strconv.Itoa(code))
+}
+
+// siteRoot 网站实际的 web 根目录（网站目录 + 运行目录）
+func (c *Client) siteRoot(ctx context.Context, id int64) (name string, root string, err error) {
+	if name, err = c.siteKey(ctx, id, "name"); err != nil {
+		return "", "", err
+	}
+	path, err := c.siteKey(ctx, id, "path")
+	if err != nil {
+		return "", "", err
+	}
+	ini, err := c.GetDirUserINI(ctx, id, path)
+	if err != nil {
+		return "", "", err
+	}
+	root = strings.TrimRight(path, "/")
+	if run := strings.Trim(ini.RunPath.RunPath, "/"); run != "" {
+		root += "/" + run
+	}
+	return name, root, nil
+}
+
+// GetSiteErrorPage 获取网站自定义错误页内容 未设置时返回空字符串
+func (c *Client) GetSiteErrorPage(ctx context.Context, id int64, code int) (string, error) {
+	if err := checkErrorPageCode(code); err != nil {
+		return "", err
+	}
+	_, root, err := c.siteRoot(ctx, id)
+	if err != nil {
+		return "", err
+	}
+	file, err := c.GetFile(ctx, root+"/"+strconv.Itoa(code)+".html")
+	if err != nil {
+		return "", err
+	}
+	if !file.Status {
+		return "", nil
+	}
+	return file.Data, nil
+}
+
+// SetSiteErrorPage 设置网站自定义错误页（仅支持 nginx）
+// 将 html 写入网站运行目录下的 <code>.html 并在配置中添加对应的 error_page 指令
+func (c *Client) SetSiteErrorPage(ctx context.Context, id int64, code int, html string) error {
+	if err := checkErrorPageCode(code); err != nil {
+		return err
+	}
+	if strings.TrimSpace(html) == "" {
+		return errors.New("error page is empty")
+	}
+	if len(html) > maxErrorPageSize {
+		return errors.New("error page is larger than 512KB")
+	}
+	name, root, err := c.siteRoot(ctx, id)
+	if err != nil {
+		return err
+	}
+	page := "/" + strconv.Itoa(code) + ".html"
+	if _, err := c.WriteFile(ctx, root+page, html, true, false); err != nil {
+		return err
+	}
+	_, err = c.editManagedBlock(ctx, name, "ERRPAGE-"+strconv.Itoa(code), "error_page "+strconv.Itoa(code)+" "+page+";")
+	return err
+}
+
+// DeleteSiteErrorPage 移除网站配置中的自定义错误页指令 错误页文件保留
+func (c *Client) DeleteSiteErrorPage(ctx context.Context, id int64, code int) error {
+	if err := checkErrorPageCode(code); err != nil {
+		return err
+	}
+	name, err := c.siteKey(ctx, id, "name")
+	if err != nil {
+		return err
+	}
+	_, err = c.editManagedBlock(ctx, name, "ERRPAGE-"+strconv.Itoa(code), "")
+	return err
+}
@@ -0,0 +1,51 @@
+package bt
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestSetSiteErrorPage(t *testing.T) {
+	files := map[string]string{
+		NginxVhostPath("w1.hao.com"): "server\n{\n    listen 80;\n}\n",
+	}
+	c := newFakePanel(t, map[string]http.HandlerFunc{
+		"/data?action=getKey": func(w http.ResponseWriter, r *http.Request) {
+			if r.FormValue("key") == "name" {
+				_, _ = w.Write([]byte(`"w1.hao.com"`))
+				return
+			}
+			_, _ = w.Write([]byte(`"/www/wwwroot/w1.hao.com/"`))
+		},
+		"/site?action=GetDirUserINI": reply(`{"runPath":{"runPath":"/public"}}`),
+		"/files?action=GetFileBody": func(w http.ResponseWriter, r *http.Request) {
+			body, ok := files[r.FormValue("path")]
+			_ = json.NewEncoder(w).Encode(RespGetFile{Status: ok, Data: body})
+		},
+		"/files?action=CreateFile": func(w http.ResponseWriter, r *http.Request) {
+			files[r.FormValue("path")] = ""
+			_, _ = w.Write([]byte(`{"status":true,"msg":"文件创建成功"}`))
+		},
+		"/files?action=SaveFileBody": func(w http.ResponseWriter, r *http.Request) {
+			files[r.FormValue("path")] = r.FormValue("data")
+			_, _ = w.Write([]byte(`{"status":true,"msg":"文件已保存!"}`))
+		},
+	})
+	if err := c.SetSiteErrorPage(ctx, 11, 418, "<h1>teapot</h1>"); err == nil {
+		t.Fatal("expected unsupported code error")
+	}
+	if err := c.SetSiteErrorPage(ctx, 11, 404, "<h1>not found</h1>"); err != nil {
+		t.Fatal(err)
+	}
+	if files["/www/wwwroot/w1.hao.com/public/404.html"] != "<h1>not found</h1>" {
+		t.Fatalf("files %v", files)
+	}
+	if !strings.Contains(files[NginxVhostPath("w1.hao.com")], "error_page 404 /404.html;") {
+		t.Fatalf("vhost not updated:\n%s", files[NginxVhostPath("w1.hao.com")])
+	}
+	page, err := c.GetSiteErrorPage(ctx, 11, 404)
+	if err != nil || page != "<h1>not found</h1>" {
+		t.Fatalf("GetSiteErrorPage = %q, %v", page, err)
+	}
+}
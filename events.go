@@ -0,0 +1,192 @@
+package bt
+
+import (
+	"bytes"
+	"log"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+// FailureEvent 变更类调用失败事件
+type FailureEvent struct {
+	Time      time.Time         `json:"time"`
+	RequestID string            `json:"request_id"`
+	Panel     string            `json:"panel"` // 面板地址
+	Endpoint  string            `json:"endpoint"`
+	Action    string            `json:"action"`
+	Params    map[string]string `json:"params"` // 参数摘要 敏感字段已脱敏 长内容已截断
+	Error     string            `json:"error"`  // 网络/HTTP 错误或面板返回的 msg
+}
+
+// EventSink 事件接收方 Emit 在请求所在的 goroutine 中同步调用 耗时操作应自行异步处理
+type EventSink interface {
+	Emit(event FailureEvent)
+}
+
+// EventSinkFunc 函数形式的 EventSink
+type EventSinkFunc func(event FailureEvent)
+
+// Emit 实现 EventSink
+func (f EventSinkFunc) Emit(event FailureEvent) {
+	f(event)
+}
+
+// EventBus 将变更类调用的失败事件分发给所有订阅的 EventSink
+// 通过 c.Hooks = append(c.Hooks, bus.Hook(c)) 挂到 Client 上
+type EventBus struct {
+	mu    sync.RWMutex
+	sinks []EventSink
+}
+
+// NewEventBus 实例化事件总线
+func NewEventBus(sinks ...EventSink) *EventBus {
+	return &EventBus{sinks: sinks}
+}
+
+// Subscribe 添加事件接收方
+func (b *EventBus) Subscribe(sink EventSink) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.sinks = append(b.sinks, sink)
+}
+
+// Publish 向所有接收方发送事件
+func (b *EventBus) Publish(event FailureEvent) {
+	b.mu.RLock()
+	sinks := b.sinks
+	b.mu.RUnlock()
+	for _, s := range sinks {
+		s.Emit(event)
+	}
+}
+
+// Hook 返回挂载到 c 上的请求钩子 变更类调用出现网络错误或面板返回 status 为 false 时发布事件
+func (b *EventBus) Hook(c *Client) Hook {
+	return func(info *RequestInfo) {
+		if !info.Mutation || (info.Err == nil && !info.PanelFailed) {
+			return
+		}
+		event := FailureEvent{
+			Time:      info.Start,
+			RequestID: info.ID,
+			Panel:     c.BTAddress,
+			Endpoint:  info.Endpoint,
+			Action:    endpointAction(info.Endpoint),
+			Params:    summarizeParams(info.Params),
+			Error:     info.PanelMsg,
+		}
+		if info.Err != nil {
+			event.Error = info.Err.Error()
+		}
+		b.Publish(event)
+	}
+}
+
+// sensitiveParams 参数名包含这些关键字时只记录是否填写
+var sensitiveParams = []string{"password", "passwd", "pass", "key", "token", "secret", "csr"}
+
+// maxParamLength 参数摘要中单个值的最大字节数
+const maxParamLength = 64
+
+// sensitiveParam 参数是否为敏感参数
+func sensitiveParam(key string) bool {
+	lower := strings.ToLower(key)
+	for _, s := range sensitiveParams {
+		if strings.Contains(lower, s) {
+			return true
+		}
+	}
+	return false
+}
+
+func summarizeParams(params map[string][]string) map[string]string {
+	ret := make(map[string]string, len(params))
+	for k, v := range params {
+		value := strings.Join(v, ",")
+		if sensitiveParam(k) {
+			value = "[REDACTED]"
+		}
+		if len(value) > maxParamLength {
+			// 在字符边界截断 避免切开多字节字符
+			n := maxParamLength
+			for n > 0 && !utf8.RuneStart(value[n]) {
+				n--
+			}
+			value = value[:n] + "...(truncated)"
+		}
+		ret[k] = value
+	}
+	return ret
+}
+
+// LogSink 将事件写入日志 Logger 为空时使用 log 默认 logger
+type LogSink struct {
+	Logger *log.Logger
+}
+
+// Emit 实现 EventSink
+func (s LogSink) Emit(event FailureEvent) {
+	logger := s.Logger
+	if logger == nil {
+		logger = log.Default()
+	}
+	keys := make([]string, 0, len(event.Params))
+	for k := range event.Params {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var params []string
+	for _, k := range keys {
+		params = append(params, k+"="+event.Params[k])
+	}
+	logger.Printf("bt: [%s] %s %s failed: %s (%s)", event.RequestID, event.Panel, event.Action, event.Error, strings.Join(params, " "))
+}
+
+// ChanSink 将事件发送到 channel channel 已满时丢弃事件以免阻塞请求
+type ChanSink chan FailureEvent
+
+// Emit 实现 EventSink
+func (s ChanSink) Emit(event FailureEvent) {
+	select {
+	case s <- event:
+	default:
+	}
+}
+
+// WebhookSink 以 JSON POST 的方式将事件推送到 URL 在后台 goroutine 中发送 失败时忽略
+type WebhookSink struct {
+	URL     string
+	Client  *http.Client // 为空时使用超时 10 秒的默认 Client
+	Headers map[string]string
+}
+
+// Emit 实现 EventSink
+func (s WebhookSink) Emit(event FailureEvent) {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return
+	}
+	client := s.Client
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+	go func() {
+		req, err := http.NewRequest(http.MethodPost, s.URL, bytes.NewReader(body))
+		if err != nil {
+			return
+		}
+		req.Header.Set("Content-Type", "application/json")
+		for k, v := range s.Headers {
+			req.Header.Set(k, v)
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			return
+		}
+		resp.Body.Close()
+	}()
+}
@@ -0,0 +1,55 @@
+package bt
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestIsMutation(t *testing.T) {
+	cases := map[string]bool{
+		"/site?action=AddSite":                      true,
+		"/site?action=GetSSL":                       false,
+		"/data?action=getData&table=sites":          false,
+		"/plugin?action=a&name=btwaf&s=set_open":    true,
+		"/plugin?action=a&name=total&s=get_ip_rank": false,
+		"/download": false,
+	}
+	for endpoint, want := range cases {
+		if got := IsMutation(endpoint); got != want {
+			t.Errorf("IsMutation(%q) = %v", endpoint, got)
+		}
+	}
+}
+
+func TestEventBus(t *testing.T) {
+	c := newFakePanel(t, map[string]http.HandlerFunc{
+		"/site?action=SetHasPwd":        reply(`{"status":false,"msg":"密码格式错误"}`),
+		"/site?action=CloseHasPwd":      reply(`{"status":true,"msg":"操作成功"}`),
+		"/system?action=GetSystemTotal": reply(`{"status":false}`),
+	})
+	events := make(ChanSink, 10)
+	bus := NewEventBus(events)
+	c.Hooks = append(c.Hooks, bus.Hook(c))
+	_, _ = c.SetHasPwd(ctx, 11, "admin", "secret")
+	_, _ = c.CloseHasPwd(ctx, 11)
+	_, _ = c.GetSystemTotal(ctx)
+	if len(events) != 1 {
+		t.Fatalf("got %d events", len(events))
+	}
+	e := <-events
+	if e.Action != "SetHasPwd" || e.Error != "密码格式错误" || e.Params["password"] != "[REDACTED]" || e.Params["username"] != "admin" || e.Panel != c.BTAddress {
+		t.Fatalf("event %+v", e)
+	}
+}
+
+func TestSummarizeParamsTruncate(t *testing.T) {
+	// 每个汉字占 3 字节 第 64 字节落在字符中间
+	value := strings.Repeat("网站", 20)
+	got := summarizeParams(map[string][]string{"ps": {value}})["ps"]
+	want := strings.Repeat("网站", 10) + "网...(truncated)"
+	if got != want || !utf8.ValidString(got) {
+		t.Fatalf("summary %q", got)
+	}
+}
@@ -0,0 +1,436 @@
+package bt
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CopyFile 复制文件或目录 sfile 源路径 dfile 目标路径
+func (c *Client) CopyFile(ctx context.Context, sfile string, dfile string) (RespMSG, error) {
+	data := map[string][]string{
+		"sfile": {sfile},
+		"dfile": {dfile},
+	}
+	return c.btResult(ctx, data, "/files?action=CopyFile")
+}
+
+// EditOptions SafeEditConfig 的可选项
+type EditOptions struct {
+	// Review 写入前以 unified diff 形式回调变更内容 返回错误则放弃写入
+	Review func(diff string) error
+	// Backup 为 true 时写入前将原文件复制为 path.bak
+	Backup bool
+	// DryRun 为 true 时只计算并回调 diff 不写入
+	DryRun bool
+}
+
+// SafeEditConfig 读取文件 经 mutate 修改后写回 内容未变化时不写入
+// 返回本次变更的 unified diff 便于记录和回滚
+func (c *Client) SafeEditConfig(ctx context.Context, path string, mutate func(string) (string, error), opts *EditOptions) (string, error) {
+	if opts == nil {
+		opts = &EditOptions{}
+	}
+	current, err := c.readFile(ctx, path)
+	if err != nil {
+		return "", err
+	}
+	body, err := mutate(current)
+	if err != nil {
+		return "", err
+	}
+	diff := UnifiedDiff(current, body, path, path)
+	if diff == "" {
+		return "", nil
+	}
+	if opts.Review != nil {
+		if err := opts.Review(diff); err != nil {
+			return diff, err
+		}
+	}
+	if opts.DryRun {
+		return diff, nil
+	}
+	if opts.Backup {
+		if _, err := c.CopyFile(ctx, path, path+".bak"); err != nil {
+			return diff, fmt.Errorf("backup failed: %w", err)
+		}
+	}
+	_, err = c.SetFile(ctx, path, body)
+	return diff, err
+}
+
+// readFile 读取文件内容 文件不存在或无权读取时返回带面板消息的 *APIError
+func (c *Client) readFile(ctx context.Context, path string) (string, error) {
+	data := map[string][]string{
+		"path": {path},
+	}
+	resp, err := c.btAPI(ctx, data, "/files?action=GetFileBody")
+	if err != nil {
+		return "", err
+	}
+	var dec RespGetFile
+	if err := c.unmarshal(ctx, resp, &dec); err != nil {
+		return "", err
+	}
+	if !dec.Status {
+		msg, _ := c.decodeMSG(resp)
+		return "", newPanelError("/files?action=GetFileBody", msg.Msg, resp)
+	}
+	return dec.Data, nil
+}
+
+// CreateFile 新建空文件 文件已存在时面板返回失败
+func (c *Client) CreateFile(ctx context.Context, path string) (RespMSG, error) {
+	data := map[string][]string{
+		"path": {path},
+	}
+	return c.btResult(ctx, data, "/files?action=CreateFile")
+}
+
+// MoveFile 移动或重命名文件 目标文件已存在时会被覆盖
+func (c *Client) MoveFile(ctx context.Context, sfile string, dfile string) (RespMSG, error) {
+	data := map[string][]string{
+		"sfile": {sfile},
+		"dfile": {dfile},
+	}
+	return c.btResult(ctx, data, "/files?action=MvFile")
+}
+
+// WriteFile 写入文件 createIfMissing 为 true 时文件不存在则先创建
+// backup 为 true 时写入前将已存在的原文件复制为 path.bak
+func (c *Client) WriteFile(ctx context.Context, path string, content string, createIfMissing bool, backup bool) (RespMSG, error) {
+	file, err := c.GetFile(ctx, path)
+	if err != nil {
+		return RespMSG{}, err
+	}
+	if !file.Status {
+		if !createIfMissing {
+			return RespMSG{}, errors.New("file not found: " + path)
+		}
+		if ret, err := c.CreateFile(ctx, path); err != nil {
+			return ret, err
+		}
+	} else if backup {
+		if ret, err := c.CopyFile(ctx, path, path+".bak"); err != nil {
+			return ret, fmt.Errorf("backup failed: %w", err)
+		}
+	}
+	return c.SetFile(ctx, path, content)
+}
+
+// WriteFileAtomic 先写入同目录下的临时文件再重命名覆盖 path 写入中途失败不会留下内容不完整的文件
+// 注意面板只在直接保存网站配置时检测并重载 nginx/apache 以此方式写入配置文件需自行重载
+func (c *Client) WriteFileAtomic(ctx context.Context, path string, content string, backup bool) (ret RespMSG, err error) {
+	// 临时文件名带随机后缀 避免同一文件的并发写入互相覆盖
+	tmp := path + ".btsdk-tmp-" + randomHex(4)
+	defer func() {
+		// 失败时删除临时文件 即使 ctx 已取消
+		if err != nil {
+			_, _ = c.DeleteFile(context.WithoutCancel(ctx), tmp)
+		}
+	}()
+	if ret, err := c.WriteFile(ctx, tmp, content, true, false); err != nil {
+		return ret, err
+	}
+	if backup {
+		file, err := c.GetFile(ctx, path)
+		if err != nil {
+			return RespMSG{}, err
+		}
+		if file.Status {
+			if ret, err := c.CopyFile(ctx, path, path+".bak"); err != nil {
+				return ret, fmt.Errorf("backup failed: %w", err)
+			}
+		}
+	}
+	return c.MoveFile(ctx, tmp, path)
+}
+
+// GetFileFavorites 获取文件管理器的收藏路径
+func (c *Client) GetFileFavorites(ctx context.Context) ([]FileFavorite, error) {
+	resp, err := c.btAPI(ctx, map[string][]string{}, "/files?action=get_files_store")
+	if err != nil {
+		return nil, err
+	}
+	var dec []FileFavorite
+	if err := c.unmarshal(ctx, resp, &dec); err != nil {
+		return nil, err
+	}
+	return dec, nil
+}
+
+// AddFileFavorite 将文件或目录加入文件管理器收藏
+func (c *Client) AddFileFavorite(ctx context.Context, path string) (RespMSG, error) {
+	data := map[string][]string{
+		"path": {path},
+	}
+	return c.btResult(ctx, data, "/files?action=add_files_store")
+}
+
+// DeleteFileFavorite 取消收藏
+func (c *Client) DeleteFileFavorite(ctx context.Context, path string) (RespMSG, error) {
+	data := map[string][]string{
+		"path": {path},
+	}
+	return c.btResult(ctx, data, "/files?action=del_files_store")
+}
+
+// SyncFileFavorites 使收藏路径与 paths 一致 添加缺少的并删除多余的 便于统一各服务器的快捷入口
+func (c *Client) SyncFileFavorites(ctx context.Context, paths []string) error {
+	current, err := c.GetFileFavorites(ctx)
+	if err != nil {
+		return err
+	}
+	want := map[string]bool{}
+	for _, p := range paths {
+		want[p] = true
+	}
+	have := map[string]bool{}
+	for _, f := range current {
+		have[f.Path] = true
+		if want[f.Path] {
+			continue
+		}
+		if _, err := c.DeleteFileFavorite(ctx, f.Path); err != nil {
+			return err
+		}
+	}
+	for _, p := range paths {
+		if have[p] {
+			continue
+		}
+		have[p] = true
+		if _, err := c.AddFileFavorite(ctx, p); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ListFileVersions 获取面板在保存文件时留下的历史版本 按时间从新到旧排列
+// 版本号为保存时的时间戳 可传给 RestoreFileVersion
+func (c *Client) ListFileVersions(ctx context.Context, path string) ([]int64, error) {
+	f, err := c.GetFile(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+	versions := append([]int64(nil), f.Historys...)
+	sort.Slice(versions, func(i, j int) bool { return versions[i] > versions[j] })
+	return versions, nil
+}
+
+// RestoreFileVersion 将文件恢复到指定历史版本
+func (c *Client) RestoreFileVersion(ctx context.Context, path string, version int64) (RespMSG, error) {
+	data := map[string][]string{
+		"filename": {path},
+		"history":  {strconv.FormatInt(version, 10)},
+	}
+	return c.btResult(ctx, data, "/files?action=re_history")
+}
+
+// GetDir 获取目录列表 目录不存在时 Status 为 false 且 Msg 为面板的提示
+// 只返回前 100 项 需要分页或搜索时使用 ListDir
+func (c *Client) GetDir(ctx context.Context, dir string) (RespDir, error) {
+	return c.ListDir(ctx, dir, 1, "")
+}
+
+// ListDir 分页获取目录列表 每页 100 项 p 为页码 search 为文件名关键字 为空时不搜索
+// 可通过 RespDir.Entries 获取解析后的列表
+func (c *Client) ListDir(ctx context.Context, dir string, p int64, search string) (RespDir, error) {
+	if p < 1 {
+		p = 1
+	}
+	data := map[string][]string{
+		"path":    {dir},
+		"p":       {strconv.FormatInt(p, 10)},
+		"showRow": {"100"},
+	}
+	if search != "" {
+		data["search"] = []string{search}
+	}
+	resp, err := c.btAPI(ctx, data, "/files?action=GetDir")
+	if err != nil {
+		return RespDir{}, err
+	}
+	dec := RespDir{Status: true}
+	if err := c.unmarshal(ctx, resp, &dec); err != nil {
+		return RespDir{}, err
+	}
+	return dec, nil
+}
+
+// DirEntry 目录列表中的一项
+type DirEntry struct {
+	Name    string
+	IsDir   bool
+	Size    int64
+	ModTime time.Time
+	Mode    os.FileMode // 权限位 eg. 0755
+	Owner   string
+	Link    string // 软链接目标 不是软链接时为空
+}
+
+// parseDirEntry 解析 名称;大小;修改时间;权限;所有者;软链接目标 格式的列表项
+func parseDirEntry(s string, isDir bool) DirEntry {
+	f := strings.Split(s, ";")
+	for len(f) < 6 {
+		f = append(f, "")
+	}
+	e := DirEntry{Name: f[0], IsDir: isDir, Owner: f[4], Link: f[5]}
+	e.Size, _ = strconv.ParseInt(f[1], 10, 64)
+	if t, err := strconv.ParseInt(f[2], 10, 64); err == nil {
+		e.ModTime = time.Unix(t, 0)
+	}
+	if m, err := strconv.ParseUint(f[3], 8, 32); err == nil {
+		e.Mode = os.FileMode(m)
+	}
+	return e
+}
+
+// Entries 解析目录列表 目录在前
+func (d RespDir) Entries() []DirEntry {
+	ret := make([]DirEntry, 0, len(d.Dirs)+len(d.Files))
+	for _, s := range d.Dirs {
+		ret = append(ret, parseDirEntry(s, true))
+	}
+	for _, s := range d.Files {
+		ret = append(ret, parseDirEntry(s, false))
+	}
+	return ret
+}
+
+// CreateDir 新建目录 上级目录需已存在
+func (c *Client) CreateDir(ctx context.Context, dir string) (RespMSG, error) {
+	data := map[string][]string{
+		"path": {dir},
+	}
+	return c.btResult(ctx, data, "/files?action=CreateDir")
+}
+
+// DeleteDir 删除目录及其中的所有文件 面板开启回收站时移入回收站
+func (c *Client) DeleteDir(ctx context.Context, dir string) (RespMSG, error) {
+	data := map[string][]string{
+		"path": {dir},
+	}
+	return c.btResult(ctx, data, "/files?action=DeleteDir")
+}
+
+// DeleteFile 删除文件 面板开启回收站时移入回收站
+func (c *Client) DeleteFile(ctx context.Context, path string) (RespMSG, error) {
+	data := map[string][]string{
+		"path": {path},
+	}
+	return c.btResult(ctx, data, "/files?action=DeleteFile")
+}
+
+// BatchOp 批量操作类型
+type BatchOp int
+
+const (
+	BatchCopy   BatchOp = 1 // 复制到 Dest
+	BatchMove   BatchOp = 2 // 剪切到 Dest
+	BatchAccess BatchOp = 3 // 设置权限和所有者
+	BatchDelete BatchOp = 4 // 删除
+)
+
+// BatchOperate 对同一目录下的多个文件或目录执行批量操作
+// 复制和剪切分两步完成 先提交待操作列表 再粘贴到目标目录
+func (c *Client) BatchOperate(ctx context.Context, params *ReqBatch) (RespMSG, error) {
+	if params.Dir == "" || len(params.Names) == 0 {
+		return RespMSG{}, errors.New("batch dir and names are required")
+	}
+	names, err := json.Marshal(params.Names)
+	if err != nil {
+		return RespMSG{}, err
+	}
+	data := map[string][]string{
+		"path": {params.Dir},
+		"type": {strconv.Itoa(int(params.Op))},
+		"data": {string(names)},
+	}
+	switch params.Op {
+	case BatchCopy, BatchMove:
+		if params.Dest == "" {
+			return RespMSG{}, errors.New("batch destination is required")
+		}
+	case BatchAccess:
+		mode, owner := params.Mode, params.Owner
+		if mode == 0 {
+			mode = 0755
+		}
+		if owner == "" {
+			owner = "www"
+		}
+		data["access"] = []string{strconv.FormatUint(uint64(mode.Perm()), 8)}
+		data["user"] = []string{owner}
+	case BatchDelete:
+	default:
+		return RespMSG{}, errors.New("unknown batch operation " + strconv.Itoa(int(params.Op)))
+	}
+	ret, err := c.btResult(ctx, data, "/files?action=SetBatchData")
+	if err != nil || (params.Op != BatchCopy && params.Op != BatchMove) {
+		return ret, err
+	}
+	return c.btResult(ctx, map[string][]string{
+		"path": {params.Dest},
+		"type": {strconv.Itoa(int(params.Op))},
+	}, "/files?action=BatchPaste")
+}
+
+// SetFileAccess 设置文件或目录的权限和所有者 mode eg. 0755 owner eg. www
+func (c *Client) SetFileAccess(ctx context.Context, filename string, mode os.FileMode, owner string) (RespMSG, error) {
+	data := map[string][]string{
+		"filename": {filename},
+		"access":   {strconv.FormatUint(uint64(mode.Perm()), 8)},
+		"user":     {owner},
+		"all":      {"False"},
+	}
+	return c.btResult(ctx, data, "/files?action=SetFileAccess")
+}
+
+// EnsureDir 确保目录存在 逐级创建缺失的上级目录并设置权限和所有者 返回新建的目录
+// mode 为 0 或 owner 为空时使用面板的默认值 已存在的目录不会被修改
+func (c *Client) EnsureDir(ctx context.Context, dir string, mode os.FileMode, owner string) ([]string, error) {
+	if !strings.HasPrefix(dir, "/") {
+		return nil, errors.New("path must be absolute: " + dir)
+	}
+	var missing []string
+	for p := path.Clean(dir); p != "/"; p = path.Dir(p) {
+		ret, err := c.GetDir(ctx, p)
+		if err != nil {
+			return nil, err
+		}
+		if ret.Status {
+			break
+		}
+		missing = append(missing, p)
+	}
+	var created []string
+	for i := len(missing) - 1; i >= 0; i-- {
+		p := missing[i]
+		if _, err := c.CreateDir(ctx, p); err != nil {
+			return created, err
+		}
+		created = append(created, p)
+		if mode == 0 && owner == "" {
+			continue
+		}
+		if mode == 0 {
+			mode = 0755
+		}
+		if owner == "" {
+			owner = "www"
+		}
+		if _, err := c.SetFileAccess(ctx, p, mode, owner); err != nil {
+			return created, err
+		}
+	}
+	return created, nil
+}
@@ -0,0 +1,265 @@
+package bt
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestSafeEditConfig(t *testing.T) {
+	var saved, copied string
+	c := newFakePanel(t, map[string]http.HandlerFunc{
+		"/files?action=GetFileBody": func(w http.ResponseWriter, r *http.Request) {
+			if r.FormValue("path") != "/www/a.conf" {
+				_, _ = w.Write([]byte(`{"status":false,"msg":"指定文件不存在!"}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"status":true,"data":"listen 80;\n"}`))
+		},
+		"/files?action=CopyFile": func(w http.ResponseWriter, r *http.Request) {
+			copied = r.FormValue("dfile")
+			_, _ = w.Write([]byte(`{"status":true,"msg":"复制成功"}`))
+		},
+		"/files?action=SaveFileBody": func(w http.ResponseWriter, r *http.Request) {
+			saved = r.FormValue("data")
+			_, _ = w.Write([]byte(`{"status":true,"msg":"文件已保存!"}`))
+		},
+	})
+	var reviewed string
+	diff, err := c.SafeEditConfig(ctx, "/www/a.conf", func(s string) (string, error) {
+		return strings.Replace(s, "80", "8080", 1), nil
+	}, &EditOptions{Backup: true, Review: func(d string) error { reviewed = d; return nil }})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if saved != "listen 8080;\n" || copied != "/www/a.conf.bak" || diff != reviewed || !strings.Contains(diff, "+listen 8080;") {
+		t.Fatalf("saved %q copied %q diff %q", saved, copied, diff)
+	}
+	var apiErr *APIError
+	_, err = c.SafeEditConfig(ctx, "/www/missing.conf", func(s string) (string, error) { return s, nil }, nil)
+	if !errors.As(err, &apiErr) || apiErr.Msg != "指定文件不存在!" || apiErr.Endpoint != "/files?action=GetFileBody" {
+		t.Fatalf("SafeEditConfig error %v", err)
+	}
+}
+
+func TestWriteFileAtomic(t *testing.T) {
+	files := map[string]string{"/www/a.conf": "old"}
+	var calls []string
+	failOn := ""
+	fail := func(action string, w http.ResponseWriter) bool {
+		if action != failOn {
+			return false
+		}
+		_, _ = w.Write([]byte(`{"status":false,"msg":"权限不足"}`))
+		return true
+	}
+	c := newFakePanel(t, map[string]http.HandlerFunc{
+		"/files?action=GetFileBody": func(w http.ResponseWriter, r *http.Request) {
+			body, ok := files[r.FormValue("path")]
+			b, _ := json.Marshal(RespGetFile{Status: ok, Data: body})
+			_, _ = w.Write(b)
+		},
+		"/files?action=CreateFile": func(w http.ResponseWriter, r *http.Request) {
+			calls = append(calls, "create "+r.FormValue("path"))
+			files[r.FormValue("path")] = ""
+			_, _ = w.Write([]byte(`{"status":true,"msg":"文件创建成功"}`))
+		},
+		"/files?action=SaveFileBody": func(w http.ResponseWriter, r *http.Request) {
+			if fail("SaveFileBody", w) {
+				return
+			}
+			calls = append(calls, "save "+r.FormValue("path"))
+			files[r.FormValue("path")] = r.FormValue("data")
+			_, _ = w.Write([]byte(`{"status":true,"msg":"文件已保存!"}`))
+		},
+		"/files?action=CopyFile": func(w http.ResponseWriter, r *http.Request) {
+			if fail("CopyFile", w) {
+				return
+			}
+			calls = append(calls, "copy "+r.FormValue("dfile"))
+			files[r.FormValue("dfile")] = files[r.FormValue("sfile")]
+			_, _ = w.Write([]byte(`{"status":true,"msg":"复制成功"}`))
+		},
+		"/files?action=MvFile": func(w http.ResponseWriter, r *http.Request) {
+			if fail("MvFile", w) {
+				return
+			}
+			calls = append(calls, "move "+r.FormValue("dfile"))
+			files[r.FormValue("dfile")] = files[r.FormValue("sfile")]
+			delete(files, r.FormValue("sfile"))
+			_, _ = w.Write([]byte(`{"status":true,"msg":"移动成功"}`))
+		},
+		"/files?action=DeleteFile": func(w http.ResponseWriter, r *http.Request) {
+			calls = append(calls, "delete "+r.FormValue("path"))
+			delete(files, r.FormValue("path"))
+			_, _ = w.Write([]byte(`{"status":true,"msg":"删除成功"}`))
+		},
+	})
+	if _, err := c.WriteFile(ctx, "/www/missing.conf", "x", false, false); err == nil {
+		t.Fatal("expected error")
+	}
+	r, err := c.WriteFileAtomic(ctx, "/www/a.conf", "new", true)
+	if err != nil || !r.Status {
+		t.Fatalf("WriteFileAtomic = %+v, %v", r, err)
+	}
+	tmp := strings.TrimPrefix(calls[0], "create ")
+	if !strings.HasPrefix(tmp, "/www/a.conf.btsdk-tmp-") {
+		t.Fatalf("temp file %q", tmp)
+	}
+	want := "create " + tmp + ",save " + tmp + ",copy /www/a.conf.bak,move /www/a.conf"
+	if strings.Join(calls, ",") != want || files["/www/a.conf"] != "new" || files["/www/a.conf.bak"] != "old" {
+		t.Fatalf("calls %v files %v", calls, files)
+	}
+	// 每次写入使用不同的临时文件
+	calls = nil
+	if _, err := c.WriteFileAtomic(ctx, "/www/a.conf", "new", false); err != nil || calls[0] == "create "+tmp {
+		t.Fatalf("temp file reused: %v, %v", calls, err)
+	}
+
+	// 任一步骤失败都要删除临时文件 原文件保持不变
+	for _, action := range []string{"SaveFileBody", "CopyFile", "MvFile"} {
+		failOn, calls = action, nil
+		if _, err := c.WriteFileAtomic(ctx, "/www/a.conf", "newer", true); err == nil {
+			t.Fatalf("%s: expected error", action)
+		}
+		tmp := strings.TrimPrefix(calls[0], "create ")
+		if _, ok := files[tmp]; ok || files["/www/a.conf"] != "new" || calls[len(calls)-1] != "delete "+tmp {
+			t.Fatalf("%s: calls %v files %v", action, calls, files)
+		}
+	}
+}
+
+func TestSyncFileFavorites(t *testing.T) {
+	var calls []string
+	c := newFakePanel(t, map[string]http.HandlerFunc{
+		"/files?action=get_files_store": reply(`[{"name":"wwwroot","path":"/www/wwwroot"},{"name":"tmp","path":"/tmp"}]`),
+		"/files?action=add_files_store": func(w http.ResponseWriter, r *http.Request) {
+			calls = append(calls, "add "+r.FormValue("path"))
+			_, _ = w.Write([]byte(`{"status":true,"msg":"添加成功"}`))
+		},
+		"/files?action=del_files_store": func(w http.ResponseWriter, r *http.Request) {
+			calls = append(calls, "del "+r.FormValue("path"))
+			_, _ = w.Write([]byte(`{"status":true,"msg":"删除成功"}`))
+		},
+	})
+	if err := c.SyncFileFavorites(ctx, []string{"/www/wwwroot", "/www/wwwlogs", "/www/wwwlogs"}); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(calls, ",") != "del /tmp,add /www/wwwlogs" {
+		t.Fatalf("calls %v", calls)
+	}
+}
+
+func TestFileVersions(t *testing.T) {
+	c := newFakePanel(t, map[string]http.HandlerFunc{
+		"/files?action=GetFileBody": reply(`{"status":true,"data":"x","encoding":"utf-8","historys":[1700000000,1700000300,1700000100]}`),
+		"/files?action=re_history": func(w http.ResponseWriter, r *http.Request) {
+			if r.FormValue("filename") != "/www/a.conf" || r.FormValue("history") != "1700000300" {
+				t.Errorf("unexpected form %v", r.Form)
+			}
+			_, _ = w.Write([]byte(`{"status":true,"msg":"恢复成功"}`))
+		},
+	})
+	versions, err := c.ListFileVersions(ctx, "/www/a.conf")
+	if err != nil || len(versions) != 3 || versions[0] != 1700000300 || versions[2] != 1700000000 {
+		t.Fatalf("ListFileVersions = %v, %v", versions, err)
+	}
+	r, err := c.RestoreFileVersion(ctx, "/www/a.conf", versions[0])
+	if err != nil || !r.Status {
+		t.Fatalf("RestoreFileVersion = %+v, %v", r, err)
+	}
+}
+
+func TestEnsureDir(t *testing.T) {
+	var calls []string
+	c := newFakePanel(t, map[string]http.HandlerFunc{
+		"/files?action=GetDir": func(w http.ResponseWriter, r *http.Request) {
+			if r.FormValue("path") == "/www/wwwroot" {
+				_, _ = w.Write([]byte(`{"PATH":"/www/wwwroot","DIR":[],"FILES":[]}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"status":false,"msg":"指定目录不存在!"}`))
+		},
+		"/files?action=CreateDir": func(w http.ResponseWriter, r *http.Request) {
+			calls = append(calls, "mkdir "+r.FormValue("path"))
+			_, _ = w.Write([]byte(`{"status":true,"msg":"目录创建成功!"}`))
+		},
+		"/files?action=SetFileAccess": func(w http.ResponseWriter, r *http.Request) {
+			calls = append(calls, "chmod "+r.FormValue("access")+" "+r.FormValue("user")+" "+r.FormValue("filename"))
+			_, _ = w.Write([]byte(`{"status":true,"msg":"设置成功!"}`))
+		},
+	})
+	created, err := c.EnsureDir(ctx, "/www/wwwroot/app/releases/", 0750, "www")
+	if err != nil || len(created) != 2 {
+		t.Fatalf("EnsureDir = %v, %v", created, err)
+	}
+	want := []string{
+		"mkdir /www/wwwroot/app",
+		"chmod 750 www /www/wwwroot/app",
+		"mkdir /www/wwwroot/app/releases",
+		"chmod 750 www /www/wwwroot/app/releases",
+	}
+	if strings.Join(calls, "\n") != strings.Join(want, "\n") {
+		t.Errorf("calls = %q", calls)
+	}
+	if _, err := c.EnsureDir(ctx, "relative", 0, ""); err == nil {
+		t.Error("relative path should be rejected")
+	}
+}
+
+func TestListDir(t *testing.T) {
+	c := newFakePanel(t, map[string]http.HandlerFunc{
+		"/files?action=GetDir": func(w http.ResponseWriter, r *http.Request) {
+			if r.FormValue("p") != "2" || r.FormValue("search") != "log" {
+				t.Errorf("unexpected form %v", r.Form)
+			}
+			_, _ = w.Write([]byte(`{"PATH":"/www/wwwlogs","DIR":["old;4096;1714528800;755;root;"],"FILES":["a.com.log;1024;1714528860;644;www;","latest.log;0;1714528900;777;root;/www/wwwlogs/a.com.log"]}`))
+		},
+	})
+	dir, err := c.ListDir(ctx, "/www/wwwlogs", 2, "log")
+	if err != nil {
+		t.Fatal(err)
+	}
+	entries := dir.Entries()
+	if len(entries) != 3 || !entries[0].IsDir || entries[0].Mode != 0755 {
+		t.Fatalf("entries %+v", entries)
+	}
+	if e := entries[1]; e.Name != "a.com.log" || e.Size != 1024 || e.Owner != "www" || e.ModTime.Unix() != 1714528860 || e.IsDir {
+		t.Errorf("file %+v", e)
+	}
+	if entries[2].Link != "/www/wwwlogs/a.com.log" {
+		t.Errorf("link %+v", entries[2])
+	}
+}
+
+func TestBatchOperate(t *testing.T) {
+	var calls []string
+	c := newFakePanel(t, map[string]http.HandlerFunc{
+		"/files?action=SetBatchData": func(w http.ResponseWriter, r *http.Request) {
+			calls = append(calls, "set "+r.FormValue("type")+" "+r.FormValue("path")+" "+r.FormValue("data")+" "+r.FormValue("access"))
+			_, _ = w.Write([]byte(`{"status":true,"msg":"标记成功"}`))
+		},
+		"/files?action=BatchPaste": func(w http.ResponseWriter, r *http.Request) {
+			calls = append(calls, "paste "+r.FormValue("type")+" "+r.FormValue("path"))
+			_, _ = w.Write([]byte(`{"status":true,"msg":"批量操作成功"}`))
+		},
+	})
+	if _, err := c.BatchOperate(ctx, &ReqBatch{Op: BatchMove, Dir: "/www/a", Names: []string{"x", "y"}, Dest: "/www/b"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.BatchOperate(ctx, &ReqBatch{Op: BatchAccess, Dir: "/www/a", Names: []string{"x"}, Mode: 0700}); err != nil {
+		t.Fatal(err)
+	}
+	want := []string{
+		`set 2 /www/a ["x","y"] `,
+		"paste 2 /www/b",
+		`set 3 /www/a ["x"] 700`,
+	}
+	if strings.Join(calls, "\n") != strings.Join(want, "\n") {
+		t.Errorf("calls = %q", calls)
+	}
+	if _, err := c.BatchOperate(ctx, &ReqBatch{Op: BatchCopy, Dir: "/www/a", Names: []string{"x"}}); err == nil {
+		t.Error("copy without destination should be rejected")
+	}
+}
@@ -0,0 +1,275 @@
+package bt
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strconv"
+	"strings"
+)
+
+// FirewallBackend 系统防火墙类型
+type FirewallBackend string
+
+// 面板支持的系统防火墙
+const (
+	FirewallFirewalld FirewallBackend = "firewalld" // CentOS/RHEL 系
+	FirewallUFW       FirewallBackend = "ufw"       // Ubuntu/Debian
+	FirewallIptables  FirewallBackend = "iptables"  // 其他发行版
+)
+
+// firewalldSystems 默认使用 firewalld 的发行版关键字
+var firewalldSystems = []string{"centos", "red hat", "rhel", "rocky", "alma", "fedora", "alibaba", "anolis", "openeuler", "opencloudos", "tencentos", "euleros"}
+
+// GetFirewallBackend 根据服务器操作系统判断面板所使用的系统防火墙
+func (c *Client) GetFirewallBackend(ctx context.Context) (FirewallBackend, error) {
+	total, err := c.GetSystemTotal(ctx)
+	if err != nil {
+		return "", err
+	}
+	return firewallBackendOf(total.System), nil
+}
+
+func firewallBackendOf(system string) FirewallBackend {
+	s := strings.ToLower(system)
+	if strings.Contains(s, "ubuntu") || strings.Contains(s, "debian") {
+		return FirewallUFW
+	}
+	for _, k := range firewalldSystems {
+		if strings.Contains(s, k) {
+			return FirewallFirewalld
+		}
+	}
+	return FirewallIptables
+}
+
+// PortRange 按防火墙要求的格式表示端口范围 firewalld 使用 "8000-9000" ufw/iptables 使用 "8000:9000"
+func (b FirewallBackend) PortRange(from int64, to int64) string {
+	if from == to {
+		return strconv.FormatInt(from, 10)
+	}
+	sep := ":"
+	if b == FirewallFirewalld {
+		sep = "-"
+	}
+	return strconv.FormatInt(from, 10) + sep + strconv.FormatInt(to, 10)
+}
+
+// firewallPort 将端口规则转换为当前系统防火墙要求的格式 端口范围可写作 8000-9000 或 8000:9000
+// 只有端口范围需要查询防火墙类型
+func (c *Client) firewallPort(ctx context.Context, rule string) (string, error) {
+	from, to, ok := parsePortRule(rule)
+	if !ok {
+		return "", errors.New("invalid port: " + rule)
+	}
+	if from == to {
+		return strconv.FormatInt(from, 10), nil
+	}
+	backend, err := c.GetFirewallBackend(ctx)
+	if err != nil {
+		return "", err
+	}
+	return backend.PortRange(from, to), nil
+}
+
+// 系统防火墙（专业版）插件名
+const firewallPlugin = "firewall"
+
+// RegionBlock 地区封禁规则参数
+type RegionBlock struct {
+	Country string // 国家或地区名称 以面板的地区列表为准 eg. 美国
+	Ports   string // 封禁的端口 多个以逗号分隔 空为全部端口
+	Brief   string // 备注
+}
+
+// AddRegionBlock 添加地区封禁规则 禁止该地区的 IP 访问（需系统防火墙专业版）
+func (c *Client) AddRegionBlock(ctx context.Context, block RegionBlock) (RespMSG, error) {
+	choose := "port"
+	if block.Ports == "" {
+		choose = "all"
+	}
+	return c.pluginResult(ctx, firewallPlugin, "create_countrys", map[string]string{
+		"types":   "drop",
+		"country": block.Country,
+		"ports":   block.Ports,
+		"choose":  choose,
+		"brief":   block.Brief,
+	})
+}
+
+// RemoveRegionBlock 删除地区封禁规则 id 为 GetRegionBlocks 返回的规则 ID
+func (c *Client) RemoveRegionBlock(ctx context.Context, id int64) (RespMSG, error) {
+	return c.pluginResult(ctx, firewallPlugin, "remove_countrys", map[string]string{
+		"id": strconv.FormatInt(id, 10),
+	})
+}
+
+// GetRegionBlocks 获取地区封禁规则列表
+func (c *Client) GetRegionBlocks(ctx context.Context) ([]RegionRule, error) {
+	resp, err := c.PluginCall(ctx, firewallPlugin, "get_countrys_list", map[string]string{
+		"p":     "1",
+		"limit": "1000",
+	})
+	if err != nil {
+		return nil, err
+	}
+	var dec []RegionRule
+	if err := c.unmarshal(ctx, resp, &dec); err != nil {
+		return nil, err
+	}
+	return dec, nil
+}
+
+// FirewallRuleType 面板防火墙规则类型
+type FirewallRuleType string
+
+// 面板防火墙规则类型
+const (
+	FirewallAcceptPort  FirewallRuleType = "port"    // 放行端口
+	FirewallDropAddress FirewallRuleType = "address" // 屏蔽 IP
+)
+
+// Type 规则类型 Port 为 IP 或 IP 段时为屏蔽 IP 否则为放行端口
+func (r FirewallRule) Type() FirewallRuleType {
+	if isAddress(r.Port) {
+		return FirewallDropAddress
+	}
+	return FirewallAcceptPort
+}
+
+// isAddress 判断是否为 IP 或 CIDR 形式的 IP 段
+func isAddress(s string) bool {
+	s = strings.TrimSpace(s)
+	if net.ParseIP(s) != nil {
+		return true
+	}
+	_, _, err := net.ParseCIDR(s)
+	return err == nil
+}
+
+// parsePortRule 解析端口或端口范围（8000-9000 或 8000:9000） 单个端口时 from 与 to 相同
+func parsePortRule(rule string) (from int64, to int64, ok bool) {
+	f, t, isRange := strings.Cut(rule, "-")
+	if !isRange {
+		f, t, isRange = strings.Cut(rule, ":")
+	}
+	if !isRange {
+		t = f
+	}
+	from, err1 := strconv.ParseInt(f, 10, 64)
+	to, err2 := strconv.ParseInt(t, 10, 64)
+	if err1 != nil || err2 != nil || from <= 0 || from > to || to > 65535 {
+		return 0, 0, false
+	}
+	return from, to, true
+}
+
+// GetFirewallRules 获取面板防火墙的全部规则 含放行的端口和屏蔽的 IP
+func (c *Client) GetFirewallRules(ctx context.Context) ([]FirewallRule, error) {
+	var ret []FirewallRule
+	err := fetchPages(func(p int64, limit int64) (int, error) {
+		var dec FirewallList
+		if err := c.QueryTable("firewall").Page(p).Limit(limit).Into(ctx, &dec); err != nil {
+			return 0, err
+		}
+		ret = append(ret, dec.Data...)
+		return len(dec.Data), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+// AddFirewallRule 添加面板防火墙规则 放行端口或屏蔽 IP
+func (c *Client) AddFirewallRule(ctx context.Context, params *ReqFirewallRule) (RespMSG, error) {
+	switch params.Type {
+	case FirewallAcceptPort:
+		return c.AddPortRule(ctx, params.Port, params.PS)
+	case FirewallDropAddress:
+		if !isAddress(params.Port) {
+			return RespMSG{}, errors.New("invalid address: " + params.Port)
+		}
+		data := map[string][]string{
+			"port": {params.Port},
+			"ps":   {params.PS},
+			"type": {string(FirewallDropAddress)},
+		}
+		return c.btResult(ctx, data, "/firewall?action=AddDropAddress")
+	default:
+		return RespMSG{}, errors.New("unknown firewall rule type: " + string(params.Type))
+	}
+}
+
+// DeleteFirewallRule 删除 GetFirewallRules 返回的规则
+func (c *Client) DeleteFirewallRule(ctx context.Context, rule FirewallRule) (RespMSG, error) {
+	if rule.Type() == FirewallAcceptPort {
+		return c.DelPortRule(ctx, int64(rule.ID), rule.Port)
+	}
+	data := map[string][]string{
+		"id":   {strconv.Itoa(rule.ID)},
+		"port": {rule.Port},
+	}
+	return c.btResult(ctx, data, "/firewall?action=DelDropAddress")
+}
+
+// AddPortRule 在面板防火墙放行端口 port 为单个端口或端口范围 eg. 8080、8000-9000 或 8000:9000
+// 端口范围按系统防火墙的格式发送 使用非 80/443 端口的网站创建后需调用此方法放行
+func (c *Client) AddPortRule(ctx context.Context, port string, ps string) (RespMSG, error) {
+	port, err := c.firewallPort(ctx, port)
+	if err != nil {
+		return RespMSG{}, err
+	}
+	data := map[string][]string{
+		"port": {port},
+		"ps":   {ps},
+		"type": {string(FirewallAcceptPort)},
+	}
+	return c.btResult(ctx, data, "/firewall?action=AddAcceptPort")
+}
+
+// DelPortRule 删除放行端口规则 id 为 GetFirewallRules 返回的规则 ID
+// 端口范围按系统防火墙的格式发送 面板记录的分隔符与防火墙不一致时也能删除系统规则
+func (c *Client) DelPortRule(ctx context.Context, id int64, port string) (RespMSG, error) {
+	port, err := c.firewallPort(ctx, port)
+	if err != nil {
+		return RespMSG{}, err
+	}
+	data := map[string][]string{
+		"id":   {strconv.FormatInt(id, 10)},
+		"port": {port},
+	}
+	return c.btResult(ctx, data, "/firewall?action=DelAcceptPort")
+}
+
+// SetFirewallStatus 开启或关闭系统防火墙
+func (c *Client) SetFirewallStatus(ctx context.Context, enabled bool) (RespMSG, error) {
+	status := "0"
+	if enabled {
+		status = "1"
+	}
+	return c.btResult(ctx, map[string][]string{"status": {status}}, "/firewall?action=SetFirewallStatus")
+}
+
+// GetSSHStatus 获取 SSH 服务状态、端口及系统防火墙状态
+func (c *Client) GetSSHStatus(ctx context.Context) (RespSSHInfo, error) {
+	resp, err := c.btAPI(ctx, map[string][]string{}, "/firewall?action=GetSshInfo")
+	if err != nil {
+		return RespSSHInfo{}, err
+	}
+	var dec RespSSHInfo
+	if err := c.unmarshal(ctx, resp, &dec); err != nil {
+		return RespSSHInfo{}, err
+	}
+	return dec, nil
+}
+
+// SetSSHStatus 启动或停止 SSH 服务
+// 面板的 status 参数表示当前状态 1 为停止 0 为启动
+func (c *Client) SetSSHStatus(ctx context.Context, enabled bool) (RespMSG, error) {
+	status := "1"
+	if enabled {
+		status = "0"
+	}
+	return c.btResult(ctx, map[string][]string{"status": {status}}, "/firewall?action=SetSshStatus")
+}
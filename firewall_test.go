@@ -0,0 +1,130 @@
+package bt
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestFirewallBackendOf(t *testing.T) {
+	cases := map[string]FirewallBackend{
+		"CentOS Linux 7.9.2009 (Core)":   FirewallFirewalld,
+		"Rocky Linux 9.2 (Blue Onyx)":    FirewallFirewalld,
+		"Ubuntu 22.04.3 LTS":             FirewallUFW,
+		"Debian GNU/Linux 12 (bookworm)": FirewallUFW,
+		"Arch Linux":                     FirewallIptables,
+	}
+	for system, want := range cases {
+		if got := firewallBackendOf(system); got != want {
+			t.Errorf("firewallBackendOf(%q) = %s", system, got)
+		}
+	}
+}
+
+func TestFirewallBackend_PortRange(t *testing.T) {
+	if got := FirewallFirewalld.PortRange(8000, 9000); got != "8000-9000" {
+		t.Errorf("firewalld range %q", got)
+	}
+	if got := FirewallUFW.PortRange(8000, 9000); got != "8000:9000" {
+		t.Errorf("ufw range %q", got)
+	}
+	if got := FirewallIptables.PortRange(80, 80); got != "80" {
+		t.Errorf("single port %q", got)
+	}
+}
+
+func TestFirewallPort(t *testing.T) {
+	cases := []struct {
+		system string
+		rule   string
+		want   string
+	}{
+		{"CentOS Linux 7.9.2009 (Core)", "8000:9000", "8000-9000"},
+		{"Ubuntu 22.04.3 LTS", "8000-9000", "8000:9000"},
+		{"Arch Linux", "8000-9000", "8000:9000"},
+		{"Ubuntu 22.04.3 LTS", "8080", "8080"},
+	}
+	for _, tc := range cases {
+		var sent string
+		c := newFakePanel(t, map[string]http.HandlerFunc{
+			"/system?action=GetSystemTotal": reply(`{"system":"` + tc.system + `"}`),
+			"/firewall?action=DelAcceptPort": func(w http.ResponseWriter, r *http.Request) {
+				sent = r.FormValue("port")
+				_, _ = w.Write([]byte(`{"status":true,"msg":"删除成功"}`))
+			},
+			"/firewall?action=AddAcceptPort": func(w http.ResponseWriter, r *http.Request) {
+				sent = r.FormValue("port")
+				_, _ = w.Write([]byte(`{"status":true,"msg":"添加成功"}`))
+			},
+		})
+		if _, err := c.DelPortRule(ctx, 1, tc.rule); err != nil || sent != tc.want {
+			t.Errorf("%s %s: sent %q, %v", tc.system, tc.rule, sent, err)
+		}
+		sent = ""
+		if _, err := c.AddFirewallRule(ctx, &ReqFirewallRule{Type: FirewallAcceptPort, Port: tc.rule}); err != nil || sent != tc.want {
+			t.Errorf("add %s %s: sent %q, %v", tc.system, tc.rule, sent, err)
+		}
+	}
+	c := newFakePanel(t, nil)
+	if _, err := c.firewallPort(ctx, "9000-8000"); err == nil {
+		t.Error("reversed range accepted")
+	}
+}
+
+func TestAddRegionBlock(t *testing.T) {
+	c := newFakePanel(t, map[string]http.HandlerFunc{
+		"/plugin?action=a": func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Query().Get("s") != "create_countrys" || r.FormValue("country") != "美国" || r.FormValue("choose") != "all" {
+				t.Errorf("unexpected request %s %v", r.URL, r.Form)
+			}
+			_, _ = w.Write([]byte(`{"status":true,"msg":"添加成功"}`))
+		},
+	})
+	r, err := c.AddRegionBlock(ctx, RegionBlock{Country: "美国"})
+	if err != nil || !r.Status {
+		t.Fatalf("AddRegionBlock = %+v, %v", r, err)
+	}
+}
+
+func TestFirewallRules(t *testing.T) {
+	var deleted []string
+	c := newFakePanel(t, map[string]http.HandlerFunc{
+		"/system?action=GetSystemTotal": reply(`{"system":"CentOS Linux 7.9.2009 (Core)"}`),
+		"/data?action=getData":          reply(`{"data":[{"id":1,"port":"8000-9000"},{"id":2,"port":"1.2.3.0/24"}]}`),
+		"/firewall?action=DelAcceptPort": func(w http.ResponseWriter, r *http.Request) {
+			deleted = append(deleted, "port:"+r.FormValue("id")+":"+r.FormValue("port"))
+			_, _ = w.Write([]byte(`{"status":true,"msg":"删除成功"}`))
+		},
+		"/firewall?action=DelDropAddress": func(w http.ResponseWriter, r *http.Request) {
+			deleted = append(deleted, "address:"+r.FormValue("id"))
+			_, _ = w.Write([]byte(`{"status":true,"msg":"删除成功"}`))
+		},
+		"/firewall?action=SetSshStatus": func(w http.ResponseWriter, r *http.Request) {
+			if r.FormValue("status") != "1" {
+				t.Errorf("stop ssh with status %q", r.FormValue("status"))
+			}
+			_, _ = w.Write([]byte(`{"status":true,"msg":"SSH服务已停用"}`))
+		},
+	})
+	rules, err := c.GetFirewallRules(ctx)
+	if err != nil || len(rules) != 2 || rules[0].Type() != FirewallAcceptPort || rules[1].Type() != FirewallDropAddress {
+		t.Fatalf("GetFirewallRules = %+v, %v", rules, err)
+	}
+	for _, r := range rules {
+		if _, err := c.DeleteFirewallRule(ctx, r); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if strings.Join(deleted, " ") != "port:1:8000-9000 address:2" {
+		t.Errorf("deleted %v", deleted)
+	}
+	if _, err := c.AddPortRule(ctx, "9000-8000", ""); err == nil {
+		t.Error("AddPortRule accepted a reversed range")
+	}
+	if _, err := c.AddFirewallRule(ctx, &ReqFirewallRule{Type: FirewallDropAddress, Port: "8080"}); err == nil {
+		t.Error("AddFirewallRule accepted a port as address")
+	}
+	if _, err := c.SetSSHStatus(ctx, false); err != nil {
+		t.Fatal(err)
+	}
+}
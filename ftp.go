@@ -0,0 +1,97 @@
+package bt
+
+import (
+	"context"
+	"errors"
+	"strconv"
+)
+
+// GetFTPAccounts 分页获取全部 FTP 账户 search 为搜索关键字
+func (c *Client) GetFTPAccounts(ctx context.Context, search string) ([]FTPAccount, error) {
+	var ret []FTPAccount
+	err := fetchPages(func(p int64, limit int64) (int, error) {
+		var dec struct {
+			Data []FTPAccount `json:"data"`
+		}
+		if err := c.QueryTable("ftps").Search(search).Page(p).Limit(limit).Into(ctx, &dec); err != nil {
+			return 0, err
+		}
+		ret = append(ret, dec.Data...)
+		return len(dec.Data), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+// AddFTPAccount 添加 FTP 账户
+func (c *Client) AddFTPAccount(ctx context.Context, params *ReqAddFTP) (RespMSG, error) {
+	if params.UserName == "" || params.Password == "" || params.Path == "" {
+		return RespMSG{}, errors.New("ftp username, password and path are required")
+	}
+	ps := params.PS
+	if ps == "" {
+		ps = params.UserName
+	}
+	data := map[string][]string{
+		"ftp_username": {params.UserName},
+		"ftp_password": {params.Password},
+		"path":         {params.Path},
+		"ps":           {ps},
+	}
+	return c.btResult(ctx, data, "/ftp?action=AddUser")
+}
+
+// DeleteFTPAccount 删除 FTP 账户 不会删除根目录中的文件
+func (c *Client) DeleteFTPAccount(ctx context.Context, id int64, username string) (RespMSG, error) {
+	data := map[string][]string{
+		"id":       {strconv.FormatInt(id, 10)},
+		"username": {username},
+	}
+	return c.btResult(ctx, data, "/ftp?action=DeleteUser")
+}
+
+// SetFTPPassword 修改 FTP 账户密码
+func (c *Client) SetFTPPassword(ctx context.Context, id int64, username string, password string) (RespMSG, error) {
+	data := map[string][]string{
+		"id":           {strconv.FormatInt(id, 10)},
+		"ftp_username": {username},
+		"new_password": {password},
+	}
+	return c.btResult(ctx, data, "/ftp?action=SetUserPassword")
+}
+
+// SetFTPStatus 启用或停用 FTP 账户
+func (c *Client) SetFTPStatus(ctx context.Context, id int64, username string, enabled bool) (RespMSG, error) {
+	status := "0"
+	if enabled {
+		status = "1"
+	}
+	data := map[string][]string{
+		"id":       {strconv.FormatInt(id, 10)},
+		"username": {username},
+		"status":   {status},
+	}
+	return c.btResult(ctx, data, "/ftp?action=SetStatus")
+}
+
+// SetFTPDirectory 修改 FTP 账户根目录
+// id FTP 账户ID-必填
+// path 新的根目录 绝对路径-必填
+func (c *Client) SetFTPDirectory(ctx context.Context, id int64, path string) (RespMSG, error) {
+	data := map[string][]string{
+		"id":   {strconv.FormatInt(id, 10)},
+		"path": {path},
+	}
+	return c.btResult(ctx, data, "/ftp?action=ModifyUserPath")
+}
+
+// SetFTPQuota 设置 FTP 账户磁盘配额 quota 单位 MB 填 0 为不限制
+func (c *Client) SetFTPQuota(ctx context.Context, id int64, quota int64) (RespMSG, error) {
+	data := map[string][]string{
+		"id":    {strconv.FormatInt(id, 10)},
+		"quota": {strconv.FormatInt(quota, 10)},
+	}
+	return c.btResult(ctx, data, "/ftp?action=modify_ftp_quota")
+}
@@ -0,0 +1,71 @@
+package bt
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestFTPAccounts(t *testing.T) {
+	c := newFakePanel(t, map[string]http.HandlerFunc{
+		"/data?action=getData": func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Query().Get("table") != "ftps" {
+				t.Errorf("unexpected table %s", r.URL)
+			}
+			_, _ = w.Write([]byte(`{"data":[{"id":3,"pid":7,"name":"w1_ftp","status":"1","path":"/www/wwwroot/w1"}]}`))
+		},
+		"/ftp?action=AddUser": func(w http.ResponseWriter, r *http.Request) {
+			if r.FormValue("ftp_username") != "u1" || r.FormValue("ps") != "u1" {
+				t.Errorf("unexpected form %v", r.Form)
+			}
+			_, _ = w.Write([]byte(`{"status":true,"msg":"添加成功"}`))
+		},
+		"/ftp?action=SetStatus": func(w http.ResponseWriter, r *http.Request) {
+			if r.FormValue("status") != "0" || r.FormValue("username") != "w1_ftp" {
+				t.Errorf("unexpected form %v", r.Form)
+			}
+			_, _ = w.Write([]byte(`{"status":true,"msg":"操作成功"}`))
+		},
+		"/ftp?action=SetUserPassword": reply(`{"status":false,"msg":"密码不能为空"}`),
+	})
+	accounts, err := c.GetFTPAccounts(ctx, "")
+	if err != nil || len(accounts) != 1 || accounts[0].Pid != 7 {
+		t.Fatalf("GetFTPAccounts = %+v, %v", accounts, err)
+	}
+	if _, err := c.AddFTPAccount(ctx, &ReqAddFTP{UserName: "u1", Password: "p", Path: "/www/u1"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.AddFTPAccount(ctx, &ReqAddFTP{UserName: "u1"}); err == nil {
+		t.Error("missing password and path should be rejected")
+	}
+	if _, err := c.SetFTPStatus(ctx, 3, "w1_ftp", false); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.SetFTPPassword(ctx, 3, "w1_ftp", ""); err == nil || err.Error() != "密码不能为空" {
+		t.Errorf("SetFTPPassword = %v", err)
+	}
+}
+
+func TestFTPDirectoryAndQuota(t *testing.T) {
+	c := newFakePanel(t, map[string]http.HandlerFunc{
+		"/ftp?action=ModifyUserPath": func(w http.ResponseWriter, r *http.Request) {
+			if r.FormValue("id") != "3" || r.FormValue("path") != "/www/wwwroot/w2" {
+				t.Errorf("unexpected form %v", r.Form)
+			}
+			_, _ = w.Write([]byte(`{"status":true,"msg":"设置成功"}`))
+		},
+		"/ftp?action=modify_ftp_quota": func(w http.ResponseWriter, r *http.Request) {
+			if r.FormValue("id") != "3" || r.FormValue("quota") != "0" {
+				t.Errorf("unexpected form %v", r.Form)
+			}
+			_, _ = w.Write([]byte(`{"status":false,"msg":"指定FTP不存在"}`))
+		},
+	})
+	if _, err := c.SetFTPDirectory(ctx, 3, "/www/wwwroot/w2"); err != nil {
+		t.Fatal(err)
+	}
+	var apiErr *APIError
+	if _, err := c.SetFTPQuota(ctx, 3, 0); !errors.As(err, &apiErr) || apiErr.Endpoint != "/ftp?action=modify_ftp_quota" || apiErr.Msg != "指定FTP不存在" {
+		t.Errorf("SetFTPQuota = %v", err)
+	}
+}
@@ -0,0 +1,204 @@
+package bt
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// FullSite 新客户开通网站所需的全部配置 除 Site 外均为可选
+type FullSite struct {
+	Site       ReqAddSite    // 必填 网站本身及随网站创建的 FTP、数据库
+	Domains    []string      // 网站创建后追加绑定的域名
+	SSL        bool          // 为全部域名申请 Let's Encrypt 证书（文件验证 域名需已解析到服务器）
+	Rewrite    string        // 伪静态模板名 eg. wordpress（仅支持 nginx）
+	Backup     *CronSchedule // 网站备份计划 为空时不创建
+	BackupSave int64         // 保留的备份份数 默认 3
+	// OnProgress 可选 每个步骤（包括回滚步骤）结束后调用
+	OnProgress func(FullSiteStep)
+}
+
+// FullSiteStep 开通步骤的执行结果
+type FullSiteStep struct {
+	Name string // site/domains/ssl/rewrite/backup 回滚步骤以 rollback_ 开头
+	Err  error
+}
+
+// FullSiteResult CreateFullSite 的执行结果
+type FullSiteResult struct {
+	Site       RespAddSite
+	SiteID     int64
+	CrontabID  int64 // 备份计划任务的 ID
+	Steps      []FullSiteStep
+	RolledBack bool // 是否因失败撤销了已完成的步骤
+}
+
+// CreateFullSite 依次创建网站（含 FTP、数据库）、绑定域名、申请证书、应用伪静态模板并添加备份计划任务
+// 任一步骤失败时按相反顺序撤销已完成的步骤并返回该步骤的错误
+// 撤销网站时会一并删除随网站创建的 FTP 和数据库 但保留网站目录
+func (c *Client) CreateFullSite(ctx context.Context, spec *FullSite) (FullSiteResult, error) {
+	var result FullSiteResult
+	// 即使 ctx 已取消也要完成撤销
+	undoCtx := context.WithoutCancel(ctx)
+	var undo []func() error
+	step := func(name string, run func() error) error {
+		err := run()
+		s := FullSiteStep{Name: name, Err: err}
+		result.Steps = append(result.Steps, s)
+		if spec.OnProgress != nil {
+			spec.OnProgress(s)
+		}
+		return err
+	}
+	fail := func(err error) (FullSiteResult, error) {
+		for i := len(undo) - 1; i >= 0; i-- {
+			_ = undo[i]()
+		}
+		result.RolledBack = len(undo) > 0
+		return result, err
+	}
+
+	name := hostOnly(spec.Site.WebName.Domain)
+	err := step("site", func() error {
+		ret, err := c.AddSite(ctx, &spec.Site)
+		if err != nil {
+			return err
+		}
+		if !ret.SiteStatus {
+			return errors.New("add site failed: " + name)
+		}
+		result.Site, result.SiteID = ret, ret.SiteID
+		if result.SiteID == 0 {
+			result.SiteID, err = c.siteIDByName(ctx, name)
+		}
+		return err
+	})
+	if err != nil {
+		if result.Site.SiteStatus {
+			undo = append(undo, c.undoSite(undoCtx, spec, name, &result))
+		}
+		return fail(err)
+	}
+	undo = append(undo, c.undoSite(undoCtx, spec, name, &result))
+
+	if len(spec.Domains) > 0 {
+		if err := step("domains", func() error {
+			for _, d := range spec.Domains {
+				if err := msgErr(c.AddDomain(ctx, result.SiteID, name, d)); err != nil {
+					return err
+				}
+			}
+			return nil
+		}); err != nil {
+			return fail(err)
+		}
+	}
+	if spec.SSL {
+		if err := step("ssl", func() error {
+			_, err := c.ApplyLetsEncryptCert(ctx, result.SiteID, fullSiteDomains(spec))
+			return err
+		}); err != nil {
+			return fail(err)
+		}
+	}
+	if spec.Rewrite != "" {
+		if err := step("rewrite", func() error {
+			return msgErr(c.ApplyRewriteTemplate(ctx, name, spec.Rewrite))
+		}); err != nil {
+			return fail(err)
+		}
+	}
+	if spec.Backup != nil {
+		save := spec.BackupSave
+		if save == 0 {
+			save = 3
+		}
+		if err := step("backup", func() error {
+			ret, err := c.AddSiteBackupCrontab(ctx, name, *spec.Backup, save)
+			result.CrontabID = ret.ID
+			return err
+		}); err != nil {
+			return fail(err)
+		}
+		undo = append(undo, func() error {
+			return step("rollback_backup", func() error {
+				return msgErr(c.DeleteCrontab(undoCtx, result.CrontabID))
+			})
+		})
+	}
+	return result, nil
+}
+
+// undoSite 删除已创建的网站及随网站创建的 FTP 和数据库
+func (c *Client) undoSite(ctx context.Context, spec *FullSite, name string, result *FullSiteResult) func() error {
+	return func() error {
+		step := FullSiteStep{Name: "rollback_site"}
+		if result.SiteID == 0 {
+			step.Err = errors.New("site id unknown, delete manually: " + name)
+		} else {
+			step.Err = msgErr(c.DeleteSite(ctx, &ReqDeleteSite{
+				ID:       result.SiteID,
+				WebName:  name,
+				FTP:      spec.Site.FTP,
+				Database: spec.Site.SQL,
+			}))
+			if step.Err == nil && spec.Site.IdempotencyKey != "" {
+				// 默认幂等键已由 DeleteSite 删除 自定义的需在此删除 以便重试时重新创建
+				c.forgetIdempotent(spec.Site.IdempotencyKey)
+			}
+		}
+		result.Steps = append(result.Steps, step)
+		if spec.OnProgress != nil {
+			spec.OnProgress(step)
+		}
+		return step.Err
+	}
+}
+
+// siteIDByName 按网站名查询网站 ID
+func (c *Client) siteIDByName(ctx context.Context, name string) (int64, error) {
+	var sites RespSites
+	if err := c.QueryTable("sites").Search(name).Limit(100).Into(ctx, &sites); err != nil {
+		return 0, err
+	}
+	for _, s := range sites.Data {
+		if s.Name == name {
+			return int64(s.ID), nil
+		}
+	}
+	return 0, errors.New("site not found: " + name)
+}
+
+// fullSiteDomains 需要签发证书的全部域名 去除端口
+func fullSiteDomains(spec *FullSite) []string {
+	var domains []string
+	seen := map[string]bool{}
+	all := append([]string{spec.Site.WebName.Domain}, spec.Site.WebName.DomainList...)
+	for _, d := range append(all, spec.Domains...) {
+		d = hostOnly(d)
+		if d != "" && !seen[d] {
+			seen[d] = true
+			domains = append(domains, d)
+		}
+	}
+	return domains
+}
+
+// hostOnly 去除域名中的端口
+func hostOnly(domain string) string {
+	if i := strings.LastIndex(domain, ":"); i >= 0 {
+		if _, err := strconv.Atoi(domain[i+1:]); err == nil {
+			return domain[:i]
+		}
+	}
+	return domain
+}
+
+// msgErr 将面板返回的失败消息转为 error
+func msgErr(ret RespMSG, err error) error {
+	if err == nil && !ret.Status {
+		err = errors.New(ret.Msg)
+	}
+	return err
+}
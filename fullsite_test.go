@@ -0,0 +1,90 @@
+package bt
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func fullSitePanel(t *testing.T, rewrite http.HandlerFunc, deleted *bool) *Client {
+	return newFakePanel(t, map[string]http.HandlerFunc{
+		"/site?action=AddSite":   reply(`{"siteStatus":true,"ftpStatus":false,"databaseStatus":true,"siteId":7}`),
+		"/site?action=AddDomain": reply(`{"status":true,"msg":"添加成功"}`),
+		"/acme?action=apply_cert_api": func(w http.ResponseWriter, r *http.Request) {
+			if r.FormValue("domains") != `["a.com","www.a.com"]` || r.FormValue("auth_to") != "7" {
+				t.Errorf("unexpected cert form %v", r.Form)
+			}
+			_, _ = w.Write([]byte(`{"status":true,"msg":"ok"}`))
+		},
+		"/files?action=GetFileBody":  reply(`{"status":true,"data":"location / {}"}`),
+		"/files?action=SaveFileBody": rewrite,
+		"/crontab?action=AddCrontab": reply(`{"status":true,"msg":"添加成功","id":3}`),
+		"/crontab?action=DelCrontab": reply(`{"status":true,"msg":"删除成功"}`),
+		"/site?action=DeleteSite": func(w http.ResponseWriter, r *http.Request) {
+			if r.FormValue("id") != "7" || r.FormValue("database") != "1" || r.FormValue("path") != "" {
+				t.Errorf("unexpected delete form %v", r.Form)
+			}
+			*deleted = true
+			_, _ = w.Write([]byte(`{"status":true,"msg":"删除成功"}`))
+		},
+	})
+}
+
+func TestCreateFullSite(t *testing.T) {
+	spec := func() *FullSite {
+		return &FullSite{
+			Site:    ReqAddSite{WebName: NewWebName("a.com:80"), Path: "/www/wwwroot/a.com", Type: "PHP", Version: 74, Port: 80, SQL: true},
+			Domains: []string{"www.a.com"},
+			SSL:     true,
+			Rewrite: "wordpress",
+			Backup:  &CronSchedule{Type: "day", Hour: 2},
+		}
+	}
+
+	var deleted bool
+	c := fullSitePanel(t, reply(`{"status":true,"msg":"文件已保存!"}`), &deleted)
+	var names []string
+	s := spec()
+	s.OnProgress = func(step FullSiteStep) { names = append(names, step.Name) }
+	res, err := c.CreateFullSite(ctx, s)
+	if err != nil || res.SiteID != 7 || res.CrontabID != 3 || res.RolledBack || deleted {
+		t.Fatalf("CreateFullSite = %+v, %v", res, err)
+	}
+	if len(names) != 5 || names[0] != "site" || names[4] != "backup" {
+		t.Errorf("progress = %v", names)
+	}
+
+	c = fullSitePanel(t, reply(`{"status":false,"msg":"文件不可写"}`), &deleted)
+	res, err = c.CreateFullSite(ctx, spec())
+	if err == nil || err.Error() != "文件不可写" || !res.RolledBack || !deleted {
+		t.Fatalf("CreateFullSite = %+v, %v", res, err)
+	}
+	last := res.Steps[len(res.Steps)-1]
+	if last.Name != "rollback_site" || last.Err != nil {
+		t.Errorf("steps = %+v", res.Steps)
+	}
+}
+
+func TestCreateFullSiteCanceled(t *testing.T) {
+	var deleted bool
+	runCtx, cancel := context.WithCancel(ctx)
+	c := fullSitePanel(t, func(w http.ResponseWriter, r *http.Request) {
+		cancel()
+		_, _ = w.Write([]byte(`{"status":true,"msg":"文件已保存!"}`))
+	}, &deleted)
+	spec := &FullSite{
+		Site:    ReqAddSite{WebName: NewWebName("a.com"), Path: "/www/wwwroot/a.com", Type: "PHP", Version: 74, Port: 80, SQL: true},
+		Rewrite: "wordpress",
+		Backup:  &CronSchedule{Type: "day", Hour: 2},
+	}
+	// 伪静态步骤中取消 ctx 后续步骤失败 撤销仍需完成
+	res, err := c.CreateFullSite(runCtx, spec)
+	if !errors.Is(err, context.Canceled) || !res.RolledBack || !deleted {
+		t.Fatalf("CreateFullSite = %+v, %v", res, err)
+	}
+	last := res.Steps[len(res.Steps)-1]
+	if last.Name != "rollback_site" || last.Err != nil {
+		t.Errorf("steps = %+v", res.Steps)
+	}
+}
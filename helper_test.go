@@ -0,0 +1,38 @@
+package bt
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// ctx 测试中调用接口使用的默认 ctx
+var ctx = context.Background()
+
+// newFakePanel 启动一个模拟面板 handlers 以 "路径?action=xxx" 为键 返回指向它的 Client
+func newFakePanel(t *testing.T, handlers map[string]http.HandlerFunc) *Client {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := r.URL.Path
+		if action := r.URL.Query().Get("action"); action != "" {
+			key += "?action=" + action
+		}
+		h, ok := handlers[key]
+		if !ok {
+			t.Errorf("unexpected request %s", r.URL)
+			http.NotFound(w, r)
+			return
+		}
+		h(w, r)
+	}))
+	t.Cleanup(srv.Close)
+	return NewClient(srv.URL, "test-key")
+}
+
+// reply 返回固定内容的 handler
+func reply(body string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(body))
+	}
+}
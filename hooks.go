@@ -0,0 +1,160 @@
+package bt
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"log"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// RequestIDHeader 请求关联 ID 使用的请求头 便于在面板前的反向代理日志中对应
+const RequestIDHeader = "X-Request-ID"
+
+// RequestInfo 单次面板 API 调用的信息
+type RequestInfo struct {
+	ID         string     // 请求关联 ID
+	Endpoint   string     // eg. /site?action=AddSite
+	Params     url.Values // 请求参数 不含签名字段
+	Start      time.Time
+	Duration   time.Duration
+	StatusCode int   // HTTP 状态码 未收到响应时为 0
+	Err        error // btAPI 返回的错误
+	Mutation   bool  // 是否为变更类接口 见 IsMutation
+	// PanelFailed 接口返回了 {"status": false} 此时 PanelMsg 为面板返回的 msg
+	PanelFailed bool
+	PanelMsg    string
+	Outcome     Outcome // 请求结果分类
+
+	ctx       context.Context
+	url       string     // 完整请求地址
+	form      url.Values // 含签名字段的实际请求参数 用于 Curl
+	multipart bool       // 以 multipart/form-data 发送（上传）
+}
+
+// Context 发起调用时传入的 ctx 可用于在 Hook 中读取链路追踪等请求范围的数据
+func (r *RequestInfo) Context() context.Context {
+	if r.ctx == nil {
+		return context.Background()
+	}
+	return r.ctx
+}
+
+// readOnlyPrefixes 只读接口 action 的前缀
+var readOnlyPrefixes = []string{"get", "list", "check", "query", "search", "find"}
+
+// endpointAction 取接口的 action 插件接口取方法名 s
+// 新版面板 /模块/子模块/方法 形式的接口取最后一段路径
+func endpointAction(endpoint string) string {
+	path, query, _ := strings.Cut(endpoint, "?")
+	values, _ := url.ParseQuery(query)
+	if s := values.Get("s"); s != "" {
+		return s
+	}
+	if action := values.Get("action"); action != "" {
+		return action
+	}
+	if strings.Count(path, "/") > 1 {
+		return path[strings.LastIndex(path, "/")+1:]
+	}
+	return ""
+}
+
+// IsMutation 根据 action 名判断接口是否会修改面板状态 Get/get_xxx 等只读接口返回 false
+func IsMutation(endpoint string) bool {
+	action := strings.ToLower(endpointAction(endpoint))
+	if action == "" {
+		return false
+	}
+	for _, p := range readOnlyPrefixes {
+		if strings.HasPrefix(action, p) {
+			return false
+		}
+	}
+	return true
+}
+
+// Outcome 请求结果分类 用于在监控中区分基础设施故障和请求本身的失败
+type Outcome string
+
+// 请求结果分类
+const (
+	OutcomeSuccess      Outcome = "success"
+	OutcomeNetworkError Outcome = "network-error"      // 未收到响应 连接失败、超时、ctx 取消等
+	OutcomeHTTPError    Outcome = "http-error"         // 面板返回 HTTP 错误状态码 见 APIError
+	OutcomeDecodeError  Outcome = "decode-error"       // 响应不是合法的 JSON eg. 面板或代理返回了 HTML 页面
+	OutcomePanelFailed  Outcome = "panel-status-false" // 面板返回 {"status": false}
+)
+
+// classifyOutcome 根据错误和响应内容判断请求结果 需在 inspectPanelStatus 之后调用
+func classifyOutcome(info *RequestInfo, body []byte) Outcome {
+	if info.Err != nil {
+		var apiErr *APIError
+		if errors.As(info.Err, &apiErr) {
+			if apiErr.HTTPStatus >= 400 {
+				return OutcomeHTTPError
+			}
+			return OutcomePanelFailed
+		}
+		return OutcomeNetworkError
+	}
+	if info.PanelFailed {
+		return OutcomePanelFailed
+	}
+	if body != nil && !json.Valid(body) {
+		return OutcomeDecodeError
+	}
+	return OutcomeSuccess
+}
+
+// inspectPanelStatus 检查接口是否返回了 status 为 false 的通用消息
+func inspectPanelStatus(info *RequestInfo, body []byte) {
+	trimmed := bytes.TrimSpace(body)
+	if len(trimmed) == 0 || trimmed[0] != '{' {
+		return
+	}
+	var dec struct {
+		Status *bool  `json:"status"`
+		Msg    string `json:"msg"`
+	}
+	if json.Unmarshal(trimmed, &dec) != nil || dec.Status == nil || *dec.Status {
+		return
+	}
+	info.PanelFailed = true
+	info.PanelMsg = dec.Msg
+}
+
+// Hook 请求钩子 每次调用面板 API 结束后触发 不应修改 info
+type Hook func(info *RequestInfo)
+
+// LogHook 将每次调用的关联 ID、接口、耗时和错误写入 logger 为空时使用 log 默认 logger
+func LogHook(logger *log.Logger) Hook {
+	if logger == nil {
+		logger = log.Default()
+	}
+	return func(info *RequestInfo) {
+		if info.Err != nil {
+			logger.Printf("bt: [%s] %s %s after %s: %v", info.ID, info.Endpoint, info.Outcome, info.Duration, info.Err)
+			return
+		}
+		logger.Printf("bt: [%s] %s %d %s", info.ID, info.Endpoint, info.StatusCode, info.Duration)
+	}
+}
+
+func (c *Client) newRequestID() string {
+	if c.RequestIDFunc != nil {
+		return c.RequestIDFunc()
+	}
+	return randomHex(8)
+}
+
+// randomHex 返回 n 字节随机数的十六进制串
+func randomHex(n int) string {
+	b := make([]byte, n)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,102 @@
+package bt
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestHooks_RequestID(t *testing.T) {
+	var header string
+	c := newFakePanel(t, map[string]http.HandlerFunc{
+		"/site?action=SiteStop": func(w http.ResponseWriter, r *http.Request) {
+			header = r.Header.Get(RequestIDHeader)
+			_, _ = w.Write([]byte(`{"status":true,"msg":"站点已停用"}`))
+		},
+	})
+	c.RequestIDFunc = func() string { return "req-1" }
+	var got *RequestInfo
+	c.Hooks = append(c.Hooks, func(info *RequestInfo) { got = info })
+	if _, err := c.StopSite(ctx, 1, "w1.hao.com"); err != nil {
+		t.Fatal(err)
+	}
+	if header != "req-1" || got == nil || got.ID != "req-1" || got.StatusCode != 200 || got.Params.Get("name") != "w1.hao.com" {
+		t.Fatalf("header %q info %+v", header, got)
+	}
+}
+
+func TestHooks_Context(t *testing.T) {
+	type traceKey struct{}
+	release := make(chan struct{})
+	c := newFakePanel(t, map[string]http.HandlerFunc{
+		"/system?action=GetNetWork": reply(`{}`),
+		"/system?action=GetSystemTotal": func(w http.ResponseWriter, r *http.Request) {
+			<-release
+		},
+	})
+	defer close(release)
+	var trace interface{}
+	c.Hooks = append(c.Hooks, func(info *RequestInfo) {
+		trace = info.Context().Value(traceKey{})
+	})
+	if _, err := c.GetNetWork(context.WithValue(ctx, traceKey{}, "span-1")); err != nil {
+		t.Fatal(err)
+	}
+	if trace != "span-1" {
+		t.Errorf("hook saw %v", trace)
+	}
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	if _, err := c.GetSystemTotal(canceled); !errors.Is(err, context.Canceled) {
+		t.Errorf("GetSystemTotal with canceled ctx = %v", err)
+	}
+}
+
+func TestHooks_Outcome(t *testing.T) {
+	c := newFakePanel(t, map[string]http.HandlerFunc{
+		"/system?action=GetNetWork":     reply(`{}`),
+		"/system?action=GetSystemTotal": reply(`<html>502 Bad Gateway</html>`),
+		"/system?action=GetDiskInfo": func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, "bad gateway", http.StatusBadGateway)
+		},
+		"/site?action=SiteStop": reply(`{"status":false,"msg":"网站不存在"}`),
+	})
+	var got []Outcome
+	c.Hooks = append(c.Hooks, func(info *RequestInfo) { got = append(got, info.Outcome) })
+	_, _ = c.GetNetWork(ctx)
+	_, _ = c.GetSystemTotal(ctx)
+	_, _ = c.GetDiskInfo(ctx)
+	_, _ = c.StopSite(ctx, 1, "w1.hao.com")
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	_, _ = c.GetNetWork(canceled)
+	want := []Outcome{OutcomeSuccess, OutcomeDecodeError, OutcomeHTTPError, OutcomePanelFailed, OutcomeNetworkError}
+	if len(got) != len(want) {
+		t.Fatalf("outcomes %v", got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("outcome %d = %s, want %s", i, got[i], want[i])
+		}
+	}
+}
+
+func TestRewriteEndpoint(t *testing.T) {
+	c := newFakePanel(t, map[string]http.HandlerFunc{
+		"/bt/system?action=GetNetWork": reply(`{}`),
+	})
+	c.RewriteEndpoint = func(endpoint string) string { return "/bt" + endpoint }
+	var got *RequestInfo
+	c.Hooks = append(c.Hooks, func(info *RequestInfo) { got = info })
+	if _, err := c.GetNetWork(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if got.Endpoint != "/system?action=GetNetWork" {
+		t.Errorf("hook endpoint %q", got.Endpoint)
+	}
+	if u := c.DownloadURL("/www/a.zip"); !strings.HasPrefix(u, c.BTAddress+"/bt/download?") {
+		t.Errorf("DownloadURL %q", u)
+	}
+}
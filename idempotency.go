@@ -0,0 +1,97 @@
+package bt
+
+import (
+	"strconv"
+	"sync"
+	"time"
+)
+
+// IdempotencyStore 幂等记录存储 保存变更类调用成功后的原始返回
+// 默认提供内存实现 需要跨进程时可自行基于 Redis/数据库实现
+type IdempotencyStore interface {
+	Load(key string) ([]byte, bool)
+	Store(key string, resp []byte)
+	// Delete 删除记录 对应的网站或域名被删除后调用 使之后可以重新创建
+	Delete(key string)
+}
+
+// defaultIdempotencyTTL MemoryIdempotencyStore 记录的默认有效期
+const defaultIdempotencyTTL = time.Hour
+
+// MemoryIdempotencyStore 基于内存的 IdempotencyStore 并发安全
+type MemoryIdempotencyStore struct {
+	TTL time.Duration // 记录的有效期 为 0 时永不过期
+	m   sync.Map
+}
+
+// idempotencyEntry MemoryIdempotencyStore 中的记录
+type idempotencyEntry struct {
+	resp []byte
+	at   time.Time
+}
+
+// NewMemoryIdempotencyStore 实例化内存幂等记录存储 记录默认保留 1 小时
+func NewMemoryIdempotencyStore() *MemoryIdempotencyStore {
+	return &MemoryIdempotencyStore{TTL: defaultIdempotencyTTL}
+}
+
+// Load 实现 IdempotencyStore
+func (s *MemoryIdempotencyStore) Load(key string) ([]byte, bool) {
+	v, ok := s.m.Load(key)
+	if !ok {
+		return nil, false
+	}
+	e := v.(*idempotencyEntry)
+	if s.TTL > 0 && time.Since(e.at) >= s.TTL {
+		s.m.CompareAndDelete(key, v)
+		return nil, false
+	}
+	return e.resp, true
+}
+
+// Store 实现 IdempotencyStore
+func (s *MemoryIdempotencyStore) Store(key string, resp []byte) {
+	s.m.Store(key, &idempotencyEntry{resp: resp, at: time.Now()})
+}
+
+// Delete 实现 IdempotencyStore
+func (s *MemoryIdempotencyStore) Delete(key string) {
+	s.m.Delete(key)
+}
+
+// idempotent 未配置 Idempotency 或 key 为空时直接调用 call
+// 否则先查找记录 未命中时调用 call 并在 succeeded 判定成功后保存结果
+func (c *Client) idempotent(key string, call func() ([]byte, error), succeeded func([]byte) bool) ([]byte, error) {
+	if c.Idempotency == nil || key == "" {
+		return call()
+	}
+	key = c.BTAddress + "|" + key
+	if resp, ok := c.Idempotency.Load(key); ok {
+		return resp, nil
+	}
+	resp, err := call()
+	if err != nil {
+		return resp, err
+	}
+	if succeeded(resp) {
+		c.Idempotency.Store(key, resp)
+	}
+	return resp, nil
+}
+
+// forgetIdempotent 删除 key 对应的幂等记录
+func (c *Client) forgetIdempotent(key string) {
+	if c.Idempotency != nil {
+		c.Idempotency.Delete(c.BTAddress + "|" + key)
+	}
+}
+
+// addSiteIdempotencyKey AddSite 默认的幂等键 与 DeleteSite 的网站名对应
+func addSiteIdempotencyKey(name string) string {
+	return "AddSite:" + hostOnly(name)
+}
+
+// addDomainIdempotencyKey AddDomain 的幂等键
+func addDomainIdempotencyKey(id int64, domain string) string {
+	return "AddDomain:" + strconv.FormatInt(id, 10) + ":" + domain
+}
@@ -0,0 +1,89 @@
+package bt
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestAddDomain_Idempotent(t *testing.T) {
+	calls := 0
+	c := newFakePanel(t, map[string]http.HandlerFunc{
+		"/site?action=AddDomain": func(w http.ResponseWriter, r *http.Request) {
+			calls++
+			_, _ = w.Write([]byte(`{"status":true,"msg":"添加成功"}`))
+		},
+	})
+	c.Idempotency = NewMemoryIdempotencyStore()
+	for i := 0; i < 2; i++ {
+		r, err := c.AddDomain(ctx, 1, "w1.hao.com", "w2.hao.com")
+		if err != nil || !r.Status {
+			t.Fatalf("AddDomain = %+v, %v", r, err)
+		}
+	}
+	if calls != 1 {
+		t.Fatalf("panel called %d times", calls)
+	}
+}
+
+func TestIdempotency_DeleteThenAdd(t *testing.T) {
+	siteID, domainCalls := 0, 0
+	c := newFakePanel(t, map[string]http.HandlerFunc{
+		"/site?action=AddSite": func(w http.ResponseWriter, r *http.Request) {
+			siteID++
+			_, _ = fmt.Fprintf(w, `{"siteStatus":true,"siteId":%d}`, siteID)
+		},
+		"/site?action=DeleteSite": reply(`{"status":true,"msg":"删除成功"}`),
+		"/site?action=AddDomain": func(w http.ResponseWriter, r *http.Request) {
+			domainCalls++
+			_, _ = w.Write([]byte(`{"status":true,"msg":"添加成功"}`))
+		},
+		"/site?action=DelDomain": reply(`{"status":true,"msg":"删除成功"}`),
+	})
+	c.Idempotency = NewMemoryIdempotencyStore()
+	add := func() int64 {
+		r, err := c.AddSite(ctx, &ReqAddSite{WebName: NewWebName("a.com:80"), Path: "/www/wwwroot/a.com"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return r.SiteID
+	}
+	if add() != 1 || add() != 1 {
+		t.Fatal("retry should reuse the first result")
+	}
+	if _, err := c.DeleteSite(ctx, &ReqDeleteSite{ID: 1, WebName: "a.com"}); err != nil {
+		t.Fatal(err)
+	}
+	if id := add(); id != 2 {
+		t.Fatalf("re-add after delete returned cached site %d", id)
+	}
+
+	for _, step := range []func() (RespMSG, error){
+		func() (RespMSG, error) { return c.AddDomain(ctx, 2, "a.com", "b.com") },
+		func() (RespMSG, error) { return c.DelDomain(ctx, 2, "a.com", "b.com", 80) },
+		func() (RespMSG, error) { return c.AddDomain(ctx, 2, "a.com", "b.com") },
+	} {
+		if _, err := step(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if domainCalls != 2 {
+		t.Fatalf("AddDomain reached panel %d times", domainCalls)
+	}
+}
+
+func TestMemoryIdempotencyStore_TTL(t *testing.T) {
+	s := NewMemoryIdempotencyStore()
+	s.TTL = time.Millisecond
+	s.Store("k", []byte("v"))
+	time.Sleep(2 * time.Millisecond)
+	if _, ok := s.Load("k"); ok {
+		t.Fatal("expired entry returned")
+	}
+	s.TTL = 0
+	s.Store("k", []byte("v"))
+	if v, ok := s.Load("k"); !ok || string(v) != "v" {
+		t.Fatalf("Load = %q, %v", v, ok)
+	}
+}
@@ -0,0 +1,206 @@
+//go:build integration
+
+package bt
+
+// 集成测试连接真实的测试面板 默认不参与 go test 需显式开启：
+//
+//	BT_TEST_ADDRESS=http://10.0.0.14:8888 BT_TEST_KEY=xxx go test -tags integration ./...
+//
+// 未设置 BT_TEST_ADDRESS 时只运行离线测试 BT_TEST_TIMEOUT 可调整单次请求超时 默认 10s
+// 测试创建的网站、数据库和计划任务均以 itPrefix 开头 每个测试结束时删除
+// 测试进程开始和结束时还会清理之前异常退出遗留的同前缀资源 请勿在生产面板上运行
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// itPrefix 集成测试创建的资源名前缀
+const itPrefix = "btsdkit"
+
+// integrationClient 根据环境变量创建连接测试面板的 Client
+func integrationClient() *Client {
+	timeout := 10 * time.Second
+	if d, err := time.ParseDuration(os.Getenv("BT_TEST_TIMEOUT")); err == nil {
+		timeout = d
+	}
+	return NewClient(os.Getenv("BT_TEST_ADDRESS"), os.Getenv("BT_TEST_KEY"), timeout)
+}
+
+func TestMain(m *testing.M) {
+	flag.Parse()
+	if client.BTAddress == "" {
+		fmt.Println("BT_TEST_ADDRESS is not set, skipping live panel tests")
+		_ = flag.Set("test.skip", "^TestClient_|^TestIT_|^Test$")
+		os.Exit(m.Run())
+	}
+	sweepTestResources()
+	code := m.Run()
+	sweepTestResources()
+	os.Exit(code)
+}
+
+// itName 生成带前缀的唯一资源名
+func itName() string {
+	b := make([]byte, 4)
+	_, _ = rand.Read(b)
+	return itPrefix + hex.EncodeToString(b)
+}
+
+// disposableSite 创建纯静态测试网站 测试结束时连同目录一起删除
+func disposableSite(t *testing.T) (int64, string) {
+	t.Helper()
+	name := itName() + ".test"
+	ret, err := client.AddSite(ctx, &ReqAddSite{
+		WebName: NewWebName(name),
+		Path:    "/www/wwwroot/" + name,
+		Type:    "PHP",
+		Version: PHPVersionStatic,
+		Port:    80,
+		PS:      name,
+	})
+	if err != nil || !ret.SiteStatus {
+		t.Fatalf("create site %s: %+v, %v", name, ret, err)
+	}
+	id := ret.SiteID
+	if id == 0 {
+		if id, err = client.siteIDByName(ctx, name); err != nil {
+			t.Fatal(err)
+		}
+	}
+	t.Cleanup(func() {
+		if _, err := client.DeleteSite(ctx, &ReqDeleteSite{ID: id, WebName: name, Path: true}); err != nil {
+			t.Errorf("delete site %s: %v", name, err)
+		}
+	})
+	return id, name
+}
+
+// disposableDatabase 创建测试数据库 测试结束时删除
+func disposableDatabase(t *testing.T) string {
+	t.Helper()
+	name := itName()
+	if _, err := client.AddDatabase(ctx, &ReqAddDatabase{Name: name, Password: itName()}); err != nil {
+		t.Fatalf("create database %s: %v", name, err)
+	}
+	t.Cleanup(func() {
+		if err := deleteTestDatabase(name); err != nil {
+			t.Errorf("delete database %s: %v", name, err)
+		}
+	})
+	return name
+}
+
+// disposableCrontab 创建只输出一行内容的 Shell 计划任务 测试结束时删除
+func disposableCrontab(t *testing.T) int64 {
+	t.Helper()
+	name := itName()
+	ret, err := client.AddShellCrontab(ctx, name, Monthly(1, 3, 0), "echo "+name)
+	if err != nil || !ret.Status {
+		t.Fatalf("create crontab %s: %+v, %v", name, ret, err)
+	}
+	t.Cleanup(func() {
+		if _, err := client.DeleteCrontab(ctx, ret.ID); err != nil {
+			t.Errorf("delete crontab %s: %v", name, err)
+		}
+	})
+	return ret.ID
+}
+
+// deleteTestDatabase 按名称删除测试数据库
+func deleteTestDatabase(name string) error {
+	var dec struct {
+		Data []struct {
+			ID   int64  `json:"id"`
+			Name string `json:"name"`
+		} `json:"data"`
+	}
+	if err := client.QueryTable("databases").Search(name).Limit(100).Into(ctx, &dec); err != nil {
+		return err
+	}
+	for _, db := range dec.Data {
+		if db.Name != name {
+			continue
+		}
+		_, err := client.btResult(ctx, map[string][]string{
+			"id":   {strconv.FormatInt(db.ID, 10)},
+			"name": {db.Name},
+		}, "/database?action=DeleteDatabase")
+		return err
+	}
+	return nil
+}
+
+// sweepTestResources 删除之前异常退出遗留的测试资源
+func sweepTestResources() {
+	if sites, err := client.ListAllSites(ctx, itPrefix); err == nil {
+		for _, s := range sites {
+			if strings.HasPrefix(s.Name, itPrefix) {
+				_, _ = client.DeleteSite(ctx, &ReqDeleteSite{ID: int64(s.ID), WebName: s.Name, Path: true})
+			}
+		}
+	}
+	if crons, err := client.GetCrontabList(ctx); err == nil {
+		for _, t := range crons {
+			if strings.HasPrefix(t.Name, itPrefix) {
+				_, _ = client.DeleteCrontab(ctx, t.ID)
+			}
+		}
+	}
+	var dbs struct {
+		Data []struct {
+			Name string `json:"name"`
+		} `json:"data"`
+	}
+	if err := client.QueryTable("databases").Search(itPrefix).Limit(100).Into(ctx, &dbs); err == nil {
+		for _, db := range dbs.Data {
+			if strings.HasPrefix(db.Name, itPrefix) {
+				_ = deleteTestDatabase(db.Name)
+			}
+		}
+	}
+}
+
+func TestIT_SiteLifecycle(t *testing.T) {
+	id, name := disposableSite(t)
+	if _, err := client.StopSite(ctx, id, name); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.StartSite(ctx, id, name); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.SetSitePS(ctx, id, "integration"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestIT_Database(t *testing.T) {
+	name := disposableDatabase(t)
+	var dec RespSites
+	if err := client.QueryTable("databases").Search(name).Into(ctx, &dec); err != nil || len(dec.Data) != 1 {
+		t.Fatalf("database %s not listed: %+v, %v", name, dec, err)
+	}
+}
+
+func TestIT_Crontab(t *testing.T) {
+	id := disposableCrontab(t)
+	if _, err := client.RunCrontabNow(ctx, id); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.StopCrontab(ctx, id); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.StartCrontab(ctx, id); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.ClearCrontabLogs(ctx, id); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,112 @@
+package bt
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// ErrClientClosed Client 已调用 Close 后再发起请求时返回
+var ErrClientClosed = errors.New("bt: client is closed")
+
+// httpClient 返回 Client 复用的 http.Client 首次调用时创建
+// 未配置 HTTPClient 时使用 Client 独占的连接池 以便 Close 时只释放自己的连接
+func (c *Client) httpClient() (*http.Client, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closed {
+		return nil, ErrClientClosed
+	}
+	if c.client == nil {
+		if c.HTTPClient != nil {
+			client := *c.HTTPClient
+			c.client = &client
+		} else {
+			c.transport = http.DefaultTransport.(*http.Transport).Clone()
+			if c.DialAddress != "" {
+				c.transport.DialContext = dialOverride(c.DialAddress)
+			}
+			c.client = &http.Client{Transport: c.transport}
+		}
+		if c.client.Jar == nil {
+			c.client.Jar = panelJar{c: c}
+		}
+	}
+	return c.client, nil
+}
+
+// dialOverride 返回始终连接 target 的 DialContext target 未指定端口时沿用原地址的端口
+func dialOverride(target string) func(ctx context.Context, network, addr string) (net.Conn, error) {
+	dialer := &net.Dialer{Timeout: 30 * time.Second, KeepAlive: 30 * time.Second}
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		host := target
+		if _, _, err := net.SplitHostPort(target); err != nil {
+			_, port, err := net.SplitHostPort(addr)
+			if err != nil {
+				return nil, err
+			}
+			host = net.JoinHostPort(strings.Trim(target, "[]"), port)
+		}
+		return dialer.DialContext(ctx, network, host)
+	}
+}
+
+// onClose 注册 Close 时需要执行的清理函数 Client 已关闭时立即执行
+// 返回的函数用于后台任务自行结束时取消注册
+func (c *Client) onClose(fn func()) (unregister func()) {
+	c.mu.Lock()
+	if c.closed {
+		c.mu.Unlock()
+		fn()
+		return func() {}
+	}
+	p := &fn
+	c.closers = append(c.closers, p)
+	c.mu.Unlock()
+	return func() {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		for i, f := range c.closers {
+			if f == p {
+				c.closers = append(c.closers[:i], c.closers[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+// isClosed 是否已调用 Close
+func (c *Client) isClosed() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.closed
+}
+
+// Close 关闭 Client：释放空闲连接、停止使用该 Client 的 Queue.Run 并清除 API Key、cookies 及缓存的面板信息
+// 之后的调用都会返回 ErrClientClosed 重复调用无副作用
+func (c *Client) Close() error {
+	c.mu.Lock()
+	if c.closed {
+		c.mu.Unlock()
+		return nil
+	}
+	c.closed = true
+	closers := c.closers
+	c.closers = nil
+	transport := c.transport
+	c.BTKey = ""
+	c.cookies = nil
+	c.cacheVer, c.cacheVerAt = "", time.Time{}
+	c.mu.Unlock()
+	c.ResetCapabilities()
+	for i := len(closers) - 1; i >= 0; i-- {
+		(*closers[i])()
+	}
+	if transport != nil {
+		transport.CloseIdleConnections()
+	}
+	return nil
+}
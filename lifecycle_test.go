@@ -0,0 +1,123 @@
+package bt
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestClose(t *testing.T) {
+	c := newFakePanel(t, map[string]http.HandlerFunc{
+		"/system?action=GetSystemTotal": reply(`{"version":"7.9.0"}`),
+	})
+	if _, err := c.cacheVersion(ctx); err != nil {
+		t.Fatal(err)
+	}
+	c.capabilities = &Capabilities{WebServer: "nginx"}
+	stopped := 0
+	c.onClose(func() { stopped++ })
+	c.onClose(func() { stopped += 10 })()
+	if err := c.Close(); err != nil {
+		t.Fatal(err)
+	}
+	_ = c.Close()
+	if stopped != 1 || c.BTKey != "" {
+		t.Fatalf("stopped %d key %q", stopped, c.BTKey)
+	}
+	if c.cacheVer != "" || c.capabilities != nil {
+		t.Fatalf("cached panel info kept: %q %+v", c.cacheVer, c.capabilities)
+	}
+	if _, err := c.GetSystemTotal(ctx); !errors.Is(err, ErrClientClosed) {
+		t.Fatalf("err = %v", err)
+	}
+	c.onClose(func() { stopped++ })
+	if stopped != 2 {
+		t.Fatal("onClose after Close should run immediately")
+	}
+}
+
+func TestCloseConcurrent(t *testing.T) {
+	c := newFakePanel(t, map[string]http.HandlerFunc{
+		"/system?action=GetSystemTotal": reply(`{"version":"7.9.0"}`),
+	})
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 20; j++ {
+				_, _ = c.GetSystemTotal(ctx)
+			}
+		}()
+	}
+	_ = c.Close()
+	wg.Wait()
+}
+
+func TestDialAddress(t *testing.T) {
+	var host string
+	c := newFakePanel(t, map[string]http.HandlerFunc{
+		"/system?action=GetSystemTotal": func(w http.ResponseWriter, r *http.Request) {
+			host = r.Host
+			_, _ = w.Write([]byte(`{"version":"7.9.0"}`))
+		},
+	})
+	u, _ := url.Parse(c.BTAddress)
+	c.BTAddress = "http://panel.example.invalid:" + u.Port()
+	c.DialAddress = "127.0.0.1"
+	if _, err := c.GetSystemTotal(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if host != "panel.example.invalid:"+u.Port() {
+		t.Fatalf("host = %q", host)
+	}
+}
+
+// roundTripFunc 函数形式的 http.RoundTripper
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+func TestHTTPClientReuse(t *testing.T) {
+	var conns int32
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"version":"7.9.0"}`))
+	}))
+	srv.Config.ConnState = func(_ net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			atomic.AddInt32(&conns, 1)
+		}
+	}
+	srv.Start()
+	defer srv.Close()
+	c := NewClient(srv.URL, "test-key", time.Second)
+	for i := 0; i < 3; i++ {
+		if _, err := c.GetSystemTotal(ctx); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if n := atomic.LoadInt32(&conns); n != 1 {
+		t.Errorf("opened %d connections", n)
+	}
+
+	var proxied int
+	injected := NewClient(srv.URL, "test-key")
+	injected.HTTPClient = &http.Client{Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		proxied++
+		return http.DefaultTransport.RoundTrip(r)
+	})}
+	if _, err := injected.GetSystemTotal(ctx); err != nil || proxied != 1 {
+		t.Fatalf("injected client used %d times, %v", proxied, err)
+	}
+	if injected.HTTPClient.Jar != nil {
+		t.Error("caller's http.Client was modified")
+	}
+}
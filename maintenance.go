@@ -0,0 +1,81 @@
+package bt
+
+import (
+	"context"
+	"errors"
+	"strings"
+)
+
+// maintenancePage 维护页面的文件名及访问路径
+const maintenancePage = "/btsdk-maintenance.html"
+
+// maintenanceBlock 生成维护模式的 nginx 配置段
+// 除维护页面和 /.well-known/（证书续签）外的请求均返回 503 并展示 file
+func maintenanceBlock(file string) string {
+	return strings.Join([]string{
+		"set $btsdk_maintenance 1;",
+		`if ($uri ~ "^/(\.well-known/|btsdk-maintenance\.html$)") {`,
+		"set $btsdk_maintenance 0;",
+		"}",
+		"if ($btsdk_maintenance) {",
+		"return 503;",
+		"}",
+		"error_page 503 " + maintenancePage + ";",
+		"location = " + maintenancePage + " {",
+		"alias " + file + ";",
+		"add_header Retry-After 600 always;",
+		"internal;",
+		"}",
+	}, "\n")
+}
+
+// EnableMaintenance 开启网站维护模式（仅支持 nginx）
+// 将 html 写入网站目录下的 btsdk-maintenance.html 之后所有请求返回 503 并展示该页面
+// 网站原有配置不做修改 DisableMaintenance 移除维护配置后即恢复 重复调用时替换维护页面
+func (c *Client) EnableMaintenance(ctx context.Context, id int64, html string) error {
+	if strings.TrimSpace(html) == "" {
+		return errors.New("maintenance page is empty")
+	}
+	if len(html) > maxErrorPageSize {
+		return errors.New("maintenance page is larger than 512KB")
+	}
+	name, err := c.siteKey(ctx, id, "name")
+	if err != nil {
+		return err
+	}
+	path, err := c.siteKey(ctx, id, "path")
+	if err != nil {
+		return err
+	}
+	file := strings.TrimRight(path, "/") + maintenancePage
+	if _, err := c.WriteFile(ctx, file, html, true, false); err != nil {
+		return err
+	}
+	_, err = c.editManagedBlock(ctx, name, "MAINTENANCE", maintenanceBlock(file))
+	return err
+}
+
+// DisableMaintenance 关闭网站维护模式 恢复开启前的配置 维护页面文件保留
+func (c *Client) DisableMaintenance(ctx context.Context, id int64) error {
+	name, err := c.siteKey(ctx, id, "name")
+	if err != nil {
+		return err
+	}
+	_, err = c.editManagedBlock(ctx, name, "MAINTENANCE", "")
+	return err
+}
+
+// WithMaintenance 在维护模式下执行 fn（eg. 部署） fn 返回或 panic 后总会关闭维护模式
+// 开启失败时不执行 fn fn 的错误优先于关闭维护模式的错误返回
+func (c *Client) WithMaintenance(ctx context.Context, id int64, html string, fn func(ctx context.Context) error) (err error) {
+	if err := c.EnableMaintenance(ctx, id, html); err != nil {
+		return err
+	}
+	defer func() {
+		// 即使 ctx 已取消也要恢复网站
+		if derr := c.DisableMaintenance(context.WithoutCancel(ctx), id); err == nil {
+			err = derr
+		}
+	}()
+	return fn(ctx)
+}
@@ -0,0 +1,61 @@
+package bt
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestWithMaintenance(t *testing.T) {
+	original := "server\n{\n    listen 80;\n    #ERROR-PAGE-START\n    #ERROR-PAGE-END\n}\n"
+	vhost := NginxVhostPath("w1.hao.com")
+	files := map[string]string{vhost: original}
+	c := newFakePanel(t, map[string]http.HandlerFunc{
+		"/data?action=getKey": func(w http.ResponseWriter, r *http.Request) {
+			if r.FormValue("key") == "name" {
+				_, _ = w.Write([]byte(`"w1.hao.com"`))
+				return
+			}
+			_, _ = w.Write([]byte(`"/www/wwwroot/w1.hao.com/"`))
+		},
+		"/files?action=GetFileBody": func(w http.ResponseWriter, r *http.Request) {
+			body, ok := files[r.FormValue("path")]
+			_ = json.NewEncoder(w).Encode(RespGetFile{Status: ok, Data: body})
+		},
+		"/files?action=CreateFile": func(w http.ResponseWriter, r *http.Request) {
+			files[r.FormValue("path")] = ""
+			_, _ = w.Write([]byte(`{"status":true,"msg":"文件创建成功"}`))
+		},
+		"/files?action=SaveFileBody": func(w http.ResponseWriter, r *http.Request) {
+			files[r.FormValue("path")] = r.FormValue("data")
+			_, _ = w.Write([]byte(`{"status":true,"msg":"文件已保存!"}`))
+		},
+	})
+	deployErr := errors.New("deploy failed")
+	canceled, cancel := context.WithCancel(ctx)
+	err := c.WithMaintenance(canceled, 11, "<h1>back soon</h1>", func(ctx context.Context) error {
+		if files["/www/wwwroot/w1.hao.com/btsdk-maintenance.html"] != "<h1>back soon</h1>" {
+			t.Errorf("page not written: %v", files)
+		}
+		conf := files[vhost]
+		if !strings.Contains(conf, "return 503;") || !strings.Contains(conf, "alias /www/wwwroot/w1.hao.com/btsdk-maintenance.html;") {
+			t.Errorf("maintenance not enabled:\n%s", conf)
+		}
+		if strings.Index(conf, "#BTSDK-MAINTENANCE-START") > strings.Index(conf, "#ERROR-PAGE-START") {
+			t.Errorf("maintenance block after error pages:\n%s", conf)
+		}
+		cancel()
+		return deployErr
+	})
+	if !errors.Is(err, deployErr) {
+		t.Fatalf("WithMaintenance = %v", err)
+	}
+	if files[vhost] != original {
+		t.Fatalf("config not restored:\n%s", files[vhost])
+	}
+	if err := c.EnableMaintenance(ctx, 11, " "); err == nil {
+		t.Error("EnableMaintenance accepted an empty page")
+	}
+}
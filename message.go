@@ -0,0 +1,102 @@
+package bt
+
+import "strings"
+
+// MsgCode 面板消息对应的机器可读代码 便于 UI 展示或程序判断
+type MsgCode string
+
+// 常见面板消息代码
+const (
+	MsgCodeSuccess       MsgCode = "success"
+	MsgCodeAdded         MsgCode = "added"
+	MsgCodeDeleted       MsgCode = "deleted"
+	MsgCodeModified      MsgCode = "modified"
+	MsgCodeSiteStopped   MsgCode = "site_stopped"
+	MsgCodeSiteStarted   MsgCode = "site_started"
+	MsgCodeBackupDone    MsgCode = "backup_done"
+	MsgCodeSiteExists    MsgCode = "site_exists"
+	MsgCodeSiteNotFound  MsgCode = "site_not_found"
+	MsgCodeDomainExists  MsgCode = "domain_exists"
+	MsgCodeFileNotFound  MsgCode = "file_not_found"
+	MsgCodeIPDenied      MsgCode = "ip_denied"
+	MsgCodeKeyInvalid    MsgCode = "key_invalid"
+	MsgCodeParamsInvalid MsgCode = "params_invalid"
+)
+
+// Translator 将面板返回的 msg 翻译为目标文本及机器可读代码
+// ok 为 false 表示未命中 此时保留原始 msg
+type Translator interface {
+	Translate(msg string) (text string, code MsgCode, ok bool)
+}
+
+// TranslatorFunc 函数形式的 Translator
+type TranslatorFunc func(msg string) (string, MsgCode, bool)
+
+// Translate 实现 Translator
+func (f TranslatorFunc) Translate(msg string) (string, MsgCode, bool) {
+	return f(msg)
+}
+
+// MsgEntry 翻译表中的一项 Text 为空时只映射代码不替换文本
+type MsgEntry struct {
+	Text string
+	Code MsgCode
+}
+
+// MsgTable 基于原始 msg 的翻译表
+// 优先精确匹配 其次匹配最长的前缀（用于 "IP校验失败,您的访问IP为[...]" 这类带参数的消息）
+type MsgTable map[string]MsgEntry
+
+// Translate 实现 Translator
+func (t MsgTable) Translate(msg string) (string, MsgCode, bool) {
+	msg = strings.TrimSpace(msg)
+	entry, ok := t[msg]
+	if !ok {
+		longest := -1
+		for k, v := range t {
+			if len(k) > longest && strings.HasPrefix(msg, k) {
+				entry, longest, ok = v, len(k), true
+			}
+		}
+	}
+	if !ok {
+		return msg, "", false
+	}
+	if entry.Text == "" {
+		return msg, entry.Code, true
+	}
+	return entry.Text, entry.Code, true
+}
+
+// EnglishMessages 常见面板消息的英文翻译 可直接赋值给 Client.Translator
+// 也可复制后按需增删 适用于宝塔及 aaPanel
+var EnglishMessages = MsgTable{
+	"操作成功":       {"Operation succeeded", MsgCodeSuccess},
+	"设置成功":       {"Settings saved", MsgCodeSuccess},
+	"添加成功":       {"Added successfully", MsgCodeAdded},
+	"删除成功":       {"Deleted successfully", MsgCodeDeleted},
+	"修改成功":       {"Modified successfully", MsgCodeModified},
+	"文件已保存!":     {"File saved", MsgCodeModified},
+	"站点已停用":      {"Site stopped", MsgCodeSiteStopped},
+	"站点已启用":      {"Site started", MsgCodeSiteStarted},
+	"备份成功":       {"Backup completed", MsgCodeBackupDone},
+	"您添加的站点已存在!": {"The site already exists", MsgCodeSiteExists},
+	"指定站点不存在!":   {"The specified site does not exist", MsgCodeSiteNotFound},
+	"您添加的域名已存在!": {"The domain already exists", MsgCodeDomainExists},
+	"指定文件不存在!":   {"The specified file does not exist", MsgCodeFileNotFound},
+	"IP校验失败":     {"IP verification failed, add this IP to the API allowlist", MsgCodeIPDenied},
+	"密钥校验失败":     {"API key verification failed", MsgCodeKeyInvalid},
+	"参数错误":       {"Invalid parameters", MsgCodeParamsInvalid},
+}
+
+// Translate 使用 t 翻译 Msg 原始内容保存在 RawMsg 中 可重复调用
+func (r *RespMSG) Translate(t Translator) {
+	if r.RawMsg == "" {
+		r.RawMsg = r.Msg
+	}
+	text, code, ok := t.Translate(r.RawMsg)
+	if !ok {
+		return
+	}
+	r.Msg, r.Code = text, code
+}
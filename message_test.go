@@ -0,0 +1,36 @@
+package bt
+
+import "testing"
+
+func TestMsgTable_Translate(t *testing.T) {
+	cases := []struct {
+		msg  string
+		text string
+		code MsgCode
+		ok   bool
+	}{
+		{"修改成功", "Modified successfully", MsgCodeModified, true},
+		{"IP校验失败,您的访问IP为[1.2.3.4]", "IP verification failed, add this IP to the API allowlist", MsgCodeIPDenied, true},
+		{"未知消息", "未知消息", "", false},
+	}
+	for _, c := range cases {
+		text, code, ok := EnglishMessages.Translate(c.msg)
+		if text != c.text || code != c.code || ok != c.ok {
+			t.Errorf("Translate(%q) = %q, %q, %v", c.msg, text, code, ok)
+		}
+	}
+}
+
+func TestRespMSG_Translate(t *testing.T) {
+	r := RespMSG{Status: true, Msg: "删除成功"}
+	r.Translate(EnglishMessages)
+	r.Translate(EnglishMessages)
+	if r.Msg != "Deleted successfully" || r.Code != MsgCodeDeleted || r.RawMsg != "删除成功" {
+		t.Errorf("unexpected %+v", r)
+	}
+	codeOnly := MsgTable{"删除成功": {Code: "gone"}}
+	r.Translate(codeOnly)
+	if r.Msg != "删除成功" || r.Code != "gone" {
+		t.Errorf("unexpected %+v", r)
+	}
+}
@@ -0,0 +1,137 @@
+package bt
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+)
+
+// Migration 面板升级后响应字段改名或改变类型时 将某一版本范围内的原始响应转换为模型当前的结构
+// 使同一个 SDK 版本可以解析多个面板版本的响应
+type Migration struct {
+	Model interface{} // 目标模型 eg. RespSSLInfo{} 或 &RespSSLInfo{} 同时适用于以该模型为元素的列表
+	Since string      // 适用的最低面板版本（含）为空时不限
+	Until string      // 适用的面板版本上限（不含）为空时不限
+	// Migrate 转换原始 JSON 可使用 RenameField/ConvertField 构造
+	Migrate func(raw []byte) ([]byte, error)
+}
+
+// versioned 是否只适用于部分面板版本
+func (m Migration) versioned() bool {
+	return m.Since != "" || m.Until != ""
+}
+
+// applies 判断是否适用于面板版本 ver
+func (m Migration) applies(ver Version) bool {
+	if m.Since != "" && !ver.IsAtLeast(m.Since) {
+		return false
+	}
+	return m.Until == "" || !ver.IsAtLeast(m.Until)
+}
+
+var (
+	migrationsMu sync.RWMutex
+	migrations   = map[reflect.Type][]Migration{}
+)
+
+// RegisterMigration 注册响应迁移 同一模型的多个迁移按注册顺序依次执行 对所有 Client 生效
+func RegisterMigration(m Migration) error {
+	if m.Model == nil || m.Migrate == nil {
+		return errors.New("migration model and func are required")
+	}
+	for _, v := range []string{m.Since, m.Until} {
+		if v == "" {
+			continue
+		}
+		if _, err := ParseVersion(v); err != nil {
+			return err
+		}
+	}
+	t := modelType(m.Model)
+	migrationsMu.Lock()
+	migrations[t] = append(migrations[t], m)
+	migrationsMu.Unlock()
+	return nil
+}
+
+// modelType 去掉指针和切片后的模型类型 为 X 注册的迁移同样适用于 []X 的响应
+func modelType(v interface{}) reflect.Type {
+	t := reflect.TypeOf(v)
+	for t.Kind() == reflect.Ptr || t.Kind() == reflect.Slice {
+		t = t.Elem()
+	}
+	return t
+}
+
+// versionLookupKey 标记正在获取面板版本 避免解析 SystemTotal 时再次获取版本
+type versionLookupKey struct{}
+
+// migrate 依次应用目标模型已注册的迁移 获取面板版本失败时只应用不限版本的迁移
+func (c *Client) migrate(ctx context.Context, data []byte, v interface{}) ([]byte, error) {
+	migrationsMu.RLock()
+	list := migrations[modelType(v)]
+	migrationsMu.RUnlock()
+	if len(list) == 0 {
+		return data, nil
+	}
+	var ver *Version
+	for _, m := range list {
+		if m.versioned() {
+			if ver == nil && ctx.Value(versionLookupKey{}) == nil {
+				if raw, err := c.cacheVersion(context.WithValue(ctx, versionLookupKey{}, true)); err == nil {
+					if parsed, err := ParseVersion(raw); err == nil {
+						ver = &parsed
+					}
+				}
+			}
+			if ver == nil || !m.applies(*ver) {
+				continue
+			}
+		}
+		var err error
+		if data, err = m.Migrate(data); err != nil {
+			return nil, err
+		}
+	}
+	return data, nil
+}
+
+// RenameField 返回将字段 from 改名为 to 的迁移函数 响应为数组时处理每个元素 to 已存在时不覆盖
+func RenameField(from string, to string) func([]byte) ([]byte, error) {
+	return ConvertField(from, func(obj map[string]interface{}, value interface{}) {
+		delete(obj, from)
+		if _, ok := obj[to]; !ok {
+			obj[to] = value
+		}
+	})
+}
+
+// ConvertField 返回对字段 name 执行 convert 的迁移函数 convert 可修改 obj 中的任意字段
+// 响应为数组时处理每个元素 不含该字段的对象保持不变
+func ConvertField(name string, convert func(obj map[string]interface{}, value interface{})) func([]byte) ([]byte, error) {
+	return func(raw []byte) ([]byte, error) {
+		var doc interface{}
+		dec := json.NewDecoder(bytes.NewReader(raw))
+		dec.UseNumber()
+		if err := dec.Decode(&doc); err != nil {
+			return nil, err
+		}
+		apply := func(item interface{}) {
+			if obj, ok := item.(map[string]interface{}); ok {
+				if value, ok := obj[name]; ok {
+					convert(obj, value)
+				}
+			}
+		}
+		if list, ok := doc.([]interface{}); ok {
+			for _, item := range list {
+				apply(item)
+			}
+		} else {
+			apply(doc)
+		}
+		return json.Marshal(doc)
+	}
+}
@@ -0,0 +1,56 @@
+package bt
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestMigration(t *testing.T) {
+	type legacy struct {
+		ID   int64  `json:"id"`
+		Name string `json:"name"`
+		Port string `json:"port"`
+	}
+	defer func() {
+		migrationsMu.Lock()
+		delete(migrations, modelType(legacy{}))
+		migrationsMu.Unlock()
+	}()
+	// 9.0 之前字段名为 title 9.0 起 port 改为数字
+	if err := RegisterMigration(Migration{Model: legacy{}, Until: "9.0", Migrate: RenameField("title", "name")}); err != nil {
+		t.Fatal(err)
+	}
+	if err := RegisterMigration(Migration{Model: &legacy{}, Since: "9.0", Migrate: ConvertField("port", func(obj map[string]interface{}, v interface{}) {
+		obj["port"] = fmt.Sprint(v)
+	})}); err != nil {
+		t.Fatal(err)
+	}
+	if err := RegisterMigration(Migration{Model: legacy{}, Since: "x", Migrate: RenameField("a", "b")}); err == nil {
+		t.Error("invalid version should be rejected")
+	}
+
+	version := "8.0.1"
+	c := newFakePanel(t, map[string]http.HandlerFunc{
+		"/system?action=GetSystemTotal": func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(`{"version":"` + version + `"}`))
+		},
+	})
+	var got []legacy
+	if err := c.unmarshal(ctx, []byte(`[{"id":1700000000123,"title":"a","port":"80"}]`), &got); err != nil {
+		t.Fatal(err)
+	}
+	if got[0].Name != "a" || got[0].ID != 1700000000123 {
+		t.Errorf("8.x = %+v", got)
+	}
+
+	version = "9.2.0"
+	c.cacheVer = ""
+	var one legacy
+	if err := c.unmarshal(ctx, []byte(`{"id":1,"name":"b","port":8080}`), &one); err != nil {
+		t.Fatal(err)
+	}
+	if one.Name != "b" || one.Port != "8080" {
+		t.Errorf("9.x = %+v", one)
+	}
+}
@@ -0,0 +1,19 @@
+package bt
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestGetNetWorkByInterface(t *testing.T) {
+	c := newFakePanel(t, map[string]http.HandlerFunc{
+		"/system?action=GetNetWork": reply(`{"up":3,"down":5,"network":{"eth0":{"up":1,"down":2,"upTotal":100},"wg0":{"up":2,"down":3}}}`),
+	})
+	r, err := c.GetNetWorkByInterface(ctx, "wg0")
+	if err != nil || r.Up != 2 || r.Down != 3 {
+		t.Fatalf("wg0 = %+v, %v", r, err)
+	}
+	if _, err := c.GetNetWorkByInterface(ctx, "eth1"); err == nil {
+		t.Fatal("expected error for missing interface")
+	}
+}
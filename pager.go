@@ -0,0 +1,66 @@
+package bt
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"strings"
+)
+
+// pageLimits 自适应分页依次尝试的每页条数 每档都能整除上一档 保证退避后页码仍能对齐已获取的条数
+var pageLimits = []int64{800, 400, 200, 100, 50, 25}
+
+// fetchPages 以较大的每页条数开始分页获取 请求超时时减半后重试当前位置
+// fetch 返回本页条数 少于 limit 时视为最后一页 只应在成功时保存结果
+func fetchPages(fetch func(p int64, limit int64) (int, error)) error {
+	level := 0
+	var got int64
+	for {
+		limit := pageLimits[level]
+		n, err := fetch(got/limit+1, limit)
+		if err != nil {
+			if isTimeout(err) && level < len(pageLimits)-1 {
+				level++
+				continue
+			}
+			return err
+		}
+		got += int64(n)
+		if int64(n) < limit {
+			return nil
+		}
+	}
+}
+
+// isTimeout 判断错误是否为请求超时（含网关超时）
+func isTimeout(err error) bool {
+	var ne net.Error
+	if errors.As(err, &ne) && ne.Timeout() {
+		return true
+	}
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && apiErr.HTTPStatus == http.StatusGatewayTimeout {
+		return true
+	}
+	return errors.Is(err, context.DeadlineExceeded) || strings.HasPrefix(err.Error(), "504 ")
+}
+
+// ListAllDomains 分页获取全部网站的域名 每页条数根据响应速度自动调整
+func (c *Client) ListAllDomains(ctx context.Context) (SiteDomains, error) {
+	var ret SiteDomains
+	err := fetchPages(func(p int64, limit int64) (int, error) {
+		var dec struct {
+			Data SiteDomains `json:"data"`
+		}
+		if err := c.QueryTable("domain").Page(p).Limit(limit).Into(ctx, &dec); err != nil {
+			return 0, err
+		}
+		ret = append(ret, dec.Data...)
+		return len(dec.Data), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
@@ -0,0 +1,57 @@
+package bt
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestFetchPages(t *testing.T) {
+	const total = 1000
+	var limits []int64
+	var got int
+	err := fetchPages(func(p int64, limit int64) (int, error) {
+		limits = append(limits, limit)
+		if limit > 200 {
+			return 0, errors.New("504 Gateway Time-out")
+		}
+		start := int((p - 1) * limit)
+		if start != got {
+			t.Fatalf("page %d limit %d starts at %d, already got %d", p, limit, start, got)
+		}
+		n := total - start
+		if n > int(limit) {
+			n = int(limit)
+		}
+		got += n
+		return n, nil
+	})
+	if err != nil || got != total {
+		t.Fatalf("got %d, %v", got, err)
+	}
+	if limits[0] != 800 || limits[2] != 200 || len(limits) != 2+6 {
+		t.Fatalf("limits %v", limits)
+	}
+	if err := fetchPages(func(int64, int64) (int, error) { return 0, errors.New("boom") }); err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestListAllSites_Backoff(t *testing.T) {
+	c := newFakePanel(t, map[string]http.HandlerFunc{
+		"/data?action=getData": func(w http.ResponseWriter, r *http.Request) {
+			limit, _ := strconv.Atoi(r.FormValue("limit"))
+			if limit > 400 {
+				time.Sleep(200 * time.Millisecond)
+			}
+			_, _ = w.Write([]byte(`{"data":[{"id":1},{"id":2}]}`))
+		},
+	})
+	c.Timeout = 50 * time.Millisecond
+	sites, err := c.ListAllSites(ctx, "")
+	if err != nil || len(sites) != 2 {
+		t.Fatalf("ListAllSites = %v, %v", sites, err)
+	}
+}
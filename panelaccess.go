@@ -0,0 +1,76 @@
+package bt
+
+import (
+	"context"
+	"errors"
+	"strings"
+)
+
+// 面板访问限制的配置文件 面板处理每个请求时读取 修改后立即生效
+const (
+	panelLimitIPFile = "/www/server/panel/data/limitip.conf"
+	panelDomainFile  = "/www/server/panel/data/domain.conf"
+)
+
+// PanelAccess 面板自身的访问限制（面板设置 - 授权 IP 与绑定域名）
+type PanelAccess struct {
+	AllowIPs []string // 授权 IP 或 IP 段 为空时不限制
+	Domain   string   // 绑定域名 为空时可通过 IP 访问
+}
+
+// GetPanelAccess 获取面板的授权 IP 和绑定域名
+func (c *Client) GetPanelAccess(ctx context.Context) (PanelAccess, error) {
+	var ret PanelAccess
+	ips, err := c.GetFile(ctx, panelLimitIPFile)
+	if err != nil {
+		return PanelAccess{}, err
+	}
+	if ips.Status {
+		for _, ip := range strings.Split(ips.Data, ",") {
+			if ip = strings.TrimSpace(ip); ip != "" {
+				ret.AllowIPs = append(ret.AllowIPs, ip)
+			}
+		}
+	}
+	domain, err := c.GetFile(ctx, panelDomainFile)
+	if err != nil {
+		return PanelAccess{}, err
+	}
+	if domain.Status {
+		ret.Domain = strings.TrimSpace(domain.Data)
+	}
+	return ret, nil
+}
+
+// SetPanelAllowIPs 设置面板授权 IP 只有这些 IP 能访问面板 ips 为空时取消限制
+// 设置错误可能导致无法登录面板 请确认包含自己的出口 IP
+// 需要同时封锁多台面板时可配合 Cluster.Each 使用
+func (c *Client) SetPanelAllowIPs(ctx context.Context, ips []string) error {
+	for _, ip := range ips {
+		if !isAddress(ip) {
+			return errors.New("invalid address: " + ip)
+		}
+	}
+	_, err := c.WriteFile(ctx, panelLimitIPFile, strings.Join(ips, ","), true, false)
+	return err
+}
+
+// SetPanelDomain 设置面板绑定域名 之后只能通过该域名访问面板 domain 为空时取消绑定
+func (c *Client) SetPanelDomain(ctx context.Context, domain string) error {
+	domain = strings.TrimSpace(domain)
+	if domain != "" {
+		if err := validateDomain(domain); err != nil {
+			return err
+		}
+	}
+	_, err := c.WriteFile(ctx, panelDomainFile, domain, true, false)
+	return err
+}
+
+// SetPanelAccess 同时设置面板的授权 IP 和绑定域名
+func (c *Client) SetPanelAccess(ctx context.Context, access PanelAccess) error {
+	if err := c.SetPanelAllowIPs(ctx, access.AllowIPs); err != nil {
+		return err
+	}
+	return c.SetPanelDomain(ctx, access.Domain)
+}
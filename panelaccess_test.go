@@ -0,0 +1,37 @@
+package bt
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestPanelAccess(t *testing.T) {
+	files := map[string]string{panelLimitIPFile: "1.2.3.4, 10.0.0.0/8"}
+	c := newFakePanel(t, map[string]http.HandlerFunc{
+		"/files?action=GetFileBody": func(w http.ResponseWriter, r *http.Request) {
+			body, ok := files[r.FormValue("path")]
+			_ = json.NewEncoder(w).Encode(RespGetFile{Status: ok, Data: body})
+		},
+		"/files?action=CreateFile": func(w http.ResponseWriter, r *http.Request) {
+			files[r.FormValue("path")] = ""
+			_, _ = w.Write([]byte(`{"status":true,"msg":"文件创建成功"}`))
+		},
+		"/files?action=SaveFileBody": func(w http.ResponseWriter, r *http.Request) {
+			files[r.FormValue("path")] = r.FormValue("data")
+			_, _ = w.Write([]byte(`{"status":true,"msg":"文件已保存!"}`))
+		},
+	})
+	a, err := c.GetPanelAccess(ctx)
+	if err != nil || len(a.AllowIPs) != 2 || a.AllowIPs[1] != "10.0.0.0/8" || a.Domain != "" {
+		t.Fatalf("GetPanelAccess = %+v, %v", a, err)
+	}
+	if err := c.SetPanelAllowIPs(ctx, []string{"1.2.3.4", "office"}); err == nil {
+		t.Error("SetPanelAllowIPs accepted a hostname")
+	}
+	if err := c.SetPanelAccess(ctx, PanelAccess{AllowIPs: []string{"5.6.7.8"}, Domain: "panel.hao.com"}); err != nil {
+		t.Fatal(err)
+	}
+	if files[panelLimitIPFile] != "5.6.7.8" || files[panelDomainFile] != "panel.hao.com" {
+		t.Fatalf("files %v", files)
+	}
+}
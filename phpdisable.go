@@ -0,0 +1,106 @@
+package bt
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"strings"
+)
+
+var phpFuncName = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// splitDisableFunctions 解析以逗号分隔的 disable_functions
+func splitDisableFunctions(s string) []string {
+	ret := []string{}
+	for _, f := range strings.Split(s, ",") {
+		if f = strings.TrimSpace(f); f != "" {
+			ret = append(ret, f)
+		}
+	}
+	return ret
+}
+
+// GetDisableFunctions 获取 PHP 版本的禁用函数列表 version eg. 74
+func (c *Client) GetDisableFunctions(ctx context.Context, version string) ([]string, error) {
+	if version == "" || version == "00" {
+		return nil, errors.New("invalid php version: " + version)
+	}
+	data := map[string][]string{
+		"version": {version},
+	}
+	resp, err := c.btAPI(ctx, data, "/ajax?action=GetPHPConfig")
+	if err != nil {
+		return nil, err
+	}
+	var dec struct {
+		DisableFunctions string `json:"disable_functions"`
+	}
+	if err := c.unmarshal(ctx, resp, &dec); err != nil {
+		return nil, err
+	}
+	return splitDisableFunctions(dec.DisableFunctions), nil
+}
+
+// SetDisableFunctions 设置 PHP 版本的禁用函数列表 会覆盖原有列表 面板保存后重载该版本的 PHP
+// PHP 配置按版本生效 使用同一版本的所有网站都会受影响
+func (c *Client) SetDisableFunctions(ctx context.Context, version string, funcs []string) (RespMSG, error) {
+	if version == "" || version == "00" {
+		return RespMSG{}, errors.New("invalid php version: " + version)
+	}
+	seen := map[string]bool{}
+	list := []string{}
+	for _, f := range funcs {
+		f = strings.TrimSpace(f)
+		if !phpFuncName.MatchString(f) {
+			return RespMSG{}, errors.New("invalid php function name: " + f)
+		}
+		if !seen[strings.ToLower(f)] {
+			seen[strings.ToLower(f)] = true
+			list = append(list, f)
+		}
+	}
+	data := map[string][]string{
+		"version":           {version},
+		"disable_functions": {strings.Join(list, ",")},
+	}
+	return c.btResult(ctx, data, "/config?action=setPHPDisable")
+}
+
+// AddDisableFunctions 将 funcs 加入 PHP 版本的禁用函数列表 已禁用的函数会被忽略
+func (c *Client) AddDisableFunctions(ctx context.Context, version string, funcs ...string) (RespMSG, error) {
+	list, err := c.GetDisableFunctions(ctx, version)
+	if err != nil {
+		return RespMSG{}, err
+	}
+	return c.SetDisableFunctions(ctx, version, append(list, funcs...))
+}
+
+// RemoveDisableFunctions 从 PHP 版本的禁用函数列表中移除 funcs 比较时忽略大小写
+func (c *Client) RemoveDisableFunctions(ctx context.Context, version string, funcs ...string) (RespMSG, error) {
+	list, err := c.GetDisableFunctions(ctx, version)
+	if err != nil {
+		return RespMSG{}, err
+	}
+	drop := map[string]bool{}
+	for _, f := range funcs {
+		drop[strings.ToLower(strings.TrimSpace(f))] = true
+	}
+	kept := list[:0]
+	for _, f := range list {
+		if !drop[strings.ToLower(f)] {
+			kept = append(kept, f)
+		}
+	}
+	return c.SetDisableFunctions(ctx, version, kept)
+}
+
+// GetSiteDisableFunctions 获取网站所用 PHP 版本及其禁用函数列表 修改时使用返回的 version
+func (c *Client) GetSiteDisableFunctions(ctx context.Context, siteName string) (version string, funcs []string, err error) {
+	if version, err = c.sitePHPVersion(ctx, siteName); err != nil {
+		return "", nil, err
+	}
+	if funcs, err = c.GetDisableFunctions(ctx, version); err != nil {
+		return "", nil, err
+	}
+	return version, funcs, nil
+}
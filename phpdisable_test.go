@@ -0,0 +1,39 @@
+package bt
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestDisableFunctions(t *testing.T) {
+	disabled := "passthru,exec,system"
+	c := newFakePanel(t, map[string]http.HandlerFunc{
+		"/site?action=GetSitePHPVersion": reply(`{"phpversion":"74"}`),
+		"/ajax?action=GetPHPConfig": func(w http.ResponseWriter, r *http.Request) {
+			_ = json.NewEncoder(w).Encode(map[string]string{"disable_functions": disabled})
+		},
+		"/config?action=setPHPDisable": func(w http.ResponseWriter, r *http.Request) {
+			if r.FormValue("version") != "74" {
+				t.Errorf("version %s", r.FormValue("version"))
+			}
+			disabled = r.FormValue("disable_functions")
+			_, _ = w.Write([]byte(`{"status":true,"msg":"设置成功!"}`))
+		},
+	})
+	version, funcs, err := c.GetSiteDisableFunctions(ctx, "w1.hao.com")
+	if err != nil || version != "74" || len(funcs) != 3 {
+		t.Fatalf("GetSiteDisableFunctions = %s %v %v", version, funcs, err)
+	}
+	if _, err := c.AddDisableFunctions(ctx, version, "shell_exec", "EXEC"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.RemoveDisableFunctions(ctx, version, "System"); err != nil {
+		t.Fatal(err)
+	}
+	if disabled != "passthru,exec,shell_exec" {
+		t.Fatalf("disabled = %q", disabled)
+	}
+	if _, err := c.AddDisableFunctions(ctx, version, "exec;rm"); err == nil {
+		t.Error("invalid function name accepted")
+	}
+}
@@ -0,0 +1,57 @@
+package bt
+
+import (
+	"context"
+	"errors"
+	"strings"
+)
+
+// SitePHPVersion 网站当前使用的 PHP 版本及可切换的版本
+type SitePHPVersion struct {
+	Version   string      // eg. 74 纯静态为 00
+	Available PHPVersions // 已安装的 PHP 版本
+}
+
+// sitePHPVersion 获取网站使用的 PHP 版本
+func (c *Client) sitePHPVersion(ctx context.Context, siteName string) (string, error) {
+	data := map[string][]string{
+		"siteName": {siteName},
+	}
+	resp, err := c.btAPI(ctx, data, "/site?action=GetSitePHPVersion")
+	if err != nil {
+		return "", err
+	}
+	var dec struct {
+		PHPVersion string `json:"phpversion"`
+	}
+	if err := c.unmarshal(ctx, resp, &dec); err != nil {
+		return "", err
+	}
+	return dec.PHPVersion, nil
+}
+
+// GetSitePHPVersion 获取网站当前使用的 PHP 版本及已安装的 PHP 版本
+func (c *Client) GetSitePHPVersion(ctx context.Context, siteName string) (SitePHPVersion, error) {
+	version, err := c.sitePHPVersion(ctx, siteName)
+	if err != nil {
+		return SitePHPVersion{}, err
+	}
+	available, err := c.GetPHPVersion(ctx)
+	if err != nil {
+		return SitePHPVersion{}, err
+	}
+	return SitePHPVersion{Version: normalizePHPVersion(version), Available: available}, nil
+}
+
+// SetSitePHPVersion 切换网站使用的 PHP 版本 version eg. 74 或 7.4 纯静态为 00 需为已安装的版本
+func (c *Client) SetSitePHPVersion(ctx context.Context, siteName string, version string) (RespMSG, error) {
+	version = normalizePHPVersion(version)
+	if version == "" || strings.Trim(version, "0123456789") != "" {
+		return RespMSG{}, errors.New("invalid php version: " + version)
+	}
+	data := map[string][]string{
+		"siteName": {siteName},
+		"version":  {version},
+	}
+	return c.btResult(ctx, data, "/site?action=SetPHPVersion")
+}
@@ -0,0 +1,28 @@
+package bt
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestSitePHPVersion(t *testing.T) {
+	var set string
+	c := newFakePanel(t, map[string]http.HandlerFunc{
+		"/site?action=GetSitePHPVersion": reply(`{"phpversion":"7.4"}`),
+		"/site?action=GetPHPVersion":     reply(`[{"version":"00","name":"纯静态"},{"version":"74","name":"PHP-74"},{"version":"82","name":"PHP-82"}]`),
+		"/site?action=SetPHPVersion": func(w http.ResponseWriter, r *http.Request) {
+			set = r.FormValue("siteName") + " " + r.FormValue("version")
+			_, _ = w.Write([]byte(`{"status":true,"msg":"切换成功"}`))
+		},
+	})
+	v, err := c.GetSitePHPVersion(ctx, "w1.hao.com")
+	if err != nil || v.Version != "74" || len(v.Available) != 3 {
+		t.Fatalf("GetSitePHPVersion = %+v, %v", v, err)
+	}
+	if _, err := c.SetSitePHPVersion(ctx, "w1.hao.com", "8.2"); err != nil || set != "w1.hao.com 82" {
+		t.Fatalf("SetSitePHPVersion set %q, %v", set, err)
+	}
+	if _, err := c.SetSitePHPVersion(ctx, "w1.hao.com", "latest"); err == nil {
+		t.Error("invalid version accepted")
+	}
+}
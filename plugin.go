@@ -0,0 +1,65 @@
+package bt
+
+import (
+	"context"
+	"errors"
+	"net/url"
+)
+
+// 插件通用读写方法名 大多数插件遵循该约定 不一致的可通过 method 参数指定
+const (
+	PluginGetConfig = "get_config"
+	PluginSetConfig = "set_config"
+)
+
+// PluginCall 调用插件的任意方法 返回原始结果
+// URI 地址：/plugin?action=a&name=<plugin>&s=<method>
+func (c *Client) PluginCall(ctx context.Context, plugin string, method string, args map[string]string) ([]byte, error) {
+	if plugin == "" || method == "" {
+		return nil, errors.New("plugin and method are required")
+	}
+	data := map[string][]string{}
+	for k, v := range args {
+		data[k] = []string{v}
+	}
+	return c.btAPI(ctx, data, pluginEndpoint(plugin, method))
+}
+
+func pluginEndpoint(plugin string, method string) string {
+	return "/plugin?action=a&name=" + url.QueryEscape(plugin) + "&s=" + url.QueryEscape(method)
+}
+
+// pluginResult 调用插件的变更类方法并解析返回的通用消息 见 decodeResult
+func (c *Client) pluginResult(ctx context.Context, plugin string, method string, args map[string]string) (RespMSG, error) {
+	resp, err := c.PluginCall(ctx, plugin, method, args)
+	if err != nil {
+		return RespMSG{}, err
+	}
+	return c.decodeResult(resp, pluginEndpoint(plugin, method))
+}
+
+// GetPluginConfig 读取插件配置 默认调用插件的 get_config 方法
+func (c *Client) GetPluginConfig(ctx context.Context, plugin string, method ...string) (map[string]interface{}, error) {
+	s := PluginGetConfig
+	if len(method) > 0 && method[0] != "" {
+		s = method[0]
+	}
+	resp, err := c.PluginCall(ctx, plugin, s, nil)
+	if err != nil {
+		return nil, err
+	}
+	var dec map[string]interface{}
+	if err := c.unmarshal(ctx, resp, &dec); err != nil {
+		return nil, err
+	}
+	return dec, nil
+}
+
+// SetPluginConfig 修改插件配置 默认调用插件的 set_config 方法 kv 原样作为表单参数提交
+func (c *Client) SetPluginConfig(ctx context.Context, plugin string, kv map[string]string, method ...string) (RespMSG, error) {
+	s := PluginSetConfig
+	if len(method) > 0 && method[0] != "" {
+		s = method[0]
+	}
+	return c.pluginResult(ctx, plugin, s, kv)
+}
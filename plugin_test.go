@@ -0,0 +1,22 @@
+package bt
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestSetPluginConfig(t *testing.T) {
+	c := newFakePanel(t, map[string]http.HandlerFunc{
+		"/plugin?action=a": func(w http.ResponseWriter, r *http.Request) {
+			q := r.URL.Query()
+			if q.Get("name") != "btwaf" || q.Get("s") != "set_open" || r.FormValue("open") != "1" {
+				t.Errorf("unexpected request %s %v", r.URL, r.Form)
+			}
+			_, _ = w.Write([]byte(`{"status":true,"msg":"设置成功"}`))
+		},
+	})
+	r, err := c.SetPluginConfig(ctx, "btwaf", map[string]string{"open": "1"}, "set_open")
+	if err != nil || !r.Status {
+		t.Fatalf("SetPluginConfig = %+v, %v", r, err)
+	}
+}
@@ -0,0 +1,83 @@
+package bt
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// PortStatus 端口可用性检查结果
+type PortStatus struct {
+	Port      int64
+	Allowed   bool   // 面板防火墙已放行
+	Listening bool   // 已有进程监听
+	Process   string // 监听该端口的进程名
+}
+
+// Available 端口未被占用 可以新建网站或转发
+func (s PortStatus) Available() bool {
+	return !s.Listening
+}
+
+// GetNetWorkList 获取当前网络连接列表
+func (c *Client) GetNetWorkList(ctx context.Context) (NetWorkList, error) {
+	resp, err := c.btAPI(ctx, map[string][]string{}, "/ajax?action=GetNetWorkList")
+	if err != nil {
+		return NetWorkList{}, err
+	}
+	var dec NetWorkList
+	if err := c.unmarshal(ctx, resp, &dec); err != nil {
+		return NetWorkList{}, err
+	}
+	return dec, nil
+}
+
+// CheckPort 检查端口是否已在面板防火墙放行以及是否已有进程监听
+func (c *Client) CheckPort(ctx context.Context, port int64) (PortStatus, error) {
+	if port <= 0 || port > 65535 {
+		return PortStatus{}, errors.New("invalid port: " + strconv.FormatInt(port, 10))
+	}
+	ret := PortStatus{Port: port}
+	rules, err := c.GetFirewallRules(ctx)
+	if err != nil {
+		return PortStatus{}, err
+	}
+	for _, r := range rules {
+		if portInRule(port, r.Port) {
+			ret.Allowed = true
+			break
+		}
+	}
+	conns, err := c.GetNetWorkList(ctx)
+	if err != nil {
+		return PortStatus{}, err
+	}
+	want := strconv.FormatInt(port, 10)
+	for _, conn := range conns {
+		if len(conn.Laddr) < 2 || fmt.Sprint(conn.Laddr[1]) != want {
+			continue
+		}
+		// UDP 没有 LISTEN 状态 只要绑定即视为占用
+		if conn.Status == "LISTEN" || strings.HasPrefix(conn.Type, "udp") {
+			ret.Listening = true
+			ret.Process = conn.Process
+			break
+		}
+	}
+	return ret, nil
+}
+
+// portInRule 判断端口是否命中防火墙规则 规则可为单个端口、范围（8000-9000 或 8000:9000）
+func portInRule(port int64, rule string) bool {
+	rule = strings.TrimSpace(rule)
+	sep := strings.IndexAny(rule, "-:")
+	if sep < 0 {
+		p, err := strconv.ParseInt(rule, 10, 64)
+		return err == nil && p == port
+	}
+	from, err1 := strconv.ParseInt(rule[:sep], 10, 64)
+	to, err2 := strconv.ParseInt(rule[sep+1:], 10, 64)
+	return err1 == nil && err2 == nil && port >= from && port <= to
+}
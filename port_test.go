@@ -0,0 +1,26 @@
+package bt
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestPortInRule(t *testing.T) {
+	cases := map[string]bool{"8080": true, "8000-9000": true, "8000:9000": true, "80": false, "10.0.0.1": false, "9000-9999": false}
+	for rule, want := range cases {
+		if got := portInRule(8080, rule); got != want {
+			t.Errorf("portInRule(8080, %q) = %v", rule, got)
+		}
+	}
+}
+
+func TestCheckPort(t *testing.T) {
+	c := newFakePanel(t, map[string]http.HandlerFunc{
+		"/data?action=getData":        reply(`{"data":[{"id":1,"port":"8000-9000"}]}`),
+		"/ajax?action=GetNetWorkList": reply(`[{"process":"nginx","type":"tcp","laddr":["0.0.0.0",8080],"status":"LISTEN"}]`),
+	})
+	r, err := c.CheckPort(ctx, 8080)
+	if err != nil || !r.Allowed || !r.Listening || r.Process != "nginx" || r.Available() {
+		t.Fatalf("CheckPort = %+v, %v", r, err)
+	}
+}
@@ -0,0 +1,55 @@
+package bt
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// DecodeBare 解析面板返回的裸值响应（如 true、1、"ok"、123）到 v
+// v 支持 *bool *int *int64 *float64 *string 可用于 Raw 返回的内容
+// 数字和布尔值允许被 JSON 引号包裹 布尔值同时接受 1/0
+func DecodeBare(body []byte, v interface{}) error {
+	s := strings.TrimSpace(string(body))
+	if len(s) >= 2 && s[0] == '"' && s[len(s)-1] == '"' {
+		unq, err := strconv.Unquote(s)
+		if err != nil {
+			return err
+		}
+		s = unq
+	}
+	switch p := v.(type) {
+	case *string:
+		*p = s
+	case *bool:
+		switch strings.ToLower(s) {
+		case "true", "1":
+			*p = true
+		case "false", "0", "":
+			*p = false
+		default:
+			return errors.New("bare response is not a boolean: " + s)
+		}
+	case *int:
+		n, err := strconv.Atoi(s)
+		if err != nil {
+			return err
+		}
+		*p = n
+	case *int64:
+		n, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return err
+		}
+		*p = n
+	case *float64:
+		n, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return err
+		}
+		*p = n
+	default:
+		return errors.New("DecodeBare: unsupported type")
+	}
+	return nil
+}
@@ -0,0 +1,31 @@
+package bt
+
+import "testing"
+
+func TestDecodeBare(t *testing.T) {
+	var n int
+	if err := DecodeBare([]byte(" 3\n"), &n); err != nil || n != 3 {
+		t.Errorf("int = %d, %v", n, err)
+	}
+	var i64 int64
+	if err := DecodeBare([]byte(`"42"`), &i64); err != nil || i64 != 42 {
+		t.Errorf("int64 = %d, %v", i64, err)
+	}
+	for body, want := range map[string]bool{"true": true, "1": true, "false": false, `"0"`: false} {
+		var b bool
+		if err := DecodeBare([]byte(body), &b); err != nil || b != want {
+			t.Errorf("bool(%s) = %v, %v", body, b, err)
+		}
+	}
+	var s string
+	if err := DecodeBare([]byte(`"a\"b"`), &s); err != nil || s != `a"b` {
+		t.Errorf("string = %q, %v", s, err)
+	}
+	var b bool
+	if err := DecodeBare([]byte("yes"), &b); err == nil {
+		t.Error("expected error")
+	}
+	if err := DecodeBare([]byte("1"), &struct{}{}); err == nil {
+		t.Error("expected error")
+	}
+}
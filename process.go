@@ -0,0 +1,42 @@
+package bt
+
+import (
+	"context"
+	"sort"
+)
+
+// 任务管理器插件名
+const taskManagerPlugin = "task_manager"
+
+// GetProcessList 获取进程列表（需安装任务管理器插件）
+func (c *Client) GetProcessList(ctx context.Context) ([]ProcessInfo, error) {
+	resp, err := c.PluginCall(ctx, taskManagerPlugin, "get_process_list", map[string]string{
+		"sortx": "cpu_percent",
+	})
+	if err != nil {
+		return nil, err
+	}
+	var dec []ProcessInfo
+	if err := c.unmarshal(ctx, resp, &dec); err != nil {
+		return nil, err
+	}
+	return dec, nil
+}
+
+// GetTopProcesses 获取 CPU（byMemory 为 true 时为内存）占用最高的 n 个进程 便于故障时快速留存现场
+func (c *Client) GetTopProcesses(ctx context.Context, n int, byMemory bool) ([]ProcessInfo, error) {
+	list, err := c.GetProcessList(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(list, func(i, j int) bool {
+		if byMemory {
+			return list[i].MemoryUsed > list[j].MemoryUsed
+		}
+		return list[i].CPUPercent > list[j].CPUPercent
+	})
+	if n >= 0 && len(list) > n {
+		list = list[:n]
+	}
+	return list, nil
+}
@@ -0,0 +1,24 @@
+package bt
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestGetTopProcesses(t *testing.T) {
+	c := newFakePanel(t, map[string]http.HandlerFunc{
+		"/plugin?action=a": reply(`[
+			{"pid":1,"name":"systemd","cpu_percent":0.1,"memory_used":100},
+			{"pid":2,"name":"mysqld","cpu_percent":3.5,"memory_used":900},
+			{"pid":3,"name":"php-fpm","cpu_percent":12.0,"memory_used":300}
+		]`),
+	})
+	r, err := c.GetTopProcesses(ctx, 2, false)
+	if err != nil || len(r) != 2 || r[0].Name != "php-fpm" || r[1].Name != "mysqld" {
+		t.Fatalf("by cpu = %+v, %v", r, err)
+	}
+	r, err = c.GetTopProcesses(ctx, 1, true)
+	if err != nil || len(r) != 1 || r[0].Name != "mysqld" {
+		t.Fatalf("by memory = %+v, %v", r, err)
+	}
+}
@@ -0,0 +1,110 @@
+package bt
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// boolFlag 将 bool 转为面板使用的 1/0
+func boolFlag(b bool) string {
+	if b {
+		return "1"
+	}
+	return "0"
+}
+
+// GetProxyList 获取网站的反向代理列表
+func (c *Client) GetProxyList(ctx context.Context, siteName string) ([]ProxyInfo, error) {
+	data := map[string][]string{
+		"sitename": {siteName},
+	}
+	resp, err := c.btAPI(ctx, data, "/site?action=GetProxyList")
+	if err != nil {
+		return nil, err
+	}
+	var dec []ProxyInfo
+	if err := c.unmarshal(ctx, resp, &dec); err != nil {
+		return nil, err
+	}
+	return dec, nil
+}
+
+// CreateProxy 为网站添加反向代理
+func (c *Client) CreateProxy(ctx context.Context, params *ReqProxy) (RespMSG, error) {
+	data, err := params.form()
+	if err != nil {
+		return RespMSG{}, err
+	}
+	return c.btResult(ctx, data, "/site?action=CreateProxy")
+}
+
+// ModifyProxy 修改网站的反向代理 按 SiteName 和 ProxyName 定位 未设置的字段会被重置为默认值
+func (c *Client) ModifyProxy(ctx context.Context, params *ReqProxy) (RespMSG, error) {
+	data, err := params.form()
+	if err != nil {
+		return RespMSG{}, err
+	}
+	return c.btResult(ctx, data, "/site?action=ModifyProxy")
+}
+
+// RemoveProxy 删除网站的反向代理
+func (c *Client) RemoveProxy(ctx context.Context, siteName, proxyName string) (RespMSG, error) {
+	data := map[string][]string{
+		"sitename":  {siteName},
+		"proxyname": {proxyName},
+	}
+	return c.btResult(ctx, data, "/site?action=RemoveProxy")
+}
+
+// form 构造 CreateProxy/ModifyProxy 的请求参数
+func (params *ReqProxy) form() (map[string][]string, error) {
+	if params.SiteName == "" {
+		return nil, errors.New("proxy: site name is required")
+	}
+	if len(params.ProxyName) < 3 {
+		return nil, errors.New("proxy: name must be at least 3 characters")
+	}
+	if !strings.HasPrefix(params.ProxySite, "http://") && !strings.HasPrefix(params.ProxySite, "https://") {
+		return nil, errors.New("proxy: target must be an http(s) URL")
+	}
+	if len(params.SubFilter) > 3 {
+		return nil, errors.New("proxy: at most 3 sub filters")
+	}
+	dir := params.ProxyDir
+	if dir == "" {
+		dir = "/"
+	}
+	if !strings.HasPrefix(dir, "/") {
+		return nil, errors.New("proxy: dir must start with /")
+	}
+	toDomain := params.ToDomain
+	if toDomain == "" {
+		toDomain = "$host"
+	}
+	cacheTime := params.CacheTime
+	if cacheTime <= 0 {
+		cacheTime = 1
+	}
+	subFilter := params.SubFilter
+	if subFilter == nil {
+		subFilter = []ProxySubFilter{}
+	}
+	sub, err := json.Marshal(subFilter)
+	if err != nil {
+		return nil, err
+	}
+	return map[string][]string{
+		"sitename":  {params.SiteName},
+		"proxyname": {params.ProxyName},
+		"proxysite": {params.ProxySite},
+		"todomain":  {toDomain},
+		"proxydir":  {dir},
+		"type":      {boolFlag(params.Enabled)},
+		"cache":     {boolFlag(params.Cache)},
+		"cachetime": {strconv.FormatInt(cacheTime, 10)},
+		"advanced":  {boolFlag(params.Advanced)},
+		"subfilter": {string(sub)},
+	}, nil
+}
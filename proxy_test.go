@@ -0,0 +1,43 @@
+package bt
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+)
+
+func TestProxy(t *testing.T) {
+	var form url.Values
+	c := newFakePanel(t, map[string]http.HandlerFunc{
+		"/site?action=GetProxyList": reply(`[{"proxyname":"api","sitename":"w1.hao.com","proxydir":"/","proxysite":"http://127.0.0.1:8080","todomain":"$host","type":1,"cache":0,"cachetime":1,"advanced":0,"subfilter":[{"sub1":"a","sub2":"b"}]}]`),
+		"/site?action=CreateProxy": func(w http.ResponseWriter, r *http.Request) {
+			_ = r.ParseForm()
+			form = r.PostForm
+			_, _ = w.Write([]byte(`{"status":true,"msg":"添加成功"}`))
+		},
+	})
+	list, err := c.GetProxyList(ctx, "w1.hao.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list) != 1 || list[0].ProxySite != "http://127.0.0.1:8080" || list[0].SubFilter[0].To != "b" {
+		t.Fatalf("list %+v", list)
+	}
+	_, err = c.CreateProxy(ctx, &ReqProxy{
+		SiteName:  "w1.hao.com",
+		ProxyName: "api",
+		ProxySite: "http://127.0.0.1:8080",
+		Enabled:   true,
+		SubFilter: []ProxySubFilter{{From: "a", To: "b"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if form.Get("proxydir") != "/" || form.Get("todomain") != "$host" || form.Get("type") != "1" || form.Get("cache") != "0" ||
+		form.Get("subfilter") != `[{"sub1":"a","sub2":"b"}]` {
+		t.Fatalf("form %v", form)
+	}
+	if _, err := c.CreateProxy(ctx, &ReqProxy{SiteName: "w1.hao.com", ProxyName: "api", ProxySite: "127.0.0.1:8080"}); err == nil {
+		t.Error("CreateProxy accepted a target without scheme")
+	}
+}
@@ -0,0 +1,200 @@
+package bt
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Operation 排队执行的变更类调用 仅包含可序列化字段以便持久化
+type Operation struct {
+	ID        string              `json:"id"`
+	Endpoint  string              `json:"endpoint"`
+	Params    map[string][]string `json:"params"`
+	Attempts  int                 `json:"attempts"`   // 已尝试次数
+	NextRun   time.Time           `json:"next_run"`   // 下次可执行时间
+	LastError string              `json:"last_error"` // 最近一次失败原因
+	Dead      bool                `json:"dead"`       // 超过最大尝试次数后不再执行
+	Created   time.Time           `json:"created"`
+}
+
+// QueueStore 操作队列存储 默认提供内存实现 需要跨进程存活时可基于文件/数据库自行实现
+type QueueStore interface {
+	Push(op Operation) error
+	// Due 返回 NextRun 不晚于 now 且未标记 Dead 的操作 按创建时间排序
+	Due(now time.Time) ([]Operation, error)
+	Update(op Operation) error
+	Remove(id string) error
+}
+
+// MemoryQueueStore 基于内存的 QueueStore 并发安全
+type MemoryQueueStore struct {
+	mu  sync.Mutex
+	ops map[string]Operation
+}
+
+// NewMemoryQueueStore 实例化内存操作队列存储
+func NewMemoryQueueStore() *MemoryQueueStore {
+	return &MemoryQueueStore{ops: map[string]Operation{}}
+}
+
+// Push 实现 QueueStore
+func (s *MemoryQueueStore) Push(op Operation) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.ops[op.ID] = op
+	return nil
+}
+
+// Due 实现 QueueStore
+func (s *MemoryQueueStore) Due(now time.Time) ([]Operation, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var ret []Operation
+	for _, op := range s.ops {
+		if !op.Dead && !op.NextRun.After(now) {
+			ret = append(ret, op)
+		}
+	}
+	sort.Slice(ret, func(i, j int) bool { return ret[i].Created.Before(ret[j].Created) })
+	return ret, nil
+}
+
+// Update 实现 QueueStore
+func (s *MemoryQueueStore) Update(op Operation) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.ops[op.ID]; !ok {
+		return errors.New("operation not found: " + op.ID)
+	}
+	s.ops[op.ID] = op
+	return nil
+}
+
+// Remove 实现 QueueStore
+func (s *MemoryQueueStore) Remove(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.ops, id)
+	return nil
+}
+
+// Queue 变更操作队列 入队后由 Run 在后台执行 遇到网络错误按退避策略重试
+// 面板已正常返回（包括 status 为 false）的操作视为已完成 不会重试
+type Queue struct {
+	Client       *Client
+	Store        QueueStore
+	MaxAttempts  int                                        // 默认 5
+	Backoff      func(attempt int) time.Duration            // 第 attempt 次失败后的等待时间 默认 1s 起指数增长 最长 5 分钟
+	PollInterval time.Duration                              // 默认 1s
+	OnResult     func(op Operation, resp []byte, err error) // 可选 操作完成或被放弃时回调
+}
+
+// NewQueue 实例化操作队列 store 为空时使用内存存储
+func NewQueue(c *Client, store QueueStore) *Queue {
+	if store == nil {
+		store = NewMemoryQueueStore()
+	}
+	return &Queue{
+		Client: c,
+		Store:  store,
+	}
+}
+
+// Enqueue 将一次调用加入队列 返回操作 ID
+func (q *Queue) Enqueue(endpoint string, data map[string][]string) (string, error) {
+	now := time.Now()
+	op := Operation{
+		ID:       q.Client.newRequestID(),
+		Endpoint: endpoint,
+		Params:   data,
+		NextRun:  now,
+		Created:  now,
+	}
+	return op.ID, q.Store.Push(op)
+}
+
+// Run 持续执行队列中的操作 直到 ctx 结束或 Client 被 Close
+func (q *Queue) Run(ctx context.Context) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	defer q.Client.onClose(cancel)()
+	interval := q.PollInterval
+	if interval <= 0 {
+		interval = time.Second
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if _, err := q.RunOnce(ctx); err != nil {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			if q.Client.isClosed() {
+				return ErrClientClosed
+			}
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce 执行当前所有到期的操作 返回执行的数量
+func (q *Queue) RunOnce(ctx context.Context) (int, error) {
+	ops, err := q.Store.Due(time.Now())
+	if err != nil {
+		return 0, err
+	}
+	n := 0
+	for _, op := range ops {
+		if ctx.Err() != nil {
+			return n, nil
+		}
+		n++
+		resp, err := q.Client.btAPI(ctx, op.Params, op.Endpoint)
+		if err == nil {
+			if err := q.Store.Remove(op.ID); err != nil {
+				return n, err
+			}
+			q.report(op, resp, nil)
+			continue
+		}
+		op.Attempts++
+		op.LastError = err.Error()
+		op.NextRun = time.Now().Add(q.backoff(op.Attempts))
+		maxAttempts := q.MaxAttempts
+		if maxAttempts <= 0 {
+			maxAttempts = 5
+		}
+		if op.Attempts >= maxAttempts {
+			op.Dead = true
+		}
+		if err := q.Store.Update(op); err != nil {
+			return n, err
+		}
+		if op.Dead {
+			q.report(op, nil, err)
+		}
+	}
+	return n, nil
+}
+
+func (q *Queue) backoff(attempt int) time.Duration {
+	if q.Backoff != nil {
+		return q.Backoff(attempt)
+	}
+	d := time.Second << uint(attempt-1)
+	if d <= 0 || d > 5*time.Minute {
+		d = 5 * time.Minute
+	}
+	return d
+}
+
+func (q *Queue) report(op Operation, resp []byte, err error) {
+	if q.OnResult != nil {
+		q.OnResult(op, resp, err)
+	}
+}
@@ -0,0 +1,88 @@
+package bt
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestQueue_RetryUntilSuccess(t *testing.T) {
+	calls := 0
+	c := newFakePanel(t, map[string]http.HandlerFunc{
+		"/site?action=SiteStop": func(w http.ResponseWriter, r *http.Request) {
+			calls++
+			if calls < 3 {
+				http.Error(w, "panel restarting", http.StatusBadGateway)
+				return
+			}
+			_, _ = w.Write([]byte(`{"status":true,"msg":"站点已停用"}`))
+		},
+	})
+	q := NewQueue(c, nil)
+	q.Backoff = func(int) time.Duration { return 0 }
+	var done []byte
+	q.OnResult = func(op Operation, resp []byte, err error) { done = resp }
+	if _, err := q.Enqueue("/site?action=SiteStop", map[string][]string{"id": {"1"}}); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 3; i++ {
+		if _, err := q.RunOnce(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if calls != 3 || string(done) != `{"status":true,"msg":"站点已停用"}` {
+		t.Fatalf("calls %d done %q", calls, done)
+	}
+	if ops, _ := q.Store.Due(time.Now()); len(ops) != 0 {
+		t.Fatalf("queue not drained: %+v", ops)
+	}
+}
+
+func TestQueue_GiveUp(t *testing.T) {
+	c := newFakePanel(t, map[string]http.HandlerFunc{
+		"/site?action=SiteStop": func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, "down", http.StatusBadGateway)
+		},
+	})
+	q := NewQueue(c, nil)
+	q.MaxAttempts = 2
+	q.Backoff = func(int) time.Duration { return 0 }
+	var failed error
+	q.OnResult = func(op Operation, resp []byte, err error) { failed = err }
+	_, _ = q.Enqueue("/site?action=SiteStop", nil)
+	for i := 0; i < 3; i++ {
+		_, _ = q.RunOnce(context.Background())
+	}
+	if failed == nil {
+		t.Fatal("expected failure report")
+	}
+}
+
+func TestQueue_StopOnClose(t *testing.T) {
+	c := newFakePanel(t, nil)
+	q := NewQueue(c, nil)
+	q.PollInterval = time.Hour
+	done := make(chan error, 1)
+	go func() { done <- q.Run(context.Background()) }()
+	// 等待 Run 注册清理函数
+	for {
+		c.mu.Lock()
+		n := len(c.closers)
+		c.mu.Unlock()
+		if n > 0 {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+	_ = c.Close()
+	select {
+	case err := <-done:
+		if !errors.Is(err, ErrClientClosed) {
+			t.Fatalf("Run = %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Run still running after Close")
+	}
+}
@@ -0,0 +1,83 @@
+package bt
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// limitRateDirective nginx 配置中的 limit_rate 指令 单位为空时为字节
+var limitRateDirective = regexp.MustCompile(`(?m)^[ \t]*limit_rate[ \t]+(\d+)([kKmM]?)[ \t]*;[ \t]*\n?`)
+
+// 面板流量限制配置段的标记 SetLimitNet/CloseLimitNet 也使用此段
+const (
+	limitInfoStart = "#LIMIT_INFO_START"
+	limitInfoEnd   = "#LIMIT_INFO_END"
+)
+
+// SetSiteLimitRate 只设置网站每个连接的下载速度上限 单位 KB/s 为 0 时取消限速 返回配置文件变更的 diff（仅支持 nginx）
+// 写入面板的流量限制配置段 已开启并发限制时只替换其中的 limit_rate 不影响 perserver/perip
+func (c *Client) SetSiteLimitRate(ctx context.Context, siteName string, kbps int64) (string, error) {
+	if kbps < 0 {
+		return "", errors.New("limit rate must not be negative")
+	}
+	return c.SafeEditConfig(ctx, NginxVhostPath(siteName), func(conf string) (string, error) {
+		return setLimitRate(conf, kbps), nil
+	}, nil)
+}
+
+// GetSiteLimitRate 获取网站生效的下载速度上限 单位 KB/s 未限速时为 0（仅支持 nginx）
+func (c *Client) GetSiteLimitRate(ctx context.Context, siteName string) (int64, error) {
+	conf, err := c.readFile(ctx, NginxVhostPath(siteName))
+	if err != nil {
+		return 0, err
+	}
+	m := limitRateDirective.FindStringSubmatch(conf)
+	if m == nil {
+		return 0, nil
+	}
+	n, _ := strconv.ParseInt(m[1], 10, 64)
+	switch strings.ToLower(m[2]) {
+	case "m":
+		return n * 1024, nil
+	case "k":
+		return n, nil
+	}
+	return n / 1024, nil
+}
+
+// setLimitRate 替换面板流量限制配置段中的 limit_rate 配置段不存在时在 #SSL-END 后新建
+// 配置段中不再有其他指令时整段删除
+func setLimitRate(conf string, kbps int64) string {
+	i := strings.Index(conf, limitInfoStart)
+	j := strings.Index(conf, limitInfoEnd)
+	if i < 0 || j < i {
+		if kbps == 0 {
+			return conf
+		}
+		block := "    " + limitInfoStart + "\n    limit_rate " + strconv.FormatInt(kbps, 10) + "k;\n    " + limitInfoEnd + "\n"
+		at := strings.Index(conf, "#SSL-END")
+		if at >= 0 {
+			at += strings.Index(conf[at:], "\n") + 1
+		} else if at = strings.LastIndex(conf, "}"); at >= 0 {
+			at = strings.LastIndex(conf[:at], "\n") + 1
+		} else {
+			at = len(conf)
+		}
+		return conf[:at] + block + conf[at:]
+	}
+	body := limitRateDirective.ReplaceAllString(conf[i:j], "")
+	if kbps > 0 {
+		body += "limit_rate " + strconv.FormatInt(kbps, 10) + "k;\n    "
+	} else if strings.TrimSpace(strings.TrimPrefix(body, limitInfoStart)) == "" {
+		lineStart := strings.LastIndex(conf[:i], "\n") + 1
+		stop := j + len(limitInfoEnd)
+		if stop < len(conf) && conf[stop] == '\n' {
+			stop++
+		}
+		return conf[:lineStart] + conf[stop:]
+	}
+	return conf[:i] + body + conf[j:]
+}
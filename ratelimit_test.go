@@ -0,0 +1,39 @@
+package bt
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestSetLimitRate(t *testing.T) {
+	conf := "server {\n    #SSL-END\n    #ERROR-PAGE-START\n}\n"
+	got := setLimitRate(conf, 512)
+	want := "server {\n    #SSL-END\n    #LIMIT_INFO_START\n    limit_rate 512k;\n    #LIMIT_INFO_END\n    #ERROR-PAGE-START\n}\n"
+	if got != want {
+		t.Fatalf("got\n%s", got)
+	}
+	if back := setLimitRate(got, 0); back != conf {
+		t.Fatalf("got\n%s", back)
+	}
+
+	full := "server {\n    #LIMIT_INFO_START\n    limit_conn perserver 300;\n    limit_conn perip 25;\n    limit_rate 512k;\n    #LIMIT_INFO_END\n}\n"
+	got = setLimitRate(full, 1024)
+	want = "server {\n    #LIMIT_INFO_START\n    limit_conn perserver 300;\n    limit_conn perip 25;\n    limit_rate 1024k;\n    #LIMIT_INFO_END\n}\n"
+	if got != want {
+		t.Fatalf("got\n%s", got)
+	}
+	got = setLimitRate(full, 0)
+	want = "server {\n    #LIMIT_INFO_START\n    limit_conn perserver 300;\n    limit_conn perip 25;\n    #LIMIT_INFO_END\n}\n"
+	if got != want {
+		t.Fatalf("got\n%s", got)
+	}
+}
+
+func TestGetSiteLimitRate(t *testing.T) {
+	c := newFakePanel(t, map[string]http.HandlerFunc{
+		"/files?action=GetFileBody": reply(`{"status":true,"data":"server {\n    limit_rate 2m;\n}\n"}`),
+	})
+	if n, err := c.GetSiteLimitRate(ctx, "a.com"); err != nil || n != 2048 {
+		t.Fatalf("GetSiteLimitRate = %d, %v", n, err)
+	}
+}
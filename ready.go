@@ -0,0 +1,46 @@
+package bt
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// 等待面板就绪时的重试间隔 从 readyMinDelay 开始翻倍 最长 readyMaxDelay
+const (
+	readyMinDelay = 500 * time.Millisecond
+	readyMaxDelay = 10 * time.Second
+)
+
+// AwaitPanelReady 在面板重启或升级后等待其恢复响应 以指数退避轮询 GetTaskCount 直到成功或 ctx 结束
+// 连接失败、HTTP 错误及非 JSON 响应（eg. 反向代理的错误页面）视为尚未就绪
+// 面板已响应但返回 status 为 false（eg. IP 不在白名单）时重试无意义 直接返回该错误
+// ctx 结束时返回的错误同时包含 ctx 的错误和最后一次请求的错误
+func (c *Client) AwaitPanelReady(ctx context.Context) error {
+	delay := readyMinDelay
+	for {
+		_, err := c.GetTaskCount(ctx)
+		if err == nil {
+			return nil
+		}
+		var apiErr *APIError
+		if errors.As(err, &apiErr) && apiErr.HTTPStatus == http.StatusOK {
+			return err
+		}
+		if errors.Is(err, ErrClientClosed) {
+			return err
+		}
+		t := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return fmt.Errorf("panel not ready: %w (last error: %v)", ctx.Err(), err)
+		case <-t.C:
+		}
+		if delay *= 2; delay > readyMaxDelay {
+			delay = readyMaxDelay
+		}
+	}
+}
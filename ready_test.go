@@ -0,0 +1,44 @@
+package bt
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestAwaitPanelReady(t *testing.T) {
+	calls := 0
+	c := newFakePanel(t, map[string]http.HandlerFunc{
+		"/ajax?action=GetTaskCount": func(w http.ResponseWriter, r *http.Request) {
+			if calls++; calls == 1 {
+				http.Error(w, "<html>502 Bad Gateway</html>", http.StatusBadGateway)
+				return
+			}
+			_, _ = w.Write([]byte(`0`))
+		},
+	})
+	if err := c.AwaitPanelReady(ctx); err != nil || calls != 2 {
+		t.Fatalf("AwaitPanelReady = %v after %d calls", err, calls)
+	}
+
+	denied := newFakePanel(t, map[string]http.HandlerFunc{
+		"/ajax?action=GetTaskCount": reply(`{"status":false,"msg":"IP校验失败"}`),
+	})
+	var apiErr *APIError
+	if err := denied.AwaitPanelReady(ctx); !errors.As(err, &apiErr) {
+		t.Fatalf("denied AwaitPanelReady = %v", err)
+	}
+
+	down := newFakePanel(t, map[string]http.HandlerFunc{
+		"/ajax?action=GetTaskCount": func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		},
+	})
+	short, cancel := context.WithTimeout(ctx, 100*time.Millisecond)
+	defer cancel()
+	if err := down.AwaitPanelReady(short); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("down AwaitPanelReady = %v", err)
+	}
+}
@@ -0,0 +1,107 @@
+package bt
+
+import (
+	"context"
+	"errors"
+	"strings"
+)
+
+// RedirectType 重定向状态码
+type RedirectType string
+
+const (
+	RedirectPermanent RedirectType = "301"
+	RedirectTemporary RedirectType = "302"
+)
+
+// GetRedirectList 获取网站的重定向列表
+func (c *Client) GetRedirectList(ctx context.Context, siteName string) ([]RedirectInfo, error) {
+	data := map[string][]string{
+		"sitename": {siteName},
+	}
+	resp, err := c.btAPI(ctx, data, "/site?action=GetRedirectList")
+	if err != nil {
+		return nil, err
+	}
+	var dec []RedirectInfo
+	if err := c.unmarshal(ctx, resp, &dec); err != nil {
+		return nil, err
+	}
+	return dec, nil
+}
+
+// CreateRedirect 为网站添加重定向
+func (c *Client) CreateRedirect(ctx context.Context, params *ReqRedirect) (RespMSG, error) {
+	data, err := params.form()
+	if err != nil {
+		return RespMSG{}, err
+	}
+	return c.btResult(ctx, data, "/site?action=CreateRedirect")
+}
+
+// ModifyRedirect 修改网站的重定向 按 SiteName 和 RedirectName 定位
+func (c *Client) ModifyRedirect(ctx context.Context, params *ReqRedirect) (RespMSG, error) {
+	data, err := params.form()
+	if err != nil {
+		return RespMSG{}, err
+	}
+	return c.btResult(ctx, data, "/site?action=ModifyRedirect")
+}
+
+// DeleteRedirect 删除网站的重定向
+func (c *Client) DeleteRedirect(ctx context.Context, siteName, redirectName string) (RespMSG, error) {
+	data := map[string][]string{
+		"sitename":     {siteName},
+		"redirectname": {redirectName},
+	}
+	return c.btResult(ctx, data, "/site?action=DeleteRedirect")
+}
+
+// form 构造 CreateRedirect/ModifyRedirect 的请求参数
+func (params *ReqRedirect) form() (map[string][]string, error) {
+	if params.SiteName == "" || params.RedirectName == "" {
+		return nil, errors.New("redirect: site name and redirect name are required")
+	}
+	if params.RedirectType != RedirectPermanent && params.RedirectType != RedirectTemporary {
+		return nil, errors.New("redirect: type must be 301 or 302")
+	}
+	if !strings.HasPrefix(params.ToURL, "http://") && !strings.HasPrefix(params.ToURL, "https://") {
+		return nil, errors.New("redirect: target must be an http(s) URL")
+	}
+	domains := []string{}
+	path := ""
+	switch params.DomainOrPath {
+	case "domain":
+		if len(params.Domains) == 0 {
+			return nil, errors.New("redirect: domains are required for a domain redirect")
+		}
+		for _, d := range params.Domains {
+			if err := validateDomain(d); err != nil {
+				return nil, err
+			}
+		}
+		domains = params.Domains
+	case "path":
+		if !strings.HasPrefix(params.Path, "/") {
+			return nil, errors.New("redirect: path must start with /")
+		}
+		path = params.Path
+	default:
+		return nil, errors.New("redirect: DomainOrPath must be domain or path")
+	}
+	list, err := json.Marshal(domains)
+	if err != nil {
+		return nil, err
+	}
+	return map[string][]string{
+		"sitename":       {params.SiteName},
+		"redirectname":   {params.RedirectName},
+		"redirecttype":   {string(params.RedirectType)},
+		"domainorpath":   {params.DomainOrPath},
+		"redirectdomain": {string(list)},
+		"redirectpath":   {path},
+		"tourl":          {params.ToURL},
+		"type":           {boolFlag(params.Enabled)},
+		"holdpath":       {boolFlag(params.HoldPath)},
+	}, nil
+}
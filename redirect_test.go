@@ -0,0 +1,45 @@
+package bt
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+)
+
+func TestRedirect(t *testing.T) {
+	var form url.Values
+	c := newFakePanel(t, map[string]http.HandlerFunc{
+		"/site?action=GetRedirectList": reply(`[{"redirectname":"old","sitename":"w1.hao.com","redirecttype":"301","domainorpath":"path","redirectdomain":[],"redirectpath":"/old","tourl":"https://w1.hao.com/new","type":1,"holdpath":1}]`),
+		"/site?action=CreateRedirect": func(w http.ResponseWriter, r *http.Request) {
+			_ = r.ParseForm()
+			form = r.PostForm
+			_, _ = w.Write([]byte(`{"status":true,"msg":"创建成功"}`))
+		},
+	})
+	list, err := c.GetRedirectList(ctx, "w1.hao.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list) != 1 || list[0].RedirectPath != "/old" || list[0].HoldPath != 1 {
+		t.Fatalf("list %+v", list)
+	}
+	_, err = c.CreateRedirect(ctx, &ReqRedirect{
+		SiteName:     "w1.hao.com",
+		RedirectName: "www",
+		RedirectType: RedirectPermanent,
+		DomainOrPath: "domain",
+		Domains:      []string{"www.hao.com"},
+		ToURL:        "https://w1.hao.com",
+		Enabled:      true,
+		HoldPath:     true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if form.Get("redirectdomain") != `["www.hao.com"]` || form.Get("redirecttype") != "301" || form.Get("holdpath") != "1" || form.Get("redirectpath") != "" {
+		t.Fatalf("form %v", form)
+	}
+	if _, err := c.CreateRedirect(ctx, &ReqRedirect{SiteName: "w1.hao.com", RedirectName: "x", RedirectType: "307", DomainOrPath: "path", Path: "/a", ToURL: "https://a.com"}); err == nil {
+		t.Error("CreateRedirect accepted type 307")
+	}
+}
@@ -0,0 +1,88 @@
+package bt
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// CertRenewal RenewExpiringCertificates 中单个网站的结果
+type CertRenewal struct {
+	Site    SiteInfo
+	Expiry  time.Time // 续签前的到期时间
+	Renewed bool
+	Skipped string // 未尝试续签的原因 eg. 非 Let's Encrypt 证书
+	Err     error
+}
+
+// CertRenewalReport 证书续签结果
+type CertRenewalReport struct {
+	Checked int           // 已部署证书的网站数
+	Results []CertRenewal // 在续签窗口内的网站
+}
+
+// Renewed 返回续签成功的结果
+func (r CertRenewalReport) Renewed() []CertRenewal {
+	var ret []CertRenewal
+	for _, v := range r.Results {
+		if v.Renewed {
+			ret = append(ret, v)
+		}
+	}
+	return ret
+}
+
+// Failed 返回续签失败的结果 不含跳过的网站
+func (r CertRenewalReport) Failed() []CertRenewal {
+	var ret []CertRenewal
+	for _, v := range r.Results {
+		if v.Err != nil {
+			ret = append(ret, v)
+		}
+	}
+	return ret
+}
+
+// RenewExpiringCertificates 检查所有网站的证书 为 within 内到期的 Let's Encrypt 证书（文件验证）重新签发
+// 其他来源的证书及 DNS 验证的证书只记录在结果中 不会续签 网站之间依次执行以免触发 Let's Encrypt 频率限制
+// 单个网站失败不影响其他网站 只有获取网站列表失败或 ctx 取消时返回错误
+func (c *Client) RenewExpiringCertificates(ctx context.Context, within time.Duration) (CertRenewalReport, error) {
+	var report CertRenewalReport
+	sites, err := c.ListAllSites(ctx, "")
+	if err != nil {
+		return report, err
+	}
+	deadline := time.Now().Add(within)
+	for _, site := range sites {
+		if err := ctx.Err(); err != nil {
+			return report, err
+		}
+		info, err := c.GetSSLInfo(ctx, site.Name)
+		if err != nil {
+			report.Results = append(report.Results, CertRenewal{Site: site, Err: err})
+			continue
+		}
+		expiry := info.Expiry()
+		if !info.Status || expiry.IsZero() {
+			continue
+		}
+		report.Checked++
+		if expiry.After(deadline) {
+			continue
+		}
+		r := CertRenewal{Site: site, Expiry: expiry}
+		switch {
+		case info.Type != 1:
+			r.Skipped = "not a Let's Encrypt certificate"
+		case info.AuthType == "dns":
+			r.Skipped = "issued with DNS validation"
+		case len(info.CertData.DNS) == 0:
+			r.Err = errors.New("certificate has no domains")
+		default:
+			_, r.Err = c.ApplyLetsEncryptCert(ctx, int64(site.ID), info.CertData.DNS)
+			r.Renewed = r.Err == nil
+		}
+		report.Results = append(report.Results, r)
+	}
+	return report, nil
+}
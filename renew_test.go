@@ -0,0 +1,43 @@
+package bt
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestRenewExpiringCertificates(t *testing.T) {
+	soon := time.Now().AddDate(0, 0, 10).Format("2006-01-02")
+	later := time.Now().AddDate(0, 0, 80).Format("2006-01-02")
+	certs := map[string]string{
+		"a.com": fmt.Sprintf(`{"status":true,"type":1,"auth_type":"http","cert_data":{"notAfter":%q,"dns":["a.com","www.a.com"]}}`, soon),
+		"b.com": fmt.Sprintf(`{"status":true,"type":1,"auth_type":"http","cert_data":{"notAfter":%q,"dns":["b.com"]}}`, later),
+		"c.com": fmt.Sprintf(`{"status":true,"type":0,"cert_data":{"notAfter":%q,"dns":["c.com"]}}`, soon),
+		"d.com": `{"status":false,"type":-1}`,
+	}
+	var applied []string
+	c := newFakePanel(t, map[string]http.HandlerFunc{
+		"/data?action=getData": reply(`{"data":[{"id":1,"name":"a.com"},{"id":2,"name":"b.com"},{"id":3,"name":"c.com"},{"id":4,"name":"d.com"}]}`),
+		"/site?action=GetSSL": func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(certs[r.FormValue("siteName")]))
+		},
+		"/acme?action=apply_cert_api": func(w http.ResponseWriter, r *http.Request) {
+			applied = append(applied, r.FormValue("id")+" "+r.FormValue("domains"))
+			_, _ = w.Write([]byte(`{"status":true,"msg":"证书已部署"}`))
+		},
+	})
+	report, err := c.RenewExpiringCertificates(ctx, 30*24*time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Checked != 3 || len(report.Results) != 2 || len(report.Renewed()) != 1 || len(report.Failed()) != 0 {
+		t.Fatalf("report %+v", report)
+	}
+	if report.Results[1].Site.Name != "c.com" || report.Results[1].Skipped == "" {
+		t.Errorf("c.com %+v", report.Results[1])
+	}
+	if len(applied) != 1 || applied[0] != `1 ["a.com","www.a.com"]` {
+		t.Errorf("applied %q", applied)
+	}
+}
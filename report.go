@@ -0,0 +1,61 @@
+package bt
+
+import (
+	"context"
+	"strconv"
+	"time"
+)
+
+// 网站监控报表插件名
+const reportPlugin = "total"
+
+func (c *Client) siteReport(ctx context.Context, method string, siteName string, day time.Time, limit int64, v interface{}) error {
+	args := map[string]string{
+		"site_name":  siteName,
+		"query_date": day.Format("2006-01-02"),
+	}
+	if limit > 0 {
+		args["limit"] = strconv.FormatInt(limit, 10)
+	}
+	resp, err := c.PluginCall(ctx, reportPlugin, method, args)
+	if err != nil {
+		return err
+	}
+	return c.unmarshal(ctx, resp, v)
+}
+
+// GetSiteReport 获取网站监控报表单日概览（需安装网站监控报表插件）
+func (c *Client) GetSiteReport(ctx context.Context, siteName string, day time.Time) (SiteReportOverview, error) {
+	var dec SiteReportOverview
+	if err := c.siteReport(ctx, "get_site_overview", siteName, day, 0, &dec); err != nil {
+		return SiteReportOverview{}, err
+	}
+	return dec, nil
+}
+
+// GetSiteTopIPs 获取网站单日访问量最高的 IP limit 为 0 时使用插件默认值
+func (c *Client) GetSiteTopIPs(ctx context.Context, siteName string, day time.Time, limit int64) (SiteReportRanks, error) {
+	var dec SiteReportRanks
+	if err := c.siteReport(ctx, "get_ip_rank", siteName, day, limit, &dec); err != nil {
+		return SiteReportRanks{}, err
+	}
+	return dec, nil
+}
+
+// GetSiteTopURIs 获取网站单日访问量最高的 URI
+func (c *Client) GetSiteTopURIs(ctx context.Context, siteName string, day time.Time, limit int64) (SiteReportRanks, error) {
+	var dec SiteReportRanks
+	if err := c.siteReport(ctx, "get_uri_rank", siteName, day, limit, &dec); err != nil {
+		return SiteReportRanks{}, err
+	}
+	return dec, nil
+}
+
+// GetSiteSpiders 获取网站单日各搜索引擎蜘蛛的访问统计
+func (c *Client) GetSiteSpiders(ctx context.Context, siteName string, day time.Time) (SiteReportRanks, error) {
+	var dec SiteReportRanks
+	if err := c.siteReport(ctx, "get_spider_rank", siteName, day, 0, &dec); err != nil {
+		return SiteReportRanks{}, err
+	}
+	return dec, nil
+}
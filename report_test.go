@@ -0,0 +1,45 @@
+package bt
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSiteReport(t *testing.T) {
+	var calls []string
+	c := newFakePanel(t, map[string]http.HandlerFunc{
+		"/plugin?action=a": func(w http.ResponseWriter, r *http.Request) {
+			q := r.URL.Query()
+			if q.Get("name") != "total" || r.FormValue("site_name") != "w1.hao.com" || r.FormValue("query_date") != "2024-03-05" {
+				t.Errorf("unexpected request %s %v", r.URL, r.Form)
+			}
+			calls = append(calls, q.Get("s")+":"+r.FormValue("limit"))
+			switch q.Get("s") {
+			case "get_site_overview":
+				_, _ = w.Write([]byte(`{"req":120,"pv":80,"uv":30,"ip":25,"length":4096,"spider":7,"s4xx":3,"s5xx":1}`))
+			default:
+				_, _ = w.Write([]byte(`[{"key":"1.2.3.4","count":50,"length":2048},{"key":"5.6.7.8","count":20,"length":512}]`))
+			}
+		},
+	})
+	day := time.Date(2024, 3, 5, 23, 0, 0, 0, time.UTC)
+	overview, err := c.GetSiteReport(ctx, "w1.hao.com", day)
+	if err != nil || overview != (SiteReportOverview{Requests: 120, PV: 80, UV: 30, IP: 25, Traffic: 4096, Spider: 7, Err4xx: 3, Err5xx: 1}) {
+		t.Fatalf("GetSiteReport = %+v, %v", overview, err)
+	}
+	ips, err := c.GetSiteTopIPs(ctx, "w1.hao.com", day, 10)
+	if err != nil || len(ips) != 2 || ips[0].Key != "1.2.3.4" || ips[0].Count != 50 || ips[1].Traffic != 512 {
+		t.Fatalf("GetSiteTopIPs = %+v, %v", ips, err)
+	}
+	if _, err := c.GetSiteTopURIs(ctx, "w1.hao.com", day, 0); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.GetSiteSpiders(ctx, "w1.hao.com", day); err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Join(calls, " "); got != "get_site_overview: get_ip_rank:10 get_uri_rank: get_spider_rank:" {
+		t.Errorf("calls %s", got)
+	}
+}
@@ -1,5 +1,9 @@
 package bt
 
+//go:generate go run ./cmd/btschema -o schema.json
+
+import "os"
+
 /*
  *定义请求参数较为复杂的结构体
  带注释为必填 其余为选填
@@ -8,36 +12,35 @@ package bt
 
 // ReqSites 获取网站列表
 // URI 地址：/data?action=getData&table=sites
+// 零值可直接使用 未设置的参数不会发送
 type ReqSites struct {
-	P      int64
-	Limit  int64 // 必填
-	Type   int64
-	Order  string
-	ToJS   string
-	Search string
+	P      int64  // 页码 默认 1
+	Limit  int64  // 每页条数 默认 20
+	Type   int64  // 分类 ID 为 0 时不按分类筛选
+	Order  string // 排序 eg. id desc
+	ToJS   string // 分页 HTML 使用的 JS 回调函数名
+	Search string // 搜索关键字
 }
 
 // ReqAddSite 创建网站
 // URI 地址：/site?action=AddSite
 type ReqAddSite struct {
-	WebName struct {
-		Domain     string   `json:"domain"`     // 必填
-		DomainList []string `json:"domainlist"` // 必填
-		Count      int      `json:"count"`      // 必填
-	}
-	Path         string // 必填
-	TypeID       int64  // 必填
-	Type         string // 必填
-	Version      int64  // 必填
-	Port         int64  // 必填
-	PS           string // 必填
-	FTP          bool   // 必填
-	FTPUserName  string // FTP 为 true 时 必填
-	FTPPassword  string // FTP 为 true 时 必填
-	SQL          bool   // 必填
-	Codeing      string // SQL 为 true 时 必填
-	DataUser     string // SQL 为 true 时 必填
-	DataPassword string // SQL 为 true 时 必填
+	WebName      WebName // 必填 可使用 NewWebName 构造
+	Path         string  // 必填
+	TypeID       int64   // 必填
+	Type         string  // 必填
+	Version      int64   // 必填 PHP 版本 eg. 74 纯静态为 PHPVersionStatic
+	Port         int64   // 必填
+	PS           string  // 必填
+	FTP          bool    // 必填
+	FTPUserName  string  // FTP 为 true 时 必填
+	FTPPassword  string  // FTP 为 true 时 必填
+	SQL          bool    // 必填
+	Codeing      string  // SQL 为 true 时 必填
+	DataUser     string  // SQL 为 true 时 必填
+	DataPassword string  // SQL 为 true 时 必填
+	// IdempotencyKey 配置了 Client.Idempotency 时生效 为空时以主域名（不含端口）作为幂等键
+	IdempotencyKey string
 }
 
 // ReqDeleteSite 删除网站
@@ -59,3 +62,122 @@ type ReqSiteBackups struct {
 	ToJS   string
 	Search int64 // 必填
 }
+
+// ReqAddDatabase 添加数据库
+// URI 地址：/database?action=AddDatabase
+type ReqAddDatabase struct {
+	Name       string // 必填 数据库名
+	User       string // 为空时与数据库名相同
+	Password   string // 必填
+	Charset    string // utf8mb4/utf8/gbk/big5 为空时使用 utf8mb4
+	Collation  string // eg. utf8mb4_general_ci 为空时使用字符集默认排序规则
+	DataAccess string // 访问权限 127.0.0.1/%/指定 IP 为空时仅本地
+	PS         string
+	SID        int64 // 数据库服务器 ID 0 为本机 远程服务器见 ListDatabaseServers
+}
+
+// ReqDatabaseServer 添加远程数据库服务器
+// URI 地址：/database?action=AddCloudServer
+type ReqDatabaseServer struct {
+	Host     string // 必填
+	Port     int64  // 为 0 时使用 3306
+	User     string // 必填 需具备创建库和授权的权限
+	Password string // 必填
+	PS       string
+}
+
+// ReqAddCrontab 添加计划任务
+// URI 地址：/crontab?action=AddCrontab
+type ReqAddCrontab struct {
+	Name       string       // 必填 任务名称
+	Schedule   CronSchedule // 必填 执行周期
+	SType      string       // 必填 任务类型 toShell/site/database/logs/path/toUrl 等
+	SName      string       // 备份类任务的对象 eg. 网站名 数据库名 ALL
+	SBody      string       // Shell 脚本内容
+	BackupTo   string       // 备份到 默认 localhost 可选值见 BackupTarget
+	Save       int64        // 保留最新几份
+	URLAddress string       // toUrl 类型的 URL
+}
+
+// ReqAddFTP 添加 FTP 账户
+// URI 地址：/ftp?action=AddUser
+type ReqAddFTP struct {
+	UserName string // 必填
+	Password string // 必填
+	Path     string // 必填 根目录 绝对路径
+	PS       string // 为空时使用用户名
+}
+
+// ReqFirewallRule 添加面板防火墙规则
+// URI 地址：/firewall?action=AddAcceptPort 或 /firewall?action=AddDropAddress
+type ReqFirewallRule struct {
+	Type FirewallRuleType // 必填 放行端口或屏蔽 IP
+	Port string           // 必填 端口、端口范围（8000-9000 或 8000:9000 按系统防火墙转换）或 IP
+	PS   string           // 备注
+}
+
+// ReqProxy 添加或修改网站反向代理
+// URI 地址：/site?action=CreateProxy 或 /site?action=ModifyProxy
+type ReqProxy struct {
+	SiteName  string           // 必填 网站名
+	ProxyName string           // 必填 代理名称 至少 3 个字符 修改时用于定位代理
+	ProxySite string           // 必填 目标 URL eg. http://127.0.0.1:8080
+	ToDomain  string           // 发送域名 为空时使用 $host
+	ProxyDir  string           // 代理目录 默认 /
+	Enabled   bool             // 是否启用
+	Cache     bool             // 是否开启缓存
+	CacheTime int64            // 缓存时间（分钟） 开启缓存时默认 1
+	Advanced  bool             // 高级功能 开启后可代理指定目录
+	SubFilter []ProxySubFilter // 内容替换 最多 3 条
+}
+
+// ProxySubFilter 反向代理内容替换 将响应中的 From 替换为 To
+type ProxySubFilter struct {
+	From string `json:"sub1"`
+	To   string `json:"sub2"`
+}
+
+// ReqRedirect 添加或修改网站重定向
+// URI 地址：/site?action=CreateRedirect 或 /site?action=ModifyRedirect
+type ReqRedirect struct {
+	SiteName     string       // 必填 网站名
+	RedirectName string       // 必填 重定向名称 修改时用于定位
+	RedirectType RedirectType // 必填 301 或 302
+	DomainOrPath string       // 必填 domain 按域名重定向 path 按路径重定向
+	Domains      []string     // DomainOrPath 为 domain 时必填 需重定向的域名
+	Path         string       // DomainOrPath 为 path 时必填 需重定向的路径 eg. /old
+	ToURL        string       // 必填 目标 URL
+	Enabled      bool         // 是否启用
+	HoldPath     bool         // 是否保留 URI 参数 eg. /old/a -> https://target/a
+}
+
+// ReqBatch 批量操作文件
+// URI 地址：/files?action=SetBatchData 及 /files?action=BatchPaste
+type ReqBatch struct {
+	Op    BatchOp     // 必填 操作类型
+	Dir   string      // 必填 文件所在目录
+	Names []string    // 必填 Dir 下的文件或目录名
+	Dest  string      // 复制或剪切时必填 目标目录
+	Mode  os.FileMode // 设置权限时使用 默认 0755
+	Owner string      // 设置权限时使用 默认 www
+}
+
+// ReqZip 压缩文件
+// URI 地址：/files?action=Zip
+type ReqZip struct {
+	Dir      string      // 必填 待压缩文件所在目录
+	Names    []string    // 必填 Dir 下的文件或目录名
+	Archive  string      // 必填 压缩包路径 eg. /www/backup/a.zip
+	Type     ArchiveType // 默认 zip
+	Password string      // 压缩包密码 仅支持 zip
+}
+
+// ReqUnZip 解压文件
+// URI 地址：/files?action=UnZip
+type ReqUnZip struct {
+	Archive  string      // 必填 压缩包路径
+	Dest     string      // 必填 解压到的目录
+	Type     ArchiveType // 为空时按扩展名判断
+	Password string      // 压缩包密码 仅支持 zip
+	Coding   string      // 文件名编码 默认 UTF-8 Windows 创建的 zip 可能需要 GBK
+}
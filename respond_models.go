@@ -36,8 +36,19 @@ type NetWork struct {
 		Inodes []string `json:"inodes"` // Inode使用信息 数组同下
 		Size   []string `json:"size"`   // 0-总共（GB） 1-已用（GB） 2-可用（GB） 3-使用率（百分比 带%）
 	} `json:"disk"` // 磁盘
-	DownPackets int           `json:"downPackets"` // 总收包（个）
-	CPU         []interface{} `json:"cpu"`         // 0-总体使用率 1-核心数 2-[0-CPU0 1-CPU1]使用率 3-CPU型号
+	DownPackets int                  `json:"downPackets"` // 总收包（个）
+	CPU         []interface{}        `json:"cpu"`         // 0-总体使用率 1-核心数 2-[0-CPU0 1-CPU1]使用率 3-CPU型号
+	Network     map[string]NetWorkIO `json:"network"`     // 各网卡实时信息 键为网卡名 旧版面板无此字段
+}
+
+// NetWorkIO 单个网卡的实时流量
+type NetWorkIO struct {
+	Up          float64 `json:"up"`          // 上行流量（KB）
+	Down        float64 `json:"down"`        // 下行流量（KB）
+	UpTotal     int64   `json:"upTotal"`     // 总发送（Byte）
+	DownTotal   int64   `json:"downTotal"`   // 总接收（Byte）
+	UpPackets   int64   `json:"upPackets"`   // 总发包（个）
+	DownPackets int64   `json:"downPackets"` // 总收包（个）
 }
 
 // SystemTotal 获取系统基础统计
@@ -75,19 +86,23 @@ type UpdateStatus struct {
 // RespSites 获取网站列表
 // URI 地址：/data?action=getData&table=sites
 type RespSites struct {
-	Data []struct {
-		Status      string `json:"status"`
-		Ps          string `json:"ps"`
-		Domain      int    `json:"domain"`
-		Name        string `json:"name"`
-		Addtime     string `json:"addtime"`
-		Path        string `json:"path"`
-		BackupCount int    `json:"backup_count"`
-		Edate       string `json:"edate"`
-		ID          int    `json:"id"`
-	} `json:"data"`
-	Where string `json:"where"`
-	Page  string `json:"page"`
+	Data  []SiteInfo `json:"data"`
+	Where string     `json:"where"`
+	Page  string     `json:"page"`
+}
+
+// SiteInfo 网站列表中的一项
+type SiteInfo struct {
+	Status      string `json:"status"` // 1 运行中 0 已停止
+	Ps          string `json:"ps"`
+	Domain      int    `json:"domain"` // 域名数量
+	Name        string `json:"name"`
+	Addtime     string `json:"addtime"`
+	Path        string `json:"path"`
+	BackupCount int    `json:"backup_count"`
+	Edate       string `json:"edate"`
+	ID          int    `json:"id"`
+	PHPVersion  string `json:"php_version"` // eg. 7.4 或 静态 旧版面板不返回
 }
 
 // SiteTypes 获取网站分类
@@ -114,27 +129,33 @@ type RespAddSite struct {
 	DatabasePass   string `json:"databasePass"`
 	SiteStatus     bool   `json:"siteStatus"`
 	FtpPass        string `json:"ftpPass"`
+	SiteID         int64  `json:"siteId"` // 新网站的 ID 旧版面板不返回
 }
 
 // RespMSG 通用消息结构
 type RespMSG struct {
-	Status bool   `json:"status"`
-	Msg    string `json:"msg"`
+	Status bool    `json:"status"`
+	Msg    string  `json:"msg"`
+	Code   MsgCode `json:"-"` // 经 Translator 映射出的机器可读代码 未命中时为空
+	RawMsg string  `json:"-"` // 面板返回的原始 msg 翻译后 Msg 会被替换
 }
 
 // RespSiteBackups 获取网站备份列表
 // URI 地址：/data?action=getData&table=backup
 type RespSiteBackups struct {
-	Data []struct {
-		Name     string `json:"name"`
-		Addtime  string `json:"addtime"`
-		Pid      int    `json:"pid"`
-		Filename string `json:"filename"`
-		ID       int    `json:"id"`
-		Size     int    `json:"size"`
-	} `json:"data"`
-	Where string `json:"where"`
-	Page  string `json:"page"`
+	Data  []BackupFile `json:"data"`
+	Where string       `json:"where"`
+	Page  string       `json:"page"`
+}
+
+// BackupFile 备份记录
+type BackupFile struct {
+	Name     string `json:"name"`
+	Addtime  string `json:"addtime"`
+	Pid      int    `json:"pid"`
+	Filename string `json:"filename"` // 备份文件在服务器上的完整路径
+	ID       int    `json:"id"`
+	Size     int    `json:"size"`
 }
 
 // SiteDomains 获取网站的域名列表
@@ -154,18 +175,21 @@ type RewriteList struct {
 
 // RespGetFile 获取指定文件
 type RespGetFile struct {
-	Status   bool   `json:"status"`
-	Data     string `json:"data"`
-	Encoding string `json:"encoding"`
+	Status   bool    `json:"status"`
+	Data     string  `json:"data"`
+	Encoding string  `json:"encoding"`
+	Historys []int64 `json:"historys"` // 面板保存的历史版本（时间戳）需开启文件历史副本
 }
 
+// RespUserINI 网站目录相关设置
+// URI 地址：/site?action=GetDirUserINI
 type RespUserINI struct {
-	Pass    bool `json:"pass"`
-	Logs    bool `json:"logs"`
-	Userini bool `json:"userini"`
+	Pass    bool `json:"pass"`    // 是否开启密码访问
+	Logs    bool `json:"logs"`    // 是否开启访问日志
+	Userini bool `json:"userini"` // 是否开启防跨站攻击（open_basedir）
 	RunPath struct {
-		Dirs    []string `json:"dirs"`
-		RunPath string   `json:"runPath"`
+		Dirs    []string `json:"dirs"`    // 可选的运行目录
+		RunPath string   `json:"runPath"` // 当前运行目录 eg. / 或 /public
 	} `json:"runPath"`
 }
 
@@ -175,3 +199,347 @@ type RespLimitNet struct {
 	Perserver int `json:"perserver"`
 	Perip     int `json:"perip"`
 }
+
+// RespDNSChallenge DNS 手动验证申请结果
+// URI 地址：/acme?action=apply_cert_api
+type RespDNSChallenge struct {
+	Status bool   `json:"status"`
+	Msg    string `json:"msg"`
+	Index  string `json:"index"`
+	Auths  []struct {
+		Domain    string `json:"domain"`
+		AuthValue string `json:"auth_value"`
+		Type      string `json:"type"`
+	} `json:"auths"`
+}
+
+// RespCertApply 证书签发结果
+// URI 地址：/acme?action=apply_dns_auth /acme?action=apply_cert_api（文件验证）
+type RespCertApply struct {
+	Status     bool   `json:"status"`
+	Msg        string `json:"msg"`
+	Cert       string `json:"cert"`        // 证书 PEM
+	Root       string `json:"root"`        // 证书链 PEM
+	PrivateKey string `json:"private_key"` // 私钥 PEM
+}
+
+// DatabaseServers 数据库服务器列表
+// URI 地址：/database?action=GetCloudServer
+type DatabaseServers []struct {
+	ID       int    `json:"id"`
+	Host     string `json:"db_host"`
+	Port     int    `json:"db_port"`
+	User     string `json:"db_user"`
+	Password string `json:"db_password"`
+	PS       string `json:"ps"`
+	Type     string `json:"db_type"`
+}
+
+// SiteReportOverview 网站监控报表 单日概览
+// URI 地址：/plugin?action=a&name=total&s=get_site_overview
+type SiteReportOverview struct {
+	Requests int64 `json:"req"`    // 请求数
+	PV       int64 `json:"pv"`     // 浏览量
+	UV       int64 `json:"uv"`     // 独立访客
+	IP       int64 `json:"ip"`     // 独立 IP
+	Traffic  int64 `json:"length"` // 流量（Byte）
+	Spider   int64 `json:"spider"` // 蜘蛛请求数
+	Err4xx   int64 `json:"s4xx"`   // 4xx 响应数
+	Err5xx   int64 `json:"s5xx"`   // 5xx 响应数
+}
+
+// SiteReportRanks 网站监控报表排行（IP/URI/蜘蛛）
+// URI 地址：/plugin?action=a&name=total&s=get_ip_rank 等
+type SiteReportRanks []struct {
+	Key     string `json:"key"`    // IP/URI/蜘蛛名称
+	Count   int64  `json:"count"`  // 请求数
+	Traffic int64  `json:"length"` // 流量（Byte）
+}
+
+// FirewallList 面板防火墙规则列表
+// URI 地址：/data?action=getData&table=firewall
+type FirewallList struct {
+	Data  []FirewallRule `json:"data"`
+	Where string         `json:"where"`
+	Page  string         `json:"page"`
+}
+
+// FirewallRule 面板防火墙规则 放行的端口或屏蔽的 IP
+type FirewallRule struct {
+	ID      int    `json:"id"`
+	Port    string `json:"port"` // 端口、端口范围（8000-9000）或 IP
+	PS      string `json:"ps"`
+	Addtime string `json:"addtime"`
+}
+
+// RespSSHInfo SSH 及防火墙状态
+// URI 地址：/firewall?action=GetSshInfo
+type RespSSHInfo struct {
+	Port           int  `json:"port"`
+	Status         bool `json:"status"`          // SSH 服务是否运行
+	Ping           bool `json:"ping"`            // 是否允许 ping
+	FirewallStatus bool `json:"firewall_status"` // 系统防火墙是否开启
+}
+
+// NetWorkList 网络连接列表
+// URI 地址：/ajax?action=GetNetWorkList
+type NetWorkList []struct {
+	Process string        `json:"process"`
+	PID     int           `json:"pid"`
+	Type    string        `json:"type"`  // tcp/tcp6/udp/udp6
+	Laddr   []interface{} `json:"laddr"` // 0-本地地址 1-本地端口
+	Raddr   []interface{} `json:"raddr"` // 0-远端地址 1-远端端口
+	Status  string        `json:"status"`
+}
+
+// RespSiteUser 网站运行用户
+// URI 地址：/site?action=GetSiteRunUser
+type RespSiteUser struct {
+	User  string   `json:"user"`  // 当前运行用户 默认为 www
+	Users []string `json:"users"` // 可选的系统用户
+}
+
+// TamperSite 防篡改插件中网站的保护配置
+// URI 地址：/plugin?action=a&name=tamper_proof&s=get_site_find
+type TamperSite struct {
+	SiteName    string   `json:"siteName"`
+	Open        bool     `json:"open"`        // 是否开启保护
+	Path        string   `json:"path"`        // 受保护的网站根目录
+	ExcludePath []string `json:"excludePath"` // 排除的目录/文件名
+	ProtectExt  []string `json:"protectExt"`  // 受保护的文件类型
+	Total       struct {
+		Create int `json:"create"`
+		Modify int `json:"modify"`
+		Unlink int `json:"unlink"`
+		Rename int `json:"rename"`
+	} `json:"total"` // 累计拦截次数
+}
+
+// TamperLogs 防篡改拦截日志
+// URI 地址：/plugin?action=a&name=tamper_proof&s=get_safe_logs
+type TamperLogs struct {
+	Data []struct {
+		Time   string `json:"time"`
+		Action string `json:"action"` // create/modify/unlink/rename 等
+		Path   string `json:"path"`
+	} `json:"data"`
+	Page string `json:"page"`
+}
+
+// SoftInfo 软件商店中的一项
+type SoftInfo struct {
+	Name     string `json:"name"`    // eg. php-7.4 nginx
+	Title    string `json:"title"`   // eg. PHP-7.4
+	Version  string `json:"version"` // 已安装版本 未安装时为空
+	Ps       string `json:"ps"`
+	Setup    bool   `json:"setup"`  // 是否已安装
+	Status   bool   `json:"status"` // 是否运行中
+	Type     int    `json:"type"`
+	Versions []struct {
+		MVersion string `json:"m_version"` // 主版本 eg. 7.4
+		Version  string `json:"version"`   // 小版本 eg. 33
+	} `json:"versions"` // 可安装的版本
+}
+
+// SoftList 软件商店列表
+// URI 地址：/plugin?action=get_soft_list
+type SoftList struct {
+	List struct {
+		Data []SoftInfo `json:"data"`
+		Page string     `json:"page"`
+	} `json:"list"`
+}
+
+// RespAddCrontab 添加计划任务
+// URI 地址：/crontab?action=AddCrontab
+type RespAddCrontab struct {
+	Status bool   `json:"status"`
+	Msg    string `json:"msg"`
+	ID     int64  `json:"id"`
+}
+
+// NginxStatus nginx 负载状态（stub_status）
+// URI 地址：/ajax?action=GetNginxStatus
+type NginxStatus struct {
+	Active    int64  `json:"active"`    // 活动连接数
+	Accepts   int64  `json:"accepts"`   // 总连接次数
+	Handled   int64  `json:"handled"`   // 总握手次数
+	Requests  int64  `json:"requests"`  // 总请求数
+	Reading   int64  `json:"Reading"`   // 正在读取请求头的连接
+	Writing   int64  `json:"Writing"`   // 正在返回响应的连接
+	Waiting   int64  `json:"Waiting"`   // keep-alive 空闲连接
+	Worker    int64  `json:"worker"`    // 工作进程数
+	WorkerMen string `json:"workermen"` // 工作进程内存占用
+}
+
+// RegionRule 地区封禁规则
+// URI 地址：/plugin?action=a&name=firewall&s=get_countrys_list
+type RegionRule struct {
+	ID      int64  `json:"id"`
+	Types   string `json:"types"`   // drop 封禁
+	Country string `json:"country"` // 国家或地区
+	Ports   string `json:"ports"`   // 端口 空为全部
+	Brief   string `json:"brief"`   // 备注
+	AddTime string `json:"addtime"`
+}
+
+// TimezoneData 服务器时区
+// URI 地址：/config?action=get_timezone_data
+type TimezoneData struct {
+	Zone struct {
+		Area string `json:"area"` // eg. Asia
+		City string `json:"city"` // eg. Shanghai
+	} `json:"zone"`
+	AreaList []string `json:"areaList"` // 可选的区域
+	CityList []string `json:"cityList"` // 当前区域可选的城市
+}
+
+// SwapInfo Swap 使用情况 单位为 MB
+// URI 地址：/plugin?action=a&name=linuxsys&s=GetSwap
+type SwapInfo struct {
+	Total int64 `json:"total"` // 系统 Swap 总量
+	Used  int64 `json:"used"`  // 已使用
+	Size  int64 `json:"size"`  // 面板创建的 Swap 文件大小
+}
+
+// ProcessInfo 进程信息
+// URI 地址：/plugin?action=a&name=task_manager&s=get_process_list
+type ProcessInfo struct {
+	Pid        int64   `json:"pid"`
+	Name       string  `json:"name"`
+	User       string  `json:"user"`
+	Status     string  `json:"status"`
+	CPUPercent float64 `json:"cpu_percent"`
+	MemoryUsed int64   `json:"memory_used"` // 常驻内存（字节）
+	Threads    int64   `json:"threads"`
+	Exe        string  `json:"exe"`
+	Ps         string  `json:"ps"` // 面板对进程的说明
+}
+
+// FileFavorite 文件管理器收藏的路径
+// URI 地址：/files?action=get_files_store
+type FileFavorite struct {
+	Name string `json:"name"`
+	Path string `json:"path"`
+}
+
+// SQLiteTable SQLite 数据库中的表
+// URI 地址：/database/sqlite/get_table_list
+type SQLiteTable struct {
+	Name  string `json:"name"`
+	Type  string `json:"type"`  // table/view
+	Count int64  `json:"count"` // 记录数
+	SQL   string `json:"sql"`   // 建表语句
+}
+
+// SQLiteResult SQLite 查询结果
+// URI 地址：/database/sqlite/query_sql
+type SQLiteResult struct {
+	Columns []string        `json:"columns"`
+	Rows    [][]interface{} `json:"rows"`
+}
+
+// RespDir 目录列表 每一项为分号分隔的 名称;大小;修改时间;权限;所有者;软链接目标
+// URI 地址：/files?action=GetDir
+type RespDir struct {
+	Status bool     `json:"status"` // 目录不存在时为 false
+	Msg    string   `json:"msg"`
+	Path   string   `json:"PATH"`
+	Dirs   []string `json:"DIR"`
+	Files  []string `json:"FILES"`
+}
+
+// FTPAccount FTP 账户
+// URI 地址：/data?action=getData&table=ftps
+type FTPAccount struct {
+	ID       int64  `json:"id"`
+	Pid      int64  `json:"pid"` // 关联的网站 ID 单独创建的账户为 0
+	Name     string `json:"name"`
+	Password string `json:"password"`
+	Status   string `json:"status"` // 1 启用 0 停用
+	PS       string `json:"ps"`
+	Addtime  string `json:"addtime"`
+	Path     string `json:"path"`
+}
+
+// RespSSLInfo 网站 SSL 状态
+// URI 地址：/site?action=GetSSL
+type RespSSLInfo struct {
+	Status      bool   `json:"status"` // 是否已开启 HTTPS
+	Type        int    `json:"type"`   // 证书来源 0 其他证书 1 Let's Encrypt 2 宝塔证书 -1 未部署
+	HTTPToHTTPS bool   `json:"httpTohttps"`
+	Cert        string `json:"csr"` // 证书 PEM
+	Key         string `json:"key"` // 私钥 PEM
+	Email       string `json:"email"`
+	AuthType    string `json:"auth_type"` // Let's Encrypt 验证方式 http/dns
+	CertData    struct {
+		Issuer    string   `json:"issuer"`
+		Subject   string   `json:"subject"`
+		NotBefore string   `json:"notBefore"` // eg. 2024-01-01
+		NotAfter  string   `json:"notAfter"`  // 到期日期 eg. 2024-03-31
+		EndTime   int      `json:"endtime"`   // 剩余天数
+		DNS       []string `json:"dns"`       // 证书包含的域名
+	} `json:"cert_data"`
+}
+
+// CrontabInfo 计划任务
+// URI 地址：/crontab?action=GetCrontab
+type CrontabInfo struct {
+	ID          int64  `json:"id"`
+	Name        string `json:"name"`
+	Type        string `json:"type"` // 执行周期类型 见 CronSchedule
+	Where1      string `json:"where1"`
+	WhereHour   string `json:"where_hour"`
+	WhereMinute string `json:"where_minute"`
+	Cycle       string `json:"cycle"`  // 面板生成的执行周期描述
+	Status      int    `json:"status"` // 1 启用 0 停用
+	SType       string `json:"sType"`  // 任务类型 toShell/site/database/logs/path/toUrl 等
+	SName       string `json:"sName"`  // 备份类任务的对象
+	SBody       string `json:"sBody"`
+	BackupTo    string `json:"backupTo"`
+	URLAddress  string `json:"urladdress"`
+	Echo        string `json:"echo"` // 任务脚本名 日志位于 /www/server/cron/<echo>.log
+	Addtime     string `json:"addtime"`
+}
+
+// ProxyInfo 网站反向代理
+// URI 地址：/site?action=GetProxyList
+type ProxyInfo struct {
+	ProxyName string           `json:"proxyname"`
+	SiteName  string           `json:"sitename"`
+	ProxyDir  string           `json:"proxydir"`
+	ProxySite string           `json:"proxysite"` // 目标 URL
+	ToDomain  string           `json:"todomain"`  // 发送域名
+	Type      int              `json:"type"`      // 1 启用 0 停用
+	Cache     int              `json:"cache"`     // 1 开启缓存
+	CacheTime int              `json:"cachetime"` // 缓存时间（分钟）
+	Advanced  int              `json:"advanced"`
+	SubFilter []ProxySubFilter `json:"subfilter"`
+}
+
+// RedirectInfo 网站重定向
+// URI 地址：/site?action=GetRedirectList
+type RedirectInfo struct {
+	RedirectName   string   `json:"redirectname"`
+	SiteName       string   `json:"sitename"`
+	RedirectType   string   `json:"redirecttype"` // 301 或 302
+	DomainOrPath   string   `json:"domainorpath"` // domain 或 path
+	RedirectDomain []string `json:"redirectdomain"`
+	RedirectPath   string   `json:"redirectpath"`
+	ToURL          string   `json:"tourl"`
+	Type           int      `json:"type"`     // 1 启用 0 停用
+	HoldPath       int      `json:"holdpath"` // 1 保留 URI 参数
+}
+
+// PanelTask 面板后台任务（软件安装等）
+// URI 地址：/data?action=getData&table=tasks
+type PanelTask struct {
+	ID      int64  `json:"id"`
+	Name    string `json:"name"`   // eg. 安装[nginx-1.24]
+	Type    string `json:"type"`   // eg. execshell
+	Status  string `json:"status"` // 0 等待中 -1 执行中 1 已完成
+	Addtime string `json:"addtime"`
+	Start   int64  `json:"start"` // 开始执行的 Unix 时间戳 未开始时为 0
+	End     int64  `json:"end"`
+	Execstr string `json:"execstr"` // 执行的命令
+}
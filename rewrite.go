@@ -0,0 +1,60 @@
+package bt
+
+import (
+	"context"
+	"errors"
+	"strings"
+)
+
+// NginxRewritePath 网站 nginx 伪静态规则文件路径
+func NginxRewritePath(siteName string) string {
+	return "/www/server/panel/vhost/rewrite/" + siteName + ".conf"
+}
+
+// RewriteTemplatePath 面板内置 nginx 伪静态模板路径 name 为 GetRewriteList 返回的名称 eg. wordpress
+func RewriteTemplatePath(name string) string {
+	return "/www/server/panel/rewrite/nginx/" + name + ".conf"
+}
+
+// GetRewriteTemplate 获取伪静态模板的规则内容（仅支持 nginx）
+func (c *Client) GetRewriteTemplate(ctx context.Context, name string) (string, error) {
+	file, err := c.GetFile(ctx, RewriteTemplatePath(name))
+	if err != nil {
+		return "", err
+	}
+	if !file.Status {
+		return "", errors.New("rewrite template not found: " + name)
+	}
+	return file.Data, nil
+}
+
+// ApplyRewriteTemplate 将伪静态模板应用到网站 覆盖网站当前的伪静态规则（仅支持 nginx）
+func (c *Client) ApplyRewriteTemplate(ctx context.Context, siteName string, templateName string) (RespMSG, error) {
+	body, err := c.GetRewriteTemplate(ctx, templateName)
+	if err != nil {
+		return RespMSG{}, err
+	}
+	return c.SetFile(ctx, NginxRewritePath(siteName), body)
+}
+
+// GetSiteRewrite 获取网站当前的伪静态规则（仅支持 nginx） 未设置时为空字符串
+func (c *Client) GetSiteRewrite(ctx context.Context, siteName string) (string, error) {
+	return c.readFile(ctx, NginxRewritePath(siteName))
+}
+
+// SetSiteRewrite 设置网站的伪静态规则（仅支持 nginx）
+// templateOrBody 为 GetRewriteList 中的模板名时应用该模板 否则作为规则内容直接保存
+func (c *Client) SetSiteRewrite(ctx context.Context, siteName string, templateOrBody string) (RespMSG, error) {
+	if name := strings.TrimSpace(templateOrBody); name != "" && !strings.ContainsAny(name, " \t\n;{}") {
+		list, err := c.GetRewriteList(ctx, siteName)
+		if err != nil {
+			return RespMSG{}, err
+		}
+		for _, r := range list.Rewrites {
+			if r == name {
+				return c.ApplyRewriteTemplate(ctx, siteName, name)
+			}
+		}
+	}
+	return c.SetFile(ctx, NginxRewritePath(siteName), templateOrBody)
+}
@@ -0,0 +1,65 @@
+package bt
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestApplyRewriteTemplate(t *testing.T) {
+	rule := "location / {\n\ttry_files $uri $uri/ /index.php?$args;\n}\n"
+	c := newFakePanel(t, map[string]http.HandlerFunc{
+		"/files?action=GetFileBody": func(w http.ResponseWriter, r *http.Request) {
+			if r.FormValue("path") != "/www/server/panel/rewrite/nginx/wordpress.conf" {
+				_, _ = w.Write([]byte(`{"status":false,"msg":"指定文件不存在!"}`))
+				return
+			}
+			b, _ := json.Marshal(RespGetFile{Status: true, Data: rule, Encoding: "utf8"})
+			_, _ = w.Write(b)
+		},
+		"/files?action=SaveFileBody": func(w http.ResponseWriter, r *http.Request) {
+			if r.FormValue("path") != "/www/server/panel/vhost/rewrite/a.com.conf" || r.FormValue("data") != rule {
+				t.Errorf("unexpected save %v", r.Form)
+			}
+			_, _ = w.Write([]byte(`{"status":true,"msg":"文件已保存!"}`))
+		},
+	})
+	r, err := c.ApplyRewriteTemplate(ctx, "a.com", "wordpress")
+	if err != nil || !r.Status {
+		t.Fatalf("ApplyRewriteTemplate = %+v, %v", r, err)
+	}
+	if _, err := c.GetRewriteTemplate(ctx, "missing"); err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestSetSiteRewrite(t *testing.T) {
+	files := map[string]string{
+		"/www/server/panel/rewrite/nginx/laravel.conf": "location / {\n\ttry_files $uri $uri/ /index.php$is_args$query_string;\n}\n",
+		"/www/server/panel/vhost/rewrite/a.com.conf":   "",
+	}
+	c := newFakePanel(t, map[string]http.HandlerFunc{
+		"/site?action=GetRewriteList": reply(`{"rewrite":["0.当前","laravel","wordpress"]}`),
+		"/files?action=GetFileBody": func(w http.ResponseWriter, r *http.Request) {
+			body, ok := files[r.FormValue("path")]
+			_ = json.NewEncoder(w).Encode(RespGetFile{Status: ok, Data: body})
+		},
+		"/files?action=SaveFileBody": func(w http.ResponseWriter, r *http.Request) {
+			files[r.FormValue("path")] = r.FormValue("data")
+			_, _ = w.Write([]byte(`{"status":true,"msg":"文件已保存!"}`))
+		},
+	})
+	if _, err := c.SetSiteRewrite(ctx, "a.com", "laravel"); err != nil {
+		t.Fatal(err)
+	}
+	got, err := c.GetSiteRewrite(ctx, "a.com")
+	if err != nil || got != files["/www/server/panel/rewrite/nginx/laravel.conf"] {
+		t.Fatalf("GetSiteRewrite = %q, %v", got, err)
+	}
+	body := "rewrite ^/old$ /new permanent;\n"
+	if _, err := c.SetSiteRewrite(ctx, "a.com", body); err != nil {
+		t.Fatal(err)
+	}
+	if files["/www/server/panel/vhost/rewrite/a.com.conf"] != body {
+		t.Fatalf("body not saved: %q", files["/www/server/panel/vhost/rewrite/a.com.conf"])
+	}
+}
@@ -0,0 +1,40 @@
+package schedule
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	for _, expr := range []string{"", "* * * *", "60 * * * *", "*/0 * * * *", "5-1 * * * *", "a * * * *"} {
+		if _, err := Parse(expr); err == nil {
+			t.Errorf("Parse(%q) expected error", expr)
+		}
+	}
+}
+
+func TestSpec_Next(t *testing.T) {
+	base := time.Date(2024, 5, 1, 10, 7, 30, 0, time.UTC) // 周三
+	cases := map[string]time.Time{
+		"*/15 * * * *":   time.Date(2024, 5, 1, 10, 15, 0, 0, time.UTC),
+		"@daily":         time.Date(2024, 5, 2, 0, 0, 0, 0, time.UTC),
+		"30 2 * * 7":     time.Date(2024, 5, 5, 2, 30, 0, 0, time.UTC),
+		"0 9-17/4 * * *": time.Date(2024, 5, 1, 13, 0, 0, 0, time.UTC),
+		"0 0 1 2 *":      time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC),
+		"0 0 15 * 1":     time.Date(2024, 5, 6, 0, 0, 0, 0, time.UTC), // 日期与星期满足其一
+		"5,10 10 * * *":  time.Date(2024, 5, 1, 10, 10, 0, 0, time.UTC),
+	}
+	for expr, want := range cases {
+		s, err := Parse(expr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := s.Next(base); !got.Equal(want) {
+			t.Errorf("Next(%q) = %s want %s", expr, got, want)
+		}
+	}
+	never, _ := Parse("0 0 31 2 *")
+	if !never.Next(base).IsZero() {
+		t.Error("expected zero time")
+	}
+}
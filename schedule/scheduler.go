@@ -0,0 +1,136 @@
+// Package schedule 在进程内按 cron 表达式定时执行基于 SDK 的任务（批量备份、证书续期检查、磁盘巡检等）
+// 适用于不想额外部署调度系统的小规模场景 同一面板上同时执行的任务数受 Concurrency 限制
+//
+//	s := schedule.New()
+//	_ = s.Add("disk-audit", "*/30 * * * *", clients, func(ctx context.Context, c *bt.Client) error {
+//		_, err := c.CleanupDisk(ctx, bt.CleanupPlan{Threshold: 90, EmptyRecycleBin: true})
+//		return err
+//	})
+//	_ = s.Run(ctx)
+package schedule
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/noahlsl/bt"
+)
+
+// Job 在单个面板上执行的任务
+type Job func(ctx context.Context, c *bt.Client) error
+
+// Result 任务在单个面板上的一次执行结果
+type Result struct {
+	Job      string
+	Panel    string
+	Start    time.Time
+	Duration time.Duration
+	Err      error
+}
+
+type entry struct {
+	name    string
+	spec    *Spec
+	clients []*bt.Client
+	job     Job
+}
+
+// Scheduler 进程内调度器 零值不可用 需通过 New 创建
+type Scheduler struct {
+	// Concurrency 每个面板同时执行的任务数 默认 1 超出时任务排队等待
+	Concurrency int
+	// OnResult 可选 每次执行结束后回调 可能被并发调用
+	OnResult func(Result)
+
+	mu      sync.Mutex
+	entries []*entry
+	sems    map[string]chan struct{} // 以面板地址区分的并发信号量
+	wg      sync.WaitGroup
+}
+
+// New 实例化调度器
+func New() *Scheduler {
+	return &Scheduler{sems: map[string]chan struct{}{}}
+}
+
+// Add 注册任务 spec 为 cron 表达式 任务会在 clients 中的每个面板上分别执行
+func (s *Scheduler) Add(name string, spec string, clients []*bt.Client, job Job) error {
+	if job == nil {
+		return errors.New("schedule: job is nil")
+	}
+	parsed, err := Parse(spec)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	s.entries = append(s.entries, &entry{name: name, spec: parsed, clients: clients, job: job})
+	s.mu.Unlock()
+	return nil
+}
+
+// Run 阻塞执行调度 直到 ctx 取消 返回前等待正在执行的任务结束
+func (s *Scheduler) Run(ctx context.Context) error {
+	for {
+		now := time.Now()
+		next := now.Truncate(time.Minute).Add(time.Minute)
+		timer := time.NewTimer(next.Sub(now))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			s.wg.Wait()
+			return ctx.Err()
+		case <-timer.C:
+			s.runDue(ctx, next)
+		}
+	}
+}
+
+// runDue 启动所有在 t 所在分钟到期的任务
+func (s *Scheduler) runDue(ctx context.Context, t time.Time) {
+	s.mu.Lock()
+	entries := append([]*entry(nil), s.entries...)
+	s.mu.Unlock()
+	for _, e := range entries {
+		if !e.spec.Match(t) {
+			continue
+		}
+		for _, c := range e.clients {
+			s.wg.Add(1)
+			go s.exec(ctx, e, c)
+		}
+	}
+}
+
+func (s *Scheduler) exec(ctx context.Context, e *entry, c *bt.Client) {
+	defer s.wg.Done()
+	sem := s.semaphore(c.BTAddress)
+	select {
+	case sem <- struct{}{}:
+	case <-ctx.Done():
+		return
+	}
+	defer func() { <-sem }()
+	res := Result{Job: e.name, Panel: c.BTAddress, Start: time.Now()}
+	res.Err = e.job(ctx, c)
+	res.Duration = time.Since(res.Start)
+	if s.OnResult != nil {
+		s.OnResult(res)
+	}
+}
+
+func (s *Scheduler) semaphore(panel string) chan struct{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sem, ok := s.sems[panel]
+	if !ok {
+		n := s.Concurrency
+		if n <= 0 {
+			n = 1
+		}
+		sem = make(chan struct{}, n)
+		s.sems[panel] = sem
+	}
+	return sem
+}
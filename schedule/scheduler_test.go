@@ -0,0 +1,54 @@
+package schedule
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/noahlsl/bt"
+)
+
+func TestScheduler_Concurrency(t *testing.T) {
+	a, b := bt.NewClient("http://a:8888", "k"), bt.NewClient("http://b:8888", "k")
+	var running, peak int32
+	var mu sync.Mutex
+	results := map[string]int{}
+	s := New()
+	s.OnResult = func(r Result) {
+		mu.Lock()
+		results[r.Job+"@"+r.Panel]++
+		mu.Unlock()
+	}
+	job := func(ctx context.Context, c *bt.Client) error {
+		if c.BTAddress == "http://a:8888" {
+			n := atomic.AddInt32(&running, 1)
+			for {
+				p := atomic.LoadInt32(&peak)
+				if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+					break
+				}
+			}
+			time.Sleep(10 * time.Millisecond)
+			atomic.AddInt32(&running, -1)
+		}
+		return nil
+	}
+	for _, name := range []string{"backup", "audit", "certs"} {
+		if err := s.Add(name, "0 * * * *", []*bt.Client{a, b}, job); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := s.Add("never", "30 * * * *", []*bt.Client{a}, job); err != nil {
+		t.Fatal(err)
+	}
+	s.runDue(context.Background(), time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC))
+	s.wg.Wait()
+	if peak != 1 {
+		t.Fatalf("peak concurrency on panel a = %d", peak)
+	}
+	if len(results) != 6 || results["never@http://a:8888"] != 0 {
+		t.Fatalf("results %v", results)
+	}
+}
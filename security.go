@@ -0,0 +1,72 @@
+package bt
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// SecurityEvent 面板登录及安全告警日志
+type SecurityEvent struct {
+	ID      int64
+	Type    string    // 面板日志类型 eg. 用户登录
+	Time    time.Time // 面板记录的是服务器本地时间 按客户端本地时区（time.Local）解析 两者不同时需自行换算
+	IP      string    // 来源 IP 不含端口 未记录时为空
+	User    string    // 登录帐号 未记录时为空
+	Success bool      // 日志中不含失败/错误等字样时视为成功
+	Message string    // 去除 HTML 标签后的原始日志
+}
+
+var (
+	htmlTag       = regexp.MustCompile(`<[^>]*>`)
+	logIP         = regexp.MustCompile(`IP[:：]\s*\[?([0-9A-Fa-f.:]+)`)
+	logUser       = regexp.MustCompile(`[帐账]号[:：]\s*([^,，\s]+)`)
+	logFailedWord = []string{"失败", "错误", "fail", "error", "denied"}
+)
+
+// GetSecurityEvents 获取面板日志中的登录及安全相关事件 按时间倒序 p 为页码 limit 为每页条数
+// 事件时间按客户端本地时区解析 与服务器时区不同时可通过 GetTimezone 获取服务器时区后换算
+func (c *Client) GetSecurityEvents(ctx context.Context, p int64, limit int64) ([]SecurityEvent, error) {
+	var dec struct {
+		Data []struct {
+			ID      int64  `json:"id"`
+			Type    string `json:"type"`
+			Log     string `json:"log"`
+			Addtime string `json:"addtime"`
+		} `json:"data"`
+	}
+	err := c.QueryTable("logs").Search("登录").OrderBy("id", true).Page(p).Limit(limit).Into(ctx, &dec)
+	if err != nil {
+		return nil, err
+	}
+	ret := make([]SecurityEvent, 0, len(dec.Data))
+	for _, v := range dec.Data {
+		ret = append(ret, parseSecurityEvent(v.ID, v.Type, v.Log, v.Addtime))
+	}
+	return ret, nil
+}
+
+func parseSecurityEvent(id int64, typ string, log string, addtime string) SecurityEvent {
+	msg := strings.TrimSpace(htmlTag.ReplaceAllString(log, ""))
+	e := SecurityEvent{ID: id, Type: typ, Message: msg, Success: true}
+	e.Time, _ = time.ParseInLocation("2006-01-02 15:04:05", addtime, time.Local)
+	if m := logIP.FindStringSubmatch(msg); m != nil {
+		e.IP = m[1]
+		// IPv4 带端口时去除端口
+		if strings.Count(e.IP, ":") == 1 {
+			e.IP = e.IP[:strings.IndexByte(e.IP, ':')]
+		}
+	}
+	if m := logUser.FindStringSubmatch(msg); m != nil {
+		e.User = m[1]
+	}
+	lower := strings.ToLower(msg)
+	for _, w := range logFailedWord {
+		if strings.Contains(lower, w) {
+			e.Success = false
+			break
+		}
+	}
+	return e
+}
@@ -0,0 +1,33 @@
+package bt
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestGetSecurityEvents(t *testing.T) {
+	c := newFakePanel(t, map[string]http.HandlerFunc{
+		"/data?action=getData": func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Query().Get("table") != "logs" || r.FormValue("search") != "登录" || r.FormValue("order") != "id desc" {
+				t.Errorf("unexpected request %s %v", r.URL, r.Form)
+			}
+			_, _ = w.Write([]byte(`{"data":[
+				{"id":9,"type":"用户登录","log":"<a style='color: red;'>密码错误</a>,帐号:admin,登录IP:1.2.3.4:50123","addtime":"2024-05-01 10:00:00"},
+				{"id":8,"type":"用户登录","log":"登录成功,帐号:admin,登录IP:2001:db8::1","addtime":"2024-05-01 09:00:00"}
+			]}`))
+		},
+	})
+	r, err := c.GetSecurityEvents(ctx, 1, 20)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(r) != 2 {
+		t.Fatalf("got %+v", r)
+	}
+	if r[0].Success || r[0].IP != "1.2.3.4" || r[0].User != "admin" || r[0].Message != "密码错误,帐号:admin,登录IP:1.2.3.4:50123" {
+		t.Errorf("unexpected %+v", r[0])
+	}
+	if !r[1].Success || r[1].IP != "2001:db8::1" || r[1].Time.Hour() != 9 {
+		t.Errorf("unexpected %+v", r[1])
+	}
+}
@@ -0,0 +1,24 @@
+package bt
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestNowSignature(t *testing.T) {
+	var token, reqTime string
+	c := newFakePanel(t, map[string]http.HandlerFunc{
+		"/system?action=GetSystemTotal": func(w http.ResponseWriter, r *http.Request) {
+			token, reqTime = r.FormValue("request_token"), r.FormValue("request_time")
+			_, _ = w.Write([]byte(`{}`))
+		},
+	})
+	c.Now = func() time.Time { return time.Unix(1700000000, 0) }
+	if _, err := c.GetSystemTotal(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if reqTime != "1700000000" || token != MD5("1700000000"+MD5("test-key")) {
+		t.Fatalf("time %q token %q", reqTime, token)
+	}
+}
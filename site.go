@@ -0,0 +1,194 @@
+package bt
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// siteKey 读取网站表中指定字段 eg. name/path
+func (c *Client) siteKey(ctx context.Context, id int64, key string) (string, error) {
+	return c.tableKey(ctx, "sites", id, key)
+}
+
+// tableKey 读取面板数据表中指定记录的字段
+func (c *Client) tableKey(ctx context.Context, table string, id int64, key string) (string, error) {
+	data := map[string][]string{
+		"id":  {strconv.FormatInt(id, 10)},
+		"key": {key},
+	}
+	endpoint := "/data?action=getKey&table=" + table
+	resp, err := c.btAPI(ctx, data, endpoint)
+	if err != nil {
+		return "", err
+	}
+	// 字段为字符串或数字 记录不存在等错误时面板返回通用消息
+	var dec interface{}
+	if err := c.unmarshal(ctx, resp, &dec); err != nil {
+		return "", err
+	}
+	switch v := dec.(type) {
+	case string:
+		return v, nil
+	case float64:
+		return strings.TrimSpace(string(resp)), nil
+	case map[string]interface{}:
+		if _, err := c.decodeResult(resp, endpoint); err != nil {
+			return "", err
+		}
+	}
+	var ret string
+	return "", newDecodeError(&ret, resp, errors.New("unexpected "+table+"."+key+" value"))
+}
+
+// SitePassword 网站密码访问状态
+type SitePassword struct {
+	Enabled  bool
+	Username string // 未开启时为空
+}
+
+// GetHasPwd 获取网站密码访问状态及用户名
+func (c *Client) GetHasPwd(ctx context.Context, id int64) (SitePassword, error) {
+	name, err := c.siteKey(ctx, id, "name")
+	if err != nil {
+		return SitePassword{}, err
+	}
+	path, err := c.siteKey(ctx, id, "path")
+	if err != nil {
+		return SitePassword{}, err
+	}
+	ini, err := c.GetDirUserINI(ctx, id, path)
+	if err != nil {
+		return SitePassword{}, err
+	}
+	if !ini.Pass {
+		return SitePassword{}, nil
+	}
+	ret := SitePassword{Enabled: true}
+	// 面板将 htpasswd 文件保存在 /www/server/pass/<网站名>.pass 格式为 user:hash
+	file, err := c.GetFile(ctx, "/www/server/pass/"+name+".pass")
+	if err != nil {
+		return ret, err
+	}
+	if user, _, ok := strings.Cut(strings.TrimSpace(file.Data), ":"); ok {
+		ret.Username = user
+	}
+	return ret, nil
+}
+
+// linuxUserName 合法的 Linux 用户名
+var linuxUserName = regexp.MustCompile(`^[a-z_][a-z0-9_-]{0,31}$`)
+
+// GetSiteUser 获取网站 PHP 进程的运行用户（需面板支持按网站设置运行用户）
+func (c *Client) GetSiteUser(ctx context.Context, siteName string) (RespSiteUser, error) {
+	data := map[string][]string{
+		"siteName": {siteName},
+	}
+	resp, err := c.btAPI(ctx, data, "/site?action=GetSiteRunUser")
+	if err != nil {
+		return RespSiteUser{}, err
+	}
+	var dec RespSiteUser
+	if err := c.unmarshal(ctx, resp, &dec); err != nil {
+		return RespSiteUser{}, err
+	}
+	return dec, nil
+}
+
+// SetSiteUser 设置网站 PHP 进程的运行用户 用户需已存在于系统中
+func (c *Client) SetSiteUser(ctx context.Context, siteName string, user string) (RespMSG, error) {
+	if !linuxUserName.MatchString(user) {
+		return RespMSG{}, errors.New("invalid user name: " + user)
+	}
+	data := map[string][]string{
+		"siteName": {siteName},
+		"user":     {user},
+	}
+	return c.btResult(ctx, data, "/site?action=SetSiteRunUser")
+}
+
+var (
+	orderClause = regexp.MustCompile(`^[A-Za-z_]+( (asc|desc))?$`)
+	jsFuncName  = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$]*$`)
+)
+
+// form 构造 GetSites 的请求参数 params 为 nil 时使用默认值 未设置的可选参数不发送
+func (params *ReqSites) form() (map[string][]string, error) {
+	if params == nil {
+		params = &ReqSites{}
+	}
+	p, limit := params.P, params.Limit
+	if p <= 0 {
+		p = 1
+	}
+	if limit <= 0 {
+		limit = 20
+	}
+	data := map[string][]string{
+		"p":     {strconv.FormatInt(p, 10)},
+		"limit": {strconv.FormatInt(limit, 10)},
+	}
+	if params.Type != 0 {
+		data["type"] = []string{strconv.FormatInt(params.Type, 10)}
+	}
+	if params.Order != "" {
+		if !orderClause.MatchString(params.Order) {
+			return nil, errors.New("invalid order: " + params.Order)
+		}
+		data["order"] = []string{params.Order}
+	}
+	if params.ToJS != "" {
+		if !jsFuncName.MatchString(params.ToJS) {
+			return nil, errors.New("invalid tojs: " + params.ToJS)
+		}
+		data["tojs"] = []string{params.ToJS}
+	}
+	if params.Search != "" {
+		data["search"] = []string{params.Search}
+	}
+	return data, nil
+}
+
+// RunPathOptions 网站运行目录设置
+type RunPathOptions struct {
+	Current string   // 当前运行目录 eg. / 或 /public
+	Options []string // 可选的运行目录
+}
+
+// dirUserINI 按网站 ID 获取网站目录相关设置
+func (c *Client) dirUserINI(ctx context.Context, id int64) (RespUserINI, error) {
+	path, err := c.siteKey(ctx, id, "path")
+	if err != nil {
+		return RespUserINI{}, err
+	}
+	return c.GetDirUserINI(ctx, id, path)
+}
+
+// GetRunPathOptions 获取网站当前运行目录及可选的运行目录
+func (c *Client) GetRunPathOptions(ctx context.Context, id int64) (RunPathOptions, error) {
+	ini, err := c.dirUserINI(ctx, id)
+	if err != nil {
+		return RunPathOptions{}, err
+	}
+	return RunPathOptions{Current: ini.RunPath.RunPath, Options: ini.RunPath.Dirs}, nil
+}
+
+// GetLogsStatus 获取网站访问日志是否开启
+func (c *Client) GetLogsStatus(ctx context.Context, id int64) (bool, error) {
+	ini, err := c.dirUserINI(ctx, id)
+	if err != nil {
+		return false, err
+	}
+	return ini.Logs, nil
+}
+
+// GetCrossSiteProtection 获取网站防跨站攻击（open_basedir）是否开启
+func (c *Client) GetCrossSiteProtection(ctx context.Context, id int64) (bool, error) {
+	ini, err := c.dirUserINI(ctx, id)
+	if err != nil {
+		return false, err
+	}
+	return ini.Userini, nil
+}
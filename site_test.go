@@ -0,0 +1,178 @@
+package bt
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestGetHasPwd(t *testing.T) {
+	c := newFakePanel(t, map[string]http.HandlerFunc{
+		"/data?action=getKey": func(w http.ResponseWriter, r *http.Request) {
+			switch r.FormValue("key") {
+			case "name":
+				_, _ = w.Write([]byte(`"w1.hao.com"`))
+			case "path":
+				_, _ = w.Write([]byte(`"/www/wwwroot/w1.hao.com"`))
+			}
+		},
+		"/site?action=GetDirUserINI": reply(`{"pass":true,"logs":true,"userini":true}`),
+		"/files?action=GetFileBody": func(w http.ResponseWriter, r *http.Request) {
+			if r.FormValue("path") != "/www/server/pass/w1.hao.com.pass" {
+				t.Errorf("unexpected path %q", r.FormValue("path"))
+			}
+			_, _ = w.Write([]byte(`{"status":true,"data":"admin:$apr1$x$y\n"}`))
+		},
+	})
+	r, err := c.GetHasPwd(ctx, 11)
+	if err != nil || !r.Enabled || r.Username != "admin" {
+		t.Fatalf("GetHasPwd = %+v, %v", r, err)
+	}
+}
+
+func TestSetSiteExpiration(t *testing.T) {
+	var edate string
+	c := newFakePanel(t, map[string]http.HandlerFunc{
+		"/site?action=SetEdate": func(w http.ResponseWriter, r *http.Request) {
+			edate = r.FormValue("edate")
+			_, _ = w.Write([]byte(`{"status":true,"msg":"设置成功"}`))
+		},
+	})
+	if _, err := c.SetSiteExpiration(ctx, 1, time.Time{}); err == nil {
+		t.Fatal("expected error for zero time")
+	}
+	if _, err := c.SetSiteExpiration(ctx, 1, time.Now().AddDate(0, 0, -2)); err == nil {
+		t.Fatal("expected error for past date")
+	}
+	if _, err := c.SetSiteExpiration(ctx, 1, time.Date(2099, 1, 2, 15, 0, 0, 0, time.UTC)); err != nil || edate != "2099-01-02" {
+		t.Fatalf("edate %q err %v", edate, err)
+	}
+	if _, err := c.ClearSiteExpiration(ctx, 1); err != nil || edate != SiteNeverExpires {
+		t.Fatalf("edate %q err %v", edate, err)
+	}
+}
+
+func TestReqSites_form(t *testing.T) {
+	var zero *ReqSites
+	data, err := zero.form()
+	if err != nil || len(data) != 2 || data["p"][0] != "1" || data["limit"][0] != "20" {
+		t.Fatalf("form = %v, %v", data, err)
+	}
+	data, err = (&ReqSites{P: 2, Limit: 50, Type: -1, Order: "id desc", Search: "a.com"}).form()
+	if err != nil || data["type"][0] != "-1" || data["order"][0] != "id desc" || data["search"][0] != "a.com" {
+		t.Fatalf("form = %v, %v", data, err)
+	}
+	if _, err := (&ReqSites{Order: "id; drop"}).form(); err == nil {
+		t.Fatal("expected error")
+	}
+	if _, err := (&ReqSites{ToJS: "get(1)"}).form(); err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestGetRunPathOptions(t *testing.T) {
+	c := newFakePanel(t, map[string]http.HandlerFunc{
+		"/data?action=getKey": reply(`"/www/wwwroot/a.com"`),
+		"/site?action=GetDirUserINI": func(w http.ResponseWriter, r *http.Request) {
+			if r.FormValue("path") != "/www/wwwroot/a.com" {
+				t.Errorf("unexpected form %v", r.Form)
+			}
+			_, _ = w.Write([]byte(`{"pass":false,"logs":true,"userini":true,"runPath":{"dirs":["/","/public"],"runPath":"/public"}}`))
+		},
+	})
+	r, err := c.GetRunPathOptions(ctx, 1)
+	if err != nil || r.Current != "/public" || len(r.Options) != 2 {
+		t.Fatalf("GetRunPathOptions = %+v, %v", r, err)
+	}
+	if on, err := c.GetLogsStatus(ctx, 1); err != nil || !on {
+		t.Fatalf("GetLogsStatus = %v, %v", on, err)
+	}
+	if on, err := c.GetCrossSiteProtection(ctx, 1); err != nil || !on {
+		t.Fatalf("GetCrossSiteProtection = %v, %v", on, err)
+	}
+}
+
+func TestSiteUser(t *testing.T) {
+	var set string
+	c := newFakePanel(t, map[string]http.HandlerFunc{
+		"/site?action=GetSiteRunUser": func(w http.ResponseWriter, r *http.Request) {
+			if r.FormValue("siteName") != "w1.hao.com" {
+				t.Errorf("unexpected form %v", r.Form)
+			}
+			_, _ = w.Write([]byte(`{"user":"www","users":["www","deploy"]}`))
+		},
+		"/site?action=SetSiteRunUser": func(w http.ResponseWriter, r *http.Request) {
+			set = r.FormValue("siteName") + ":" + r.FormValue("user")
+			if r.FormValue("user") == "nobody2" {
+				_, _ = w.Write([]byte(`{"status":false,"msg":"指定用户不存在"}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"status":true,"msg":"设置成功"}`))
+		},
+	})
+	u, err := c.GetSiteUser(ctx, "w1.hao.com")
+	if err != nil || u.User != "www" || len(u.Users) != 2 || u.Users[1] != "deploy" {
+		t.Fatalf("GetSiteUser = %+v, %v", u, err)
+	}
+	if _, err := c.SetSiteUser(ctx, "w1.hao.com", "deploy"); err != nil || set != "w1.hao.com:deploy" {
+		t.Fatalf("SetSiteUser = %v, sent %q", err, set)
+	}
+	var apiErr *APIError
+	if _, err := c.SetSiteUser(ctx, "w1.hao.com", "nobody2"); !errors.As(err, &apiErr) || apiErr.Endpoint != "/site?action=SetSiteRunUser" {
+		t.Errorf("SetSiteUser error %v", err)
+	}
+	set = ""
+	if _, err := c.SetSiteUser(ctx, "w1.hao.com", "root; rm -rf /"); err == nil || set != "" {
+		t.Error("invalid user name sent to panel")
+	}
+}
+
+func TestSiteMutationErrors(t *testing.T) {
+	c := newFakePanel(t, map[string]http.HandlerFunc{
+		"/site?action=SiteStop":  reply(`{"status":false,"msg":"指定站点不存在!"}`),
+		"/site?action=SiteStart": func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusBadGateway) },
+		"/data?action=setPs":     reply(`{"status":true,"msg":"修改成功"}`),
+	})
+	r, err := c.StopSite(ctx, 1, "a.com")
+	if err == nil || err.Error() != "指定站点不存在!" || r.Msg != "指定站点不存在!" {
+		t.Errorf("StopSite = %+v, %v", r, err)
+	}
+	if _, err := c.StartSite(ctx, 1, "a.com"); err == nil || err.Error() != "502 Bad Gateway" {
+		t.Errorf("StartSite = %v", err)
+	}
+	if r, err := c.SetSitePS(ctx, 1, "note"); err != nil || !r.Status {
+		t.Errorf("SetSitePS = %+v, %v", r, err)
+	}
+}
+
+func TestSiteKey(t *testing.T) {
+	c := newFakePanel(t, map[string]http.HandlerFunc{
+		"/data?action=getKey": func(w http.ResponseWriter, r *http.Request) {
+			switch r.FormValue("id") {
+			case "1":
+				_, _ = w.Write([]byte(`"w1.hao.com"`))
+			case "2":
+				_, _ = w.Write([]byte(`12`))
+			case "3":
+				_, _ = w.Write([]byte(`{"status":false,"msg":"指定记录不存在"}`))
+			default:
+				_, _ = w.Write([]byte(`<html>404</html>`))
+			}
+		},
+	})
+	if name, err := c.siteKey(ctx, 1, "name"); err != nil || name != "w1.hao.com" {
+		t.Fatalf("siteKey(1) = %q, %v", name, err)
+	}
+	if pid, err := c.tableKey(ctx, "backup", 2, "pid"); err != nil || pid != "12" {
+		t.Fatalf("tableKey(2) = %q, %v", pid, err)
+	}
+	var apiErr *APIError
+	if name, err := c.siteKey(ctx, 3, "name"); !errors.As(err, &apiErr) || name != "" {
+		t.Fatalf("siteKey(3) = %q, %v", name, err)
+	}
+	var decErr *DecodeError
+	if name, err := c.siteKey(ctx, 4, "name"); !errors.As(err, &decErr) || name != "" {
+		t.Fatalf("siteKey(4) = %q, %v", name, err)
+	}
+}
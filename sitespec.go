@@ -0,0 +1,219 @@
+package bt
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// SiteSpecVersion SiteSpec 结构版本 结构有不兼容变化时递增
+const SiteSpecVersion = 1
+
+// SiteSpec 网站配置的可移植描述 可序列化为 JSON/YAML 存入 git 作为配置备份
+type SiteSpec struct {
+	SpecVersion int               `json:"spec_version"`
+	ID          int64             `json:"id"`
+	Name        string            `json:"name"`
+	Path        string            `json:"path"`
+	RunPath     string            `json:"run_path"`
+	PHPVersion  string            `json:"php_version"` // 00 为纯静态
+	PS          string            `json:"ps"`
+	Edate       string            `json:"edate"` // 0000-00-00 为永久
+	Domains     []SiteSpecDomain  `json:"domains"`
+	Rewrite     string            `json:"rewrite"`
+	SSL         *SiteSpecSSL      `json:"ssl,omitempty"`
+	Limits      *RespLimitNet     `json:"limits,omitempty"` // 未开启流量限制时为空
+	Crontabs    []SiteSpecCrontab `json:"crontabs,omitempty"`
+}
+
+// SiteSpecDomain 网站绑定的域名
+type SiteSpecDomain struct {
+	Name string `json:"name"`
+	Port int    `json:"port"`
+}
+
+// SiteSpecSSL 网站 SSL 状态 出于安全考虑不包含私钥
+type SiteSpecSSL struct {
+	Enabled    bool   `json:"enabled"`
+	ForceHTTPS bool   `json:"force_https"`
+	Cert       string `json:"cert"`
+}
+
+// SiteSpecCrontab 与网站相关的计划任务
+type SiteSpecCrontab struct {
+	ID       int64        `json:"id"`
+	Name     string       `json:"name"`
+	SType    string       `json:"stype"`
+	Schedule CronSchedule `json:"schedule"`
+}
+
+// DescribeSite 汇总网站的域名、PHP 版本、运行目录、伪静态、SSL、流量限制和相关计划任务
+// 其中 SSL、流量限制、计划任务等可选项在当前面板不支持时会被跳过
+func (c *Client) DescribeSite(ctx context.Context, id int64) (SiteSpec, error) {
+	name, err := c.siteKey(ctx, id, "name")
+	if err != nil {
+		return SiteSpec{}, err
+	}
+	if name == "" {
+		return SiteSpec{}, errors.New("site not found: " + strconv.FormatInt(id, 10))
+	}
+	spec := SiteSpec{SpecVersion: SiteSpecVersion, ID: id, Name: name}
+	var sites RespSites
+	if err := c.QueryTable("sites").Search(name).Limit(100).Into(ctx, &sites); err != nil {
+		return SiteSpec{}, err
+	}
+	for _, s := range sites.Data {
+		if int64(s.ID) == id {
+			spec.Path, spec.PS, spec.Edate = s.Path, s.Ps, s.Edate
+		}
+	}
+	domains, err := c.GetSiteDomains(ctx, strconv.FormatInt(id, 10))
+	if err != nil {
+		return SiteSpec{}, err
+	}
+	for _, d := range domains {
+		if int64(d.Pid) == id {
+			spec.Domains = append(spec.Domains, SiteSpecDomain{Name: d.Name, Port: d.Port})
+		}
+	}
+	ini, err := c.GetDirUserINI(ctx, id, spec.Path)
+	if err != nil {
+		return SiteSpec{}, err
+	}
+	spec.RunPath = ini.RunPath.RunPath
+	if spec.PHPVersion, err = c.sitePHPVersion(ctx, spec.Name); err != nil {
+		return SiteSpec{}, err
+	}
+	// 以下配置在部分面板或 Web 服务器上不受支持 面板返回失败时跳过 网络及 ctx 错误直接返回
+	rewrite, err := c.GetFile(ctx, "/www/server/panel/vhost/rewrite/"+spec.Name+".conf")
+	if err := panelUnsupported(err); err != nil {
+		return SiteSpec{}, err
+	}
+	if err == nil && rewrite.Status {
+		spec.Rewrite = rewrite.Data
+	}
+	ssl, err := c.GetSSLInfo(ctx, spec.Name)
+	if err := panelUnsupported(err); err != nil {
+		return SiteSpec{}, err
+	}
+	if err == nil {
+		spec.SSL = &SiteSpecSSL{Enabled: ssl.Status, ForceHTTPS: ssl.HTTPToHTTPS, Cert: ssl.Cert}
+	}
+	limit, err := c.GetLimitNet(ctx, id)
+	if err := panelUnsupported(err); err != nil {
+		return SiteSpec{}, err
+	}
+	if err == nil && (limit.Perserver != 0 || limit.Perip != 0 || limit.LimitRate != 0) {
+		spec.Limits = &limit
+	}
+	crons, err := c.GetCrontabList(ctx)
+	if err := panelUnsupported(err); err != nil {
+		return SiteSpec{}, err
+	}
+	for _, t := range crons {
+		if t.SName == spec.Name {
+			spec.Crontabs = append(spec.Crontabs, SiteSpecCrontab{
+				ID:       t.ID,
+				Name:     t.Name,
+				SType:    t.SType,
+				Schedule: t.Schedule(),
+			})
+		}
+	}
+	return spec, nil
+}
+
+// panelUnsupported 面板以 status 为 false 等方式拒绝请求时返回 nil 其他错误原样返回
+func panelUnsupported(err error) error {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && apiErr.HTTPStatus < http.StatusInternalServerError {
+		return nil
+	}
+	return err
+}
+
+func atoi64(s string) int64 {
+	n, _ := strconv.ParseInt(strings.TrimSpace(s), 10, 64)
+	return n
+}
+
+// JSON 序列化为带缩进的 JSON
+func (s SiteSpec) JSON() ([]byte, error) {
+	return json.MarshalIndent(s, "", "  ")
+}
+
+// YAML 序列化为 YAML 字段名与 JSON 一致
+func (s SiteSpec) YAML() ([]byte, error) {
+	b, err := json.Marshal(s)
+	if err != nil {
+		return nil, err
+	}
+	var v interface{}
+	if err := json.Unmarshal(b, &v); err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	writeYAML(&buf, v, 0)
+	return buf.Bytes(), nil
+}
+
+// writeYAML 将 JSON 解析出的通用值写为块状 YAML 字符串统一使用双引号 多行字符串使用 | 块
+func writeYAML(buf *bytes.Buffer, v interface{}, indent int) {
+	pad := strings.Repeat("  ", indent)
+	switch val := v.(type) {
+	case map[string]interface{}:
+		keys := make([]string, 0, len(val))
+		for k := range val {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			buf.WriteString(pad + k + ":")
+			writeYAMLValue(buf, val[k], indent)
+		}
+	case []interface{}:
+		for _, item := range val {
+			buf.WriteString(pad + "-")
+			writeYAMLValue(buf, item, indent)
+		}
+	}
+}
+
+func writeYAMLValue(buf *bytes.Buffer, v interface{}, indent int) {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		if len(val) == 0 {
+			buf.WriteString(" {}\n")
+			return
+		}
+		buf.WriteString("\n")
+		writeYAML(buf, val, indent+1)
+	case []interface{}:
+		if len(val) == 0 {
+			buf.WriteString(" []\n")
+			return
+		}
+		buf.WriteString("\n")
+		writeYAML(buf, val, indent+1)
+	case string:
+		if strings.Contains(val, "\n") {
+			pad := strings.Repeat("  ", indent+1)
+			buf.WriteString(" |-\n")
+			for _, line := range strings.Split(strings.TrimRight(val, "\n"), "\n") {
+				buf.WriteString(pad + line + "\n")
+			}
+			return
+		}
+		q, _ := json.Marshal(val)
+		buf.WriteString(" " + string(q) + "\n")
+	case nil:
+		buf.WriteString(" null\n")
+	default:
+		b, _ := json.Marshal(val)
+		buf.WriteString(" " + string(b) + "\n")
+	}
+}
@@ -0,0 +1,118 @@
+package bt
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+// describeSiteHandlers DescribeSite 所需的面板接口 override 中的接口替换默认实现
+func describeSiteHandlers(override map[string]http.HandlerFunc) map[string]http.HandlerFunc {
+	handlers := map[string]http.HandlerFunc{
+		"/data?action=getKey": reply(`"w1.hao.com"`),
+		"/data?action=getData": func(w http.ResponseWriter, r *http.Request) {
+			switch r.URL.Query().Get("table") {
+			case "sites":
+				_, _ = w.Write([]byte(`{"data":[{"id":11,"name":"w1.hao.com","path":"/www/wwwroot/w1.hao.com","ps":"test","edate":"0000-00-00"}]}`))
+			case "domain":
+				_, _ = w.Write([]byte(`[{"pid":11,"name":"w1.hao.com","port":80},{"pid":12,"name":"other.com","port":80}]`))
+			}
+		},
+		"/site?action=GetDirUserINI":     reply(`{"runPath":{"runPath":"/public"}}`),
+		"/site?action=GetSitePHPVersion": reply(`{"phpversion":"74"}`),
+		"/files?action=GetFileBody":      reply(`{"status":true,"data":"location / {\n  try_files $uri /index.php;\n}\n"}`),
+		"/site?action=GetSSL":            reply(`{"status":true,"httpTohttps":true,"csr":"CERT"}`),
+		"/site?action=GetLimitNet":       reply(`{"perserver":0,"perip":0,"limit_rate":0}`),
+		"/crontab?action=GetCrontab":     reply(`[{"id":3,"name":"备份网站[w1.hao.com]","type":"day","where1":"","where_hour":"2","where_minute":"30","sType":"site","sName":"w1.hao.com"}]`),
+	}
+	for k, v := range override {
+		handlers[k] = v
+	}
+	return handlers
+}
+
+func TestDescribeSite(t *testing.T) {
+	c := newFakePanel(t, describeSiteHandlers(nil))
+	spec, err := c.DescribeSite(ctx, 11)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if spec.Path != "/www/wwwroot/w1.hao.com" || spec.RunPath != "/public" || spec.PHPVersion != "74" ||
+		len(spec.Domains) != 1 || spec.SSL == nil || !spec.SSL.ForceHTTPS || spec.Limits != nil ||
+		len(spec.Crontabs) != 1 || spec.Crontabs[0].Schedule != Daily(2, 30) {
+		t.Fatalf("unexpected spec %+v", spec)
+	}
+	y, err := spec.YAML()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `crontabs:
+  -
+    id: 3
+    name: "备份网站[w1.hao.com]"
+    schedule:
+      hour: 2
+      minute: 30
+      type: "day"
+      week: 0
+      where1: 0
+    stype: "site"
+domains:
+  -
+    name: "w1.hao.com"
+    port: 80
+edate: "0000-00-00"
+id: 11
+name: "w1.hao.com"
+path: "/www/wwwroot/w1.hao.com"
+php_version: "74"
+ps: "test"
+rewrite: |-
+  location / {
+    try_files $uri /index.php;
+  }
+run_path: "/public"
+spec_version: 1
+ssl:
+  cert: "CERT"
+  enabled: true
+  force_https: true
+`
+	if string(y) != want {
+		t.Fatalf("yaml:\n%s", y)
+	}
+}
+
+func TestDescribeSite_Errors(t *testing.T) {
+	// 旧版面板没有对应接口时跳过该配置
+	c := newFakePanel(t, describeSiteHandlers(map[string]http.HandlerFunc{
+		"/site?action=GetLimitNet": http.NotFound,
+	}))
+	spec, err := c.DescribeSite(ctx, 11)
+	if err != nil || spec.Limits != nil || spec.SSL == nil || len(spec.Crontabs) != 1 {
+		t.Fatalf("DescribeSite = %+v, %v", spec, err)
+	}
+
+	// 网络错误不能被当作没有配置
+	c = newFakePanel(t, describeSiteHandlers(map[string]http.HandlerFunc{
+		"/site?action=GetLimitNet": func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, "upstream down", http.StatusBadGateway)
+		},
+	}))
+	var apiErr *APIError
+	if _, err := c.DescribeSite(ctx, 11); !errors.As(err, &apiErr) || apiErr.HTTPStatus != http.StatusBadGateway {
+		t.Fatalf("DescribeSite error %v", err)
+	}
+
+	runCtx, cancel := context.WithCancel(ctx)
+	c = newFakePanel(t, describeSiteHandlers(map[string]http.HandlerFunc{
+		"/site?action=GetSSL": func(w http.ResponseWriter, r *http.Request) {
+			cancel()
+			_, _ = w.Write([]byte(`{"status":true}`))
+		},
+	}))
+	if _, err := c.DescribeSite(runCtx, 11); !errors.Is(err, context.Canceled) {
+		t.Fatalf("DescribeSite error %v", err)
+	}
+}
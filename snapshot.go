@@ -0,0 +1,115 @@
+package bt
+
+import (
+	"context"
+	"io"
+	"time"
+)
+
+// SnapshotVersion ServerSnapshot 结构版本 结构有不兼容变化时递增
+const SnapshotVersion = 1
+
+// ServerSnapshot 服务器状态快照 用于定期归档或比较两次快照之间的变化
+type ServerSnapshot struct {
+	SnapshotVersion int              `json:"snapshot_version"`
+	Time            time.Time        `json:"time"`  // 开始采集的时间
+	Panel           string           `json:"panel"` // 面板地址
+	Versions        SnapshotVersions `json:"versions"`
+	System          SystemTotal      `json:"system"`
+	Disks           DiskInfo         `json:"disks"`
+	Network         NetWork          `json:"network"`
+	Sites           []SnapshotSite   `json:"sites"`
+}
+
+// SnapshotVersions 面板及已安装软件的版本
+type SnapshotVersions struct {
+	Panel    string            `json:"panel"`
+	PHP      []string          `json:"php"`                // 已安装的 PHP 版本 eg. 74
+	Software map[string]string `json:"software,omitempty"` // 软件商店中已安装的软件 键为软件名 值为版本 面板不支持时为空
+}
+
+// SnapshotSite 网站摘要
+type SnapshotSite struct {
+	ID         int    `json:"id"`
+	Name       string `json:"name"`
+	Path       string `json:"path"`
+	Running    bool   `json:"running"`
+	PHPVersion string `json:"php_version"`
+	Domains    int    `json:"domains"` // 域名数量
+	Edate      string `json:"edate"`   // 0000-00-00 为永久
+}
+
+// Snapshot 采集服务器状态快照 包含系统统计、磁盘、网络、网站摘要及版本信息
+// 软件列表在当前面板不支持时会被跳过
+func (c *Client) Snapshot(ctx context.Context) (ServerSnapshot, error) {
+	ret := ServerSnapshot{
+		SnapshotVersion: SnapshotVersion,
+		Time:            time.Now(),
+		Panel:           c.BTAddress,
+	}
+	var err error
+	if ret.System, err = c.GetSystemTotal(ctx); err != nil {
+		return ServerSnapshot{}, err
+	}
+	if ret.Disks, err = c.GetDiskInfo(ctx); err != nil {
+		return ServerSnapshot{}, err
+	}
+	if ret.Network, err = c.GetNetWork(ctx); err != nil {
+		return ServerSnapshot{}, err
+	}
+	sites, err := c.ListAllSites(ctx, "")
+	if err != nil {
+		return ServerSnapshot{}, err
+	}
+	ret.Sites = make([]SnapshotSite, 0, len(sites))
+	for _, s := range sites {
+		ret.Sites = append(ret.Sites, SnapshotSite{
+			ID:         s.ID,
+			Name:       s.Name,
+			Path:       s.Path,
+			Running:    s.Status == "1",
+			PHPVersion: s.PHPVersion,
+			Domains:    s.Domain,
+			Edate:      s.Edate,
+		})
+	}
+	ret.Versions.Panel = ret.System.Version
+	php, err := c.GetPHPVersion(ctx)
+	if err != nil {
+		return ServerSnapshot{}, err
+	}
+	ret.Versions.PHP = []string{}
+	for _, v := range php {
+		if v.Version != "00" {
+			ret.Versions.PHP = append(ret.Versions.PHP, v.Version)
+		}
+	}
+	if soft, err := c.GetSoftList(ctx, ""); err == nil {
+		ret.Versions.Software = map[string]string{}
+		for _, s := range soft.List.Data {
+			if s.Setup {
+				ret.Versions.Software[s.Name] = s.Version
+			}
+		}
+	}
+	return ret, nil
+}
+
+// JSON 序列化为带缩进的 JSON
+func (s ServerSnapshot) JSON() ([]byte, error) {
+	return json.MarshalIndent(s, "", "  ")
+}
+
+// SnapshotServer 采集服务器状态快照并以 JSON 写入 w
+func (c *Client) SnapshotServer(ctx context.Context, w io.Writer) error {
+	snap, err := c.Snapshot(ctx)
+	if err != nil {
+		return err
+	}
+	b, err := snap.JSON()
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(append(b, '\n'))
+	return err
+}
@@ -0,0 +1,35 @@
+package bt
+
+import (
+	"bytes"
+	"net/http"
+	"testing"
+)
+
+func TestSnapshotServer(t *testing.T) {
+	c := newFakePanel(t, map[string]http.HandlerFunc{
+		"/system?action=GetSystemTotal": reply(`{"system":"Ubuntu 22.04.3 LTS","version":"8.0.1","cpuNum":2}`),
+		"/system?action=GetDiskInfo":    reply(`[{"path":"/","size":["40G","12G","28G","30%"]}]`),
+		"/system?action=GetNetWork":     reply(`{"up":1.5,"down":2.5}`),
+		"/data?action=getData":          reply(`{"data":[{"id":1,"name":"w1.hao.com","status":"1","domain":2,"php_version":"7.4"}]}`),
+		"/site?action=GetPHPVersion":    reply(`[{"version":"00","name":"纯静态"},{"version":"74","name":"PHP-74"}]`),
+		"/plugin?action=get_soft_list":  reply(`{"list":{"data":[{"name":"nginx","version":"1.24","setup":true},{"name":"redis","setup":false}]}}`),
+	})
+	var buf bytes.Buffer
+	if err := c.SnapshotServer(ctx, &buf); err != nil {
+		t.Fatal(err)
+	}
+	var snap ServerSnapshot
+	if err := json.Unmarshal(buf.Bytes(), &snap); err != nil {
+		t.Fatal(err)
+	}
+	if snap.SnapshotVersion != SnapshotVersion || snap.Versions.Panel != "8.0.1" || len(snap.Disks) != 1 || snap.Network.Down != 2.5 {
+		t.Errorf("snapshot %+v", snap)
+	}
+	if len(snap.Sites) != 1 || !snap.Sites[0].Running || snap.Sites[0].Domains != 2 {
+		t.Errorf("sites %+v", snap.Sites)
+	}
+	if len(snap.Versions.PHP) != 1 || snap.Versions.PHP[0] != "74" || len(snap.Versions.Software) != 1 || snap.Versions.Software["nginx"] != "1.24" {
+		t.Errorf("versions %+v", snap.Versions)
+	}
+}
@@ -0,0 +1,134 @@
+package bt
+
+import (
+	"context"
+	"sort"
+	"strings"
+)
+
+// GetSoftList 获取软件商店列表 query 为搜索关键字 为空时返回全部
+func (c *Client) GetSoftList(ctx context.Context, query string) (SoftList, error) {
+	data := map[string][]string{
+		"p":     {"1"},
+		"type":  {"0"},
+		"row":   {"1000"},
+		"query": {query},
+	}
+	resp, err := c.cached(ctx, "GetSoftList:"+query, func() ([]byte, error) {
+		return c.btAPI(ctx, data, "/plugin?action=get_soft_list")
+	}, func(b []byte) bool {
+		var v SoftList
+		return json.Unmarshal(b, &v) == nil && v.List.Data != nil
+	})
+	if err != nil {
+		return SoftList{}, err
+	}
+	var dec SoftList
+	if err := c.unmarshal(ctx, resp, &dec); err != nil {
+		return SoftList{}, err
+	}
+	return dec, nil
+}
+
+// PHPVersionStatus PHP 版本的安装与运行状态
+type PHPVersionStatus struct {
+	Version   string // 与 GetPHPVersion/AddSite 一致的版本号 eg. 74
+	Name      string // eg. PHP-7.4
+	Installed bool
+	Running   bool
+	Patch     string // 已安装的完整版本 eg. 7.4.33
+}
+
+// GetPHPVersionStatus 获取软件商店中所有 PHP 版本的安装与运行状态 按版本号升序
+// 未安装的版本可通过面板安装后再用于 AddSite
+func (c *Client) GetPHPVersionStatus(ctx context.Context) ([]PHPVersionStatus, error) {
+	soft, err := c.GetSoftList(ctx, "php")
+	if err != nil {
+		return nil, err
+	}
+	var ret []PHPVersionStatus
+	for _, s := range soft.List.Data {
+		if !strings.HasPrefix(s.Name, "php-") {
+			continue
+		}
+		ret = append(ret, PHPVersionStatus{
+			Version:   strings.ReplaceAll(strings.TrimPrefix(s.Name, "php-"), ".", ""),
+			Name:      s.Title,
+			Installed: s.Setup,
+			Running:   s.Setup && s.Status,
+			Patch:     s.Version,
+		})
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		if len(ret[i].Version) != len(ret[j].Version) {
+			return len(ret[i].Version) < len(ret[j].Version)
+		}
+		return ret[i].Version < ret[j].Version
+	})
+	return ret, nil
+}
+
+// PHPUsage PHP 版本及使用该版本的网站
+type PHPUsage struct {
+	Version   string   // 与 GetPHPVersion/AddSite 一致的版本号 eg. 74 纯静态为 00
+	Name      string   // eg. PHP-74
+	Installed bool     // 是否出现在 GetPHPVersion 中 为 false 表示网站仍引用已卸载的版本
+	Sites     []string // 使用该版本的网站名
+}
+
+// GetPHPUsage 汇总已安装的 PHP 版本及各版本被多少网站使用 用于评估下线过期 PHP 版本的影响
+// 网站列表未返回 PHP 版本的旧版面板会逐个查询网站的 PHP 版本
+func (c *Client) GetPHPUsage(ctx context.Context) ([]PHPUsage, error) {
+	installed, err := c.GetPHPVersion(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sites, err := c.ListAllSites(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+	var ret []PHPUsage
+	index := map[string]int{}
+	for _, v := range installed {
+		index[v.Version] = len(ret)
+		ret = append(ret, PHPUsage{Version: v.Version, Name: v.Name, Installed: true})
+	}
+	var missing []PHPUsage
+	for _, s := range sites {
+		version := normalizePHPVersion(s.PHPVersion)
+		if version == "" {
+			if version, err = c.sitePHPVersion(ctx, s.Name); err != nil {
+				return nil, err
+			}
+		}
+		if i, ok := index[version]; ok {
+			ret[i].Sites = append(ret[i].Sites, s.Name)
+			continue
+		}
+		found := false
+		for i := range missing {
+			if missing[i].Version == version {
+				missing[i].Sites = append(missing[i].Sites, s.Name)
+				found = true
+				break
+			}
+		}
+		if !found {
+			missing = append(missing, PHPUsage{Version: version, Name: "PHP-" + version, Sites: []string{s.Name}})
+		}
+	}
+	sort.Slice(missing, func(i, j int) bool { return missing[i].Version < missing[j].Version })
+	return append(ret, missing...), nil
+}
+
+// normalizePHPVersion 将网站列表中的 PHP 版本转为 GetPHPVersion 的格式 eg. 7.4 -> 74 静态 -> 00
+func normalizePHPVersion(v string) string {
+	v = strings.TrimSpace(v)
+	switch {
+	case v == "":
+		return ""
+	case v == "静态" || v == "00" || strings.EqualFold(v, "static"):
+		return "00"
+	}
+	return strings.ReplaceAll(v, ".", "")
+}
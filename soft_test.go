@@ -0,0 +1,60 @@
+package bt
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestGetPHPVersionStatus(t *testing.T) {
+	c := newFakePanel(t, map[string]http.HandlerFunc{
+		"/plugin?action=get_soft_list": reply(`{"list":{"data":[
+			{"name":"php-8.0","title":"PHP-8.0","setup":false,"status":false},
+			{"name":"nginx","title":"Nginx","setup":true,"status":true},
+			{"name":"php-7.4","title":"PHP-7.4","version":"7.4.33","setup":true,"status":true},
+			{"name":"php-5.6","title":"PHP-5.6","version":"5.6.40","setup":true,"status":false}
+		]}}`),
+	})
+	r, err := c.GetPHPVersionStatus(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []PHPVersionStatus{
+		{Version: "56", Name: "PHP-5.6", Installed: true, Patch: "5.6.40"},
+		{Version: "74", Name: "PHP-7.4", Installed: true, Running: true, Patch: "7.4.33"},
+		{Version: "80", Name: "PHP-8.0"},
+	}
+	if len(r) != len(want) {
+		t.Fatalf("got %+v", r)
+	}
+	for i := range want {
+		if r[i] != want[i] {
+			t.Errorf("[%d] = %+v, want %+v", i, r[i], want[i])
+		}
+	}
+}
+
+func TestGetPHPUsage(t *testing.T) {
+	c := newFakePanel(t, map[string]http.HandlerFunc{
+		"/site?action=GetPHPVersion": reply(`[{"version":"00","name":"纯静态"},{"version":"74","name":"PHP-74"},{"version":"80","name":"PHP-80"}]`),
+		"/data?action=getData": reply(`{"data":[
+			{"id":1,"name":"a.com","php_version":"7.4"},
+			{"id":2,"name":"b.com","php_version":"静态"},
+			{"id":3,"name":"c.com"},
+			{"id":4,"name":"d.com","php_version":"5.6"}
+		]}`),
+		"/site?action=GetSitePHPVersion": reply(`{"phpversion":"74"}`),
+	})
+	r, err := c.GetPHPUsage(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(r) != 4 {
+		t.Fatalf("got %+v", r)
+	}
+	if len(r[0].Sites) != 1 || len(r[1].Sites) != 2 || len(r[2].Sites) != 0 {
+		t.Errorf("got %+v", r)
+	}
+	if r[3].Version != "56" || r[3].Installed || r[3].Sites[0] != "d.com" {
+		t.Errorf("got %+v", r[3])
+	}
+}
@@ -0,0 +1,92 @@
+package bt
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"strings"
+)
+
+// sqliteModule 新版面板（8.0+）数据库管理中 SQLite 相关接口的前缀
+const sqliteModule = "/database/sqlite/"
+
+// readOnlySQL 允许通过 QuerySQLite 执行的语句 WITH 后可跟 DELETE/UPDATE 等写入语句 不在允许范围内
+var readOnlySQL = regexp.MustCompile(`(?i)^(select|explain)\b`)
+
+// pragmaSQL 匹配 PRAGMA 语句 分组为名称和括号中的参数
+var pragmaSQL = regexp.MustCompile(`(?i)^pragma\s+(?:main\.)?([a-z_]+)\s*(?:\(\s*([A-Za-z0-9_"'\x60]*)\s*\))?$`)
+
+// 允许执行的 PRAGMA 按是否接受参数区分
+// 设置类 PRAGMA 以 = 或括号传值时会写入数据库 因此只读取值时也不允许带参数
+var (
+	readOnlyPragmas = map[string]bool{
+		"user_version": true, "schema_version": true, "application_id": true,
+		"page_count": true, "page_size": true, "freelist_count": true,
+		"encoding": true, "journal_mode": true, "auto_vacuum": true,
+		"database_list": true, "collation_list": true, "compile_options": true,
+		"function_list": true, "module_list": true, "pragma_list": true,
+		"integrity_check": true, "quick_check": true, "foreign_key_check": true,
+	}
+	readOnlyPragmasWithArg = map[string]bool{
+		"table_info": true, "table_xinfo": true, "table_list": true,
+		"index_list": true, "index_info": true, "index_xinfo": true,
+		"foreign_key_list": true, "foreign_key_check": true,
+	}
+)
+
+// isReadOnlySQL 判断 sql 是否为不产生副作用的单条语句
+func isReadOnlySQL(sql string) bool {
+	if strings.Contains(sql, ";") {
+		return false
+	}
+	if readOnlySQL.MatchString(sql) {
+		return true
+	}
+	m := pragmaSQL.FindStringSubmatch(sql)
+	if m == nil {
+		return false
+	}
+	name := strings.ToLower(m[1])
+	if strings.Contains(sql, "(") {
+		return readOnlyPragmasWithArg[name]
+	}
+	return readOnlyPragmas[name] || readOnlyPragmasWithArg[name]
+}
+
+// ListSQLiteTables 列出 SQLite 数据库文件中的表（需新版面板）path 为数据库文件在服务器上的路径
+func (c *Client) ListSQLiteTables(ctx context.Context, path string) ([]SQLiteTable, error) {
+	data := map[string][]string{
+		"path": {path},
+	}
+	resp, err := c.btAPI(ctx, data, sqliteModule+"get_table_list")
+	if err != nil {
+		return nil, err
+	}
+	var dec []SQLiteTable
+	if err := c.unmarshal(ctx, resp, &dec); err != nil {
+		return nil, err
+	}
+	return dec, nil
+}
+
+// QuerySQLite 对 SQLite 数据库执行只读查询（需新版面板）
+// 只允许单条 SELECT/EXPLAIN 语句及查询类 PRAGMA（eg. table_info(users)、user_version） 其他语句在发出请求前被拒绝
+func (c *Client) QuerySQLite(ctx context.Context, path string, sql string) (SQLiteResult, error) {
+	sql = strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(sql), ";"))
+	if !isReadOnlySQL(sql) {
+		return SQLiteResult{}, errors.New("only a single read-only statement is allowed")
+	}
+	data := map[string][]string{
+		"path": {path},
+		"sql":  {sql},
+	}
+	resp, err := c.btAPI(ctx, data, sqliteModule+"query_sql")
+	if err != nil {
+		return SQLiteResult{}, err
+	}
+	var dec SQLiteResult
+	if err := c.unmarshal(ctx, resp, &dec); err != nil {
+		return SQLiteResult{}, err
+	}
+	return dec, nil
+}
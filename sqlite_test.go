@@ -0,0 +1,44 @@
+package bt
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestSQLite(t *testing.T) {
+	c := newFakePanel(t, map[string]http.HandlerFunc{
+		"/database/sqlite/get_table_list": reply(`[{"name":"users","type":"table","count":3}]`),
+		"/database/sqlite/query_sql": func(w http.ResponseWriter, r *http.Request) {
+			if r.FormValue("sql") != "SELECT id FROM users" || r.FormValue("path") != "/www/app.db" {
+				t.Errorf("unexpected form %v", r.Form)
+			}
+			_, _ = w.Write([]byte(`{"columns":["id"],"rows":[[1],[2]]}`))
+		},
+	})
+	tables, err := c.ListSQLiteTables(ctx, "/www/app.db")
+	if err != nil || len(tables) != 1 || tables[0].Name != "users" || tables[0].Count != 3 {
+		t.Fatalf("ListSQLiteTables = %+v, %v", tables, err)
+	}
+	res, err := c.QuerySQLite(ctx, "/www/app.db", " SELECT id FROM users; ")
+	if err != nil || len(res.Columns) != 1 || len(res.Rows) != 2 {
+		t.Fatalf("QuerySQLite = %+v, %v", res, err)
+	}
+	for _, sql := range []string{
+		"DELETE FROM users", "SELECT 1; DROP TABLE users", "vacuum",
+		"WITH x AS (SELECT 1) DELETE FROM users",
+		"PRAGMA user_version=1", "PRAGMA user_version(1)", "PRAGMA writable_schema=ON",
+		"PRAGMA writable_schema", "PRAGMA journal_mode = DELETE",
+	} {
+		if _, err := c.QuerySQLite(ctx, "/www/app.db", sql); err == nil {
+			t.Errorf("QuerySQLite(%q) should be rejected", sql)
+		}
+	}
+	for _, sql := range []string{"EXPLAIN SELECT 1", "PRAGMA user_version", "pragma table_info(users)", "PRAGMA main.index_list('users')"} {
+		if !isReadOnlySQL(sql) {
+			t.Errorf("%q should be allowed", sql)
+		}
+	}
+	if IsMutation("/database/sqlite/query_sql") {
+		t.Error("query_sql should be read-only")
+	}
+}
@@ -0,0 +1,194 @@
+package bt
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DNSTXTRecord DNS 手动验证需要创建的 TXT 记录
+type DNSTXTRecord struct {
+	Domain string // 申请证书的域名
+	Name   string // 记录名 eg. _acme-challenge.example.com
+	Value  string // 记录值
+}
+
+// DNSManualCert DNS 手动验证的证书申请流程
+// 先由 StartDNSManualCert 发起申请 按 Records 在自有 DNS 创建 TXT 记录 再调用 Verify 完成签发
+type DNSManualCert struct {
+	c       *Client
+	SiteID  int64
+	Index   string // 面板返回的申请标识 Verify 时回传
+	Records []DNSTXTRecord
+}
+
+// StartDNSManualCert 发起 DNS 手动验证的 Let's Encrypt 证书申请
+// siteID 网站ID-必填
+// domains 需要签发的域名 可包含通配符域名-必填
+func (c *Client) StartDNSManualCert(ctx context.Context, siteID int64, domains []string) (*DNSManualCert, error) {
+	if len(domains) == 0 {
+		return nil, errors.New("domains is empty")
+	}
+	list, err := json.Marshal(domains)
+	if err != nil {
+		return nil, err
+	}
+	data := map[string][]string{
+		"id":        {strconv.FormatInt(siteID, 10)},
+		"domains":   {string(list)},
+		"auth_type": {"dns"},
+		"auth_to":   {"dns"},
+	}
+	resp, err := c.btAPI(ctx, data, "/acme?action=apply_cert_api")
+	if err != nil {
+		return nil, err
+	}
+	var dec RespDNSChallenge
+	if err := c.unmarshal(ctx, resp, &dec); err != nil {
+		return nil, err
+	}
+	if !dec.Status && dec.Index == "" {
+		return nil, newPanelError("/acme?action=apply_cert_api", dec.Msg, resp)
+	}
+	flow := &DNSManualCert{
+		c:      c,
+		SiteID: siteID,
+		Index:  dec.Index,
+	}
+	for _, a := range dec.Auths {
+		flow.Records = append(flow.Records, DNSTXTRecord{
+			Domain: a.Domain,
+			Name:   "_acme-challenge." + strings.TrimPrefix(a.Domain, "*."),
+			Value:  a.AuthValue,
+		})
+	}
+	return flow, nil
+}
+
+// Propagated 检查所有 TXT 记录是否已能被解析到 resolver 为空时使用系统默认解析器
+func (f *DNSManualCert) Propagated(ctx context.Context, resolver *net.Resolver) (bool, error) {
+	if resolver == nil {
+		resolver = net.DefaultResolver
+	}
+	for _, r := range f.Records {
+		values, err := resolver.LookupTXT(ctx, r.Name)
+		if err != nil {
+			var dnsErr *net.DNSError
+			if errors.As(err, &dnsErr) && dnsErr.IsNotFound {
+				return false, nil
+			}
+			return false, err
+		}
+		found := false
+		for _, v := range values {
+			if v == r.Value {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// Verify 通知面板校验 TXT 记录并完成签发 成功后证书由面板部署到对应网站
+func (f *DNSManualCert) Verify(ctx context.Context) (RespCertApply, error) {
+	data := map[string][]string{
+		"id":    {strconv.FormatInt(f.SiteID, 10)},
+		"index": {f.Index},
+	}
+	resp, err := f.c.btAPI(ctx, data, "/acme?action=apply_dns_auth")
+	if err != nil {
+		return RespCertApply{}, err
+	}
+	var dec RespCertApply
+	if err := f.c.unmarshal(ctx, resp, &dec); err != nil {
+		return RespCertApply{}, err
+	}
+	if !dec.Status {
+		return dec, newPanelError("/acme?action=apply_dns_auth", dec.Msg, resp)
+	}
+	return dec, nil
+}
+
+// SetSSL 为网站部署证书并开启 HTTPS cert 为证书（含证书链）PEM key 为私钥 PEM
+func (c *Client) SetSSL(ctx context.Context, siteName string, cert string, key string) (RespMSG, error) {
+	if cert == "" || key == "" {
+		return RespMSG{}, errors.New("certificate and private key are required")
+	}
+	data := map[string][]string{
+		"type":     {"1"},
+		"siteName": {siteName},
+		"csr":      {cert},
+		"key":      {key},
+	}
+	return c.btResult(ctx, data, "/site?action=SetSSL")
+}
+
+// CloseSSLConf 关闭网站 HTTPS 证书文件保留在面板中
+func (c *Client) CloseSSLConf(ctx context.Context, siteName string) (RespMSG, error) {
+	data := map[string][]string{
+		"updateOf": {"1"},
+		"siteName": {siteName},
+	}
+	return c.btResult(ctx, data, "/site?action=CloseSSLConf")
+}
+
+// GetSSLInfo 获取网站 SSL 状态及当前证书信息
+func (c *Client) GetSSLInfo(ctx context.Context, siteName string) (RespSSLInfo, error) {
+	data := map[string][]string{
+		"siteName": {siteName},
+	}
+	resp, err := c.btAPI(ctx, data, "/site?action=GetSSL")
+	if err != nil {
+		return RespSSLInfo{}, err
+	}
+	var dec RespSSLInfo
+	if err := c.unmarshal(ctx, resp, &dec); err != nil {
+		return RespSSLInfo{}, err
+	}
+	return dec, nil
+}
+
+// Expiry 证书到期时间 未部署证书或面板未返回时为零值
+func (r RespSSLInfo) Expiry() time.Time {
+	t, _ := time.ParseInLocation("2006-01-02", r.CertData.NotAfter, time.Local)
+	return t
+}
+
+// ApplyLetsEncryptCert 使用文件验证为网站申请 Let's Encrypt 证书 签发成功后面板自动部署到网站
+// 域名需已解析到该服务器且 80 端口可访问 需要 DNS 验证（如通配符域名）时使用 StartDNSManualCert
+func (c *Client) ApplyLetsEncryptCert(ctx context.Context, siteID int64, domains []string) (RespCertApply, error) {
+	if len(domains) == 0 {
+		return RespCertApply{}, errors.New("domains is empty")
+	}
+	list, err := json.Marshal(domains)
+	if err != nil {
+		return RespCertApply{}, err
+	}
+	id := strconv.FormatInt(siteID, 10)
+	data := map[string][]string{
+		"id":            {id},
+		"domains":       {string(list)},
+		"auth_type":     {"http"},
+		"auth_to":       {id},
+		"auto_wildcard": {"0"},
+	}
+	resp, err := c.btAPI(ctx, data, "/acme?action=apply_cert_api")
+	if err != nil {
+		return RespCertApply{}, err
+	}
+	var dec RespCertApply
+	if err := c.unmarshal(ctx, resp, &dec); err != nil {
+		return RespCertApply{}, err
+	}
+	if !dec.Status {
+		return dec, newPanelError("/acme?action=apply_cert_api", dec.Msg, resp)
+	}
+	return dec, nil
+}
@@ -0,0 +1,67 @@
+package bt
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestStartDNSManualCert(t *testing.T) {
+	c := newFakePanel(t, map[string]http.HandlerFunc{
+		"/acme?action=apply_cert_api": func(w http.ResponseWriter, r *http.Request) {
+			if r.FormValue("auth_to") != "dns" || r.FormValue("domains") != `["*.hao.com","hao.com"]` {
+				t.Errorf("unexpected form %v", r.Form)
+			}
+			_, _ = w.Write([]byte(`{"status":true,"index":"abc","auths":[{"domain":"*.hao.com","auth_value":"v1","type":"dns"},{"domain":"hao.com","auth_value":"v2","type":"dns"}]}`))
+		},
+		"/acme?action=apply_dns_auth": func(w http.ResponseWriter, r *http.Request) {
+			if r.FormValue("index") != "abc" {
+				t.Errorf("unexpected index %q", r.FormValue("index"))
+			}
+			_, _ = w.Write([]byte(`{"status":true,"msg":"ok","cert":"CERT"}`))
+		},
+	})
+	flow, err := c.StartDNSManualCert(ctx, 1, []string{"*.hao.com", "hao.com"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []DNSTXTRecord{
+		{Domain: "*.hao.com", Name: "_acme-challenge.hao.com", Value: "v1"},
+		{Domain: "hao.com", Name: "_acme-challenge.hao.com", Value: "v2"},
+	}
+	if len(flow.Records) != len(want) || flow.Records[0] != want[0] || flow.Records[1] != want[1] {
+		t.Fatalf("records = %+v", flow.Records)
+	}
+	r, err := flow.Verify(ctx)
+	if err != nil || r.Cert != "CERT" {
+		t.Fatalf("Verify = %+v, %v", r, err)
+	}
+}
+
+func TestSSLLifecycle(t *testing.T) {
+	c := newFakePanel(t, map[string]http.HandlerFunc{
+		"/site?action=SetSSL": func(w http.ResponseWriter, r *http.Request) {
+			if r.FormValue("siteName") != "a.com" || r.FormValue("csr") != "CERT" || r.FormValue("key") != "KEY" || r.FormValue("type") != "1" {
+				t.Errorf("unexpected form %v", r.Form)
+			}
+			_, _ = w.Write([]byte(`{"status":true,"msg":"证书已保存!"}`))
+		},
+		"/site?action=GetSSL":       reply(`{"status":true,"type":1,"csr":"CERT","key":"KEY","cert_data":{"issuer":"R3","notAfter":"2024-03-31","endtime":30,"dns":["a.com"]}}`),
+		"/site?action=CloseSSLConf": reply(`{"status":true,"msg":"SSL已关闭!"}`),
+	})
+	if _, err := c.SetSSL(ctx, "a.com", "", "KEY"); err == nil {
+		t.Error("empty certificate should be rejected")
+	}
+	if _, err := c.SetSSL(ctx, "a.com", "CERT", "KEY"); err != nil {
+		t.Fatal(err)
+	}
+	info, err := c.GetSSLInfo(ctx, "a.com")
+	if err != nil || !info.Status || info.CertData.DNS[0] != "a.com" {
+		t.Fatalf("GetSSLInfo = %+v, %v", info, err)
+	}
+	if e := info.Expiry(); e.Year() != 2024 || e.Month() != 3 || e.Day() != 31 {
+		t.Errorf("Expiry = %v", e)
+	}
+	if _, err := c.CloseSSLConf(ctx, "a.com"); err != nil {
+		t.Fatal(err)
+	}
+}
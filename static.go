@@ -0,0 +1,95 @@
+package bt
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"strings"
+)
+
+var charsetName = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// StaticSite 纯静态网站（HTML/文档站）配置
+type StaticSite struct {
+	Domain  string   // 必填 主域名
+	Extras  []string // 附加域名
+	Path    string   // 网站目录 默认 /www/wwwroot/<主域名>
+	Port    int64    // 默认 80
+	PS      string   // 备注 默认为主域名
+	Charset string   // 响应的字符集 eg. utf-8 为空时不设置
+	Listing bool     // 开启目录浏览（autoindex）
+	Index   []string // 默认文档 为空时使用面板默认值
+}
+
+func (s *StaticSite) validate() error {
+	if s.Charset != "" && !charsetName.MatchString(s.Charset) {
+		return errors.New("invalid charset: " + s.Charset)
+	}
+	return nil
+}
+
+// block 生成写入 nginx 配置的管理段
+func (s *StaticSite) block() string {
+	var lines []string
+	if s.Charset != "" {
+		lines = append(lines, "charset "+s.Charset+";")
+	}
+	if s.Listing {
+		lines = append(lines, dirIndexBlock)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// AddStaticSite 创建不使用 PHP 的纯静态网站 并按配置设置字符集、目录浏览和默认文档（仅支持 nginx）
+// 网站创建失败时返回面板结果且不做后续配置
+func (c *Client) AddStaticSite(ctx context.Context, s *StaticSite) (RespAddSite, error) {
+	if err := s.validate(); err != nil {
+		return RespAddSite{}, err
+	}
+	webname := NewWebName(s.Domain, s.Extras...)
+	name := hostOnly(webname.Domain)
+	params := &ReqAddSite{
+		WebName: webname,
+		Path:    s.Path,
+		Type:    "PHP",
+		Version: PHPVersionStatic,
+		Port:    s.Port,
+		PS:      s.PS,
+	}
+	if params.Path == "" {
+		params.Path = "/www/wwwroot/" + name
+	}
+	if params.Port == 0 {
+		params.Port = 80
+	}
+	if params.PS == "" {
+		params.PS = name
+	}
+	ret, err := c.AddSite(ctx, params)
+	if err != nil || !ret.SiteStatus {
+		return ret, err
+	}
+	if _, err := c.ConfigureStaticSite(ctx, name, s); err != nil {
+		return ret, err
+	}
+	if len(s.Index) > 0 {
+		if ret.SiteID == 0 {
+			// 旧版面板不返回 siteId
+			if ret.SiteID, err = c.siteIDByName(ctx, name); err != nil {
+				return ret, err
+			}
+		}
+		if _, err := c.SetIndex(ctx, ret.SiteID, strings.Join(s.Index, ",")); err != nil {
+			return ret, err
+		}
+	}
+	return ret, nil
+}
+
+// ConfigureStaticSite 修改已有静态网站的字符集和目录浏览设置 返回配置文件变更的 diff（仅支持 nginx）
+func (c *Client) ConfigureStaticSite(ctx context.Context, siteName string, s *StaticSite) (string, error) {
+	if err := s.validate(); err != nil {
+		return "", err
+	}
+	return c.editManagedBlock(ctx, siteName, "STATIC", s.block())
+}
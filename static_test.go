@@ -0,0 +1,47 @@
+package bt
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestAddStaticSite(t *testing.T) {
+	var saved, index string
+	addSite := `{"siteStatus":true,"siteId":7}`
+	c := newFakePanel(t, map[string]http.HandlerFunc{
+		"/site?action=AddSite": func(w http.ResponseWriter, r *http.Request) {
+			if r.FormValue("version") != "00" || !strings.HasPrefix(r.FormValue("path"), "/www/wwwroot/") || r.FormValue("port") != "80" {
+				t.Errorf("unexpected form %v", r.Form)
+			}
+			_, _ = w.Write([]byte(addSite))
+		},
+		"/data?action=getData":      reply(`{"data":[{"id":9,"name":"old.a.com"}]}`),
+		"/files?action=GetFileBody": reply(`{"status":true,"data":"server\n{\n    listen 80;\n}\n"}`),
+		"/files?action=SaveFileBody": func(w http.ResponseWriter, r *http.Request) {
+			saved = r.FormValue("data")
+			_, _ = w.Write([]byte(`{"status":true,"msg":"文件已保存!"}`))
+		},
+		"/site?action=SetIndex": func(w http.ResponseWriter, r *http.Request) {
+			index = r.FormValue("id") + ":" + r.FormValue("Index")
+			_, _ = w.Write([]byte(`{"status":true,"msg":"设置成功"}`))
+		},
+	})
+	r, err := c.AddStaticSite(ctx, &StaticSite{Domain: "docs.a.com", Charset: "utf-8", Listing: true, Index: []string{"index.html", "README.html"}})
+	if err != nil || !r.SiteStatus {
+		t.Fatalf("AddStaticSite = %+v, %v", r, err)
+	}
+	if !strings.Contains(saved, "charset utf-8;\n") || !strings.Contains(saved, "autoindex on;") || index != "7:index.html,README.html" {
+		t.Fatalf("saved %q index %q", saved, index)
+	}
+
+	// 旧版面板不返回 siteId 时按网站名查询
+	addSite = `{"siteStatus":true}`
+	r, err = c.AddStaticSite(ctx, &StaticSite{Domain: "old.a.com", Index: []string{"index.html"}})
+	if err != nil || r.SiteID != 9 || index != "9:index.html" {
+		t.Fatalf("AddStaticSite = %+v, %v, index %q", r, err, index)
+	}
+	if _, err := c.AddStaticSite(ctx, &StaticSite{Domain: "b.com", Charset: "utf-8; evil"}); err == nil {
+		t.Fatal("expected error")
+	}
+}
@@ -0,0 +1,30 @@
+package bt
+
+import (
+	"context"
+	"strconv"
+)
+
+// Linux 工具箱插件名 提供 Swap、DNS、时区等系统设置
+const linuxToolsPlugin = "linuxsys"
+
+// GetSwap 获取 Swap 使用情况（需安装 Linux 工具箱插件）
+func (c *Client) GetSwap(ctx context.Context) (SwapInfo, error) {
+	resp, err := c.PluginCall(ctx, linuxToolsPlugin, "GetSwap", nil)
+	if err != nil {
+		return SwapInfo{}, err
+	}
+	var dec SwapInfo
+	if err := c.unmarshal(ctx, resp, &dec); err != nil {
+		return SwapInfo{}, err
+	}
+	return dec, nil
+}
+
+// SetSwap 设置 Swap 文件大小 size 单位为 MB 为 0 时关闭并删除 Swap 文件（需安装 Linux 工具箱插件）
+// 面板会重新创建 /www/swap 耗时与 size 成正比 建议配合较长的 Timeout 使用
+func (c *Client) SetSwap(ctx context.Context, size int64) (RespMSG, error) {
+	return c.pluginResult(ctx, linuxToolsPlugin, "SetSwap", map[string]string{
+		"size": strconv.FormatInt(size, 10),
+	})
+}
@@ -0,0 +1,30 @@
+package bt
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestSwap(t *testing.T) {
+	c := newFakePanel(t, map[string]http.HandlerFunc{
+		"/plugin?action=a": func(w http.ResponseWriter, r *http.Request) {
+			switch r.URL.Query().Get("s") {
+			case "GetSwap":
+				_, _ = w.Write([]byte(`{"total":1023,"used":12,"size":1024}`))
+			case "SetSwap":
+				if r.FormValue("size") != "2048" || r.URL.Query().Get("name") != "linuxsys" {
+					t.Errorf("unexpected request %s %v", r.URL, r.Form)
+				}
+				_, _ = w.Write([]byte(`{"status":true,"msg":"设置成功"}`))
+			}
+		},
+	})
+	info, err := c.GetSwap(ctx)
+	if err != nil || info.Size != 1024 || info.Used != 12 {
+		t.Fatalf("GetSwap = %+v, %v", info, err)
+	}
+	r, err := c.SetSwap(ctx, 2048)
+	if err != nil || !r.Status {
+		t.Fatalf("SetSwap = %+v, %v", r, err)
+	}
+}
@@ -0,0 +1,124 @@
+package bt
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"regexp"
+	"strconv"
+)
+
+var (
+	tableName  = regexp.MustCompile(`^[a-z_]+$`)
+	fieldName  = regexp.MustCompile(`^[A-Za-z_]+$`)
+	totalCount = regexp.MustCompile(`共\s*(\d+)\s*条`)
+)
+
+// TableQuery 面板数据表查询构造器 对应 /data?action=getData
+// eg. c.QueryTable("sites").Search("hao.com").OrderBy("id", true).Limit(50).Into(ctx, &dec)
+type TableQuery struct {
+	c      *Client
+	table  string
+	params url.Values
+	err    error
+}
+
+// TableResult 数据表查询的通用结果
+type TableResult struct {
+	Data  []map[string]interface{} `json:"data"`
+	Where string                   `json:"where"`
+	Page  string                   `json:"page"` // 面板生成的分页 HTML
+}
+
+// Total 从分页 HTML 中解析总记录数 解析失败返回 -1
+func (r TableResult) Total() int64 {
+	return pageTotal(r.Page)
+}
+
+func pageTotal(page string) int64 {
+	m := totalCount.FindStringSubmatch(page)
+	if m == nil {
+		return -1
+	}
+	n, err := strconv.ParseInt(m[1], 10, 64)
+	if err != nil {
+		return -1
+	}
+	return n
+}
+
+// QueryTable 查询面板数据表 eg. sites/domain/backup/ftps/databases/firewall/crontab/logs
+func (c *Client) QueryTable(table string) *TableQuery {
+	q := &TableQuery{
+		c:     c,
+		table: table,
+		params: url.Values{
+			"p":     {"1"},
+			"limit": {"20"},
+		},
+	}
+	if !tableName.MatchString(table) {
+		q.err = errors.New("invalid table name: " + table)
+	}
+	return q
+}
+
+// Where 添加过滤参数 面板按表支持的参数不同 eg. search/type/pid
+func (q *TableQuery) Where(key string, value string) *TableQuery {
+	q.params.Set(key, value)
+	return q
+}
+
+// Search 按关键字搜索
+func (q *TableQuery) Search(keyword string) *TableQuery {
+	return q.Where("search", keyword)
+}
+
+// OrderBy 按字段排序
+func (q *TableQuery) OrderBy(field string, desc bool) *TableQuery {
+	if !fieldName.MatchString(field) {
+		q.err = errors.New("invalid order field: " + field)
+		return q
+	}
+	dir := " asc"
+	if desc {
+		dir = " desc"
+	}
+	return q.Where("order", field+dir)
+}
+
+// Page 设置页码 从 1 开始
+func (q *TableQuery) Page(p int64) *TableQuery {
+	return q.Where("p", strconv.FormatInt(p, 10))
+}
+
+// Limit 设置每页条数
+func (q *TableQuery) Limit(n int64) *TableQuery {
+	return q.Where("limit", strconv.FormatInt(n, 10))
+}
+
+// Do 执行查询并返回原始结果
+func (q *TableQuery) Do(ctx context.Context) ([]byte, error) {
+	if q.err != nil {
+		return nil, q.err
+	}
+	return q.c.btAPI(ctx, q.params, "/data?action=getData&table="+url.QueryEscape(q.table))
+}
+
+// Into 执行查询并解析到 v 可传入 *RespSites 等已有结构
+func (q *TableQuery) Into(ctx context.Context, v interface{}) error {
+	resp, err := q.Do(ctx)
+	if err != nil {
+		return err
+	}
+	return q.c.unmarshal(ctx, resp, v)
+}
+
+// Result 执行查询并解析为通用结果
+func (q *TableQuery) Result(ctx context.Context) (TableResult, error) {
+	var dec TableResult
+	if err := q.Into(ctx, &dec); err != nil {
+		return TableResult{}, err
+	}
+	return dec, nil
+}
@@ -0,0 +1,27 @@
+package bt
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestQueryTable(t *testing.T) {
+	c := newFakePanel(t, map[string]http.HandlerFunc{
+		"/data?action=getData": func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Query().Get("table") != "sites" || r.FormValue("order") != "id desc" || r.FormValue("limit") != "50" || r.FormValue("search") != "hao.com" || r.FormValue("p") != "1" {
+				t.Errorf("unexpected request %s %v", r.URL, r.PostForm)
+			}
+			_, _ = w.Write([]byte(`{"data":[{"id":1,"name":"w1.hao.com"}],"page":"<div><span class='Pcount'>共 1 条</span></div>"}`))
+		},
+	})
+	r, err := c.QueryTable("sites").Search("hao.com").OrderBy("id", true).Limit(50).Result(ctx)
+	if err != nil || len(r.Data) != 1 || r.Data[0]["name"] != "w1.hao.com" || r.Total() != 1 {
+		t.Fatalf("Result = %+v, %v", r, err)
+	}
+	if _, err := c.QueryTable("sites;drop").Do(ctx); err == nil {
+		t.Fatal("expected invalid table error")
+	}
+	if _, err := c.QueryTable("sites").OrderBy("id desc,", false).Do(ctx); err == nil {
+		t.Fatal("expected invalid field error")
+	}
+}
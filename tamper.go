@@ -0,0 +1,72 @@
+package bt
+
+import (
+	"context"
+	"strconv"
+)
+
+// 防篡改插件名
+const tamperPlugin = "tamper_proof"
+
+func (c *Client) tamperMSG(ctx context.Context, method string, args map[string]string) (RespMSG, error) {
+	return c.pluginResult(ctx, tamperPlugin, method, args)
+}
+
+// SetTamperProtection 开启或关闭网站的防篡改保护（需安装防篡改插件）
+func (c *Client) SetTamperProtection(ctx context.Context, siteName string, enabled bool) (RespMSG, error) {
+	status := "0"
+	if enabled {
+		status = "1"
+	}
+	return c.tamperMSG(ctx, "set_site_status", map[string]string{
+		"siteName": siteName,
+		"status":   status,
+	})
+}
+
+// GetTamperSite 获取网站的防篡改保护配置 包括受保护目录、排除路径和拦截统计
+func (c *Client) GetTamperSite(ctx context.Context, siteName string) (TamperSite, error) {
+	resp, err := c.PluginCall(ctx, tamperPlugin, "get_site_find", map[string]string{
+		"siteName": siteName,
+	})
+	if err != nil {
+		return TamperSite{}, err
+	}
+	var dec TamperSite
+	if err := c.unmarshal(ctx, resp, &dec); err != nil {
+		return TamperSite{}, err
+	}
+	return dec, nil
+}
+
+// AddTamperExclusion 添加防篡改排除路径 path 为目录名或文件名 eg. cache
+func (c *Client) AddTamperExclusion(ctx context.Context, siteName string, path string) (RespMSG, error) {
+	return c.tamperMSG(ctx, "add_excloud", map[string]string{
+		"siteName":    siteName,
+		"excludePath": path,
+	})
+}
+
+// RemoveTamperExclusion 删除防篡改排除路径
+func (c *Client) RemoveTamperExclusion(ctx context.Context, siteName string, path string) (RespMSG, error) {
+	return c.tamperMSG(ctx, "remove_excloud", map[string]string{
+		"siteName":    siteName,
+		"excludePath": path,
+	})
+}
+
+// GetTamperLogs 获取网站的防篡改拦截日志 p 为页码
+func (c *Client) GetTamperLogs(ctx context.Context, siteName string, p int64) (TamperLogs, error) {
+	resp, err := c.PluginCall(ctx, tamperPlugin, "get_safe_logs", map[string]string{
+		"siteName": siteName,
+		"p":        strconv.FormatInt(p, 10),
+	})
+	if err != nil {
+		return TamperLogs{}, err
+	}
+	var dec TamperLogs
+	if err := c.unmarshal(ctx, resp, &dec); err != nil {
+		return TamperLogs{}, err
+	}
+	return dec, nil
+}
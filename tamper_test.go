@@ -0,0 +1,52 @@
+package bt
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestTamper(t *testing.T) {
+	var calls []string
+	c := newFakePanel(t, map[string]http.HandlerFunc{
+		"/plugin?action=a": func(w http.ResponseWriter, r *http.Request) {
+			q := r.URL.Query()
+			if q.Get("name") != "tamper_proof" || r.FormValue("siteName") != "w1.hao.com" {
+				t.Errorf("unexpected request %s %v", r.URL, r.Form)
+			}
+			switch q.Get("s") {
+			case "get_site_find":
+				_, _ = w.Write([]byte(`{"siteName":"w1.hao.com","open":true,"path":"/www/wwwroot/w1.hao.com","excludePath":["cache","runtime"],"protectExt":["php","html"],"total":{"create":1,"modify":4,"unlink":0,"rename":2}}`))
+			case "get_safe_logs":
+				_, _ = w.Write([]byte(`{"data":[{"time":"2024-03-05 10:00:00","action":"modify","path":"/www/wwwroot/w1.hao.com/index.php"}],"page":"<a>1</a>"}`))
+			case "remove_excloud":
+				_, _ = w.Write([]byte(`{"status":false,"msg":"指定排除路径不存在"}`))
+			default:
+				calls = append(calls, q.Get("s")+" "+r.FormValue("status")+r.FormValue("excludePath"))
+				_, _ = w.Write([]byte(`{"status":true,"msg":"设置成功"}`))
+			}
+		},
+	})
+	site, err := c.GetTamperSite(ctx, "w1.hao.com")
+	if err != nil || !site.Open || len(site.ExcludePath) != 2 || site.ProtectExt[0] != "php" || site.Total.Modify != 4 || site.Total.Rename != 2 {
+		t.Fatalf("GetTamperSite = %+v, %v", site, err)
+	}
+	logs, err := c.GetTamperLogs(ctx, "w1.hao.com", 1)
+	if err != nil || len(logs.Data) != 1 || logs.Data[0].Action != "modify" || !strings.HasSuffix(logs.Data[0].Path, "index.php") {
+		t.Fatalf("GetTamperLogs = %+v, %v", logs, err)
+	}
+	if _, err := c.SetTamperProtection(ctx, "w1.hao.com", false); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.AddTamperExclusion(ctx, "w1.hao.com", "uploads"); err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Join(calls, ","); got != "set_site_status 0,add_excloud uploads" {
+		t.Errorf("calls %s", got)
+	}
+	var apiErr *APIError
+	if _, err := c.RemoveTamperExclusion(ctx, "w1.hao.com", "missing"); !errors.As(err, &apiErr) {
+		t.Errorf("RemoveTamperExclusion error %v", err)
+	}
+}
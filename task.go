@@ -0,0 +1,53 @@
+package bt
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// panelExecLog 面板执行后台任务时输出的日志 每个任务开始时覆盖
+const panelExecLog = "/tmp/panelExec.log"
+
+// GetTasks 获取最近的面板后台任务 按 ID 倒序 limit 为条数
+func (c *Client) GetTasks(ctx context.Context, limit int64) ([]PanelTask, error) {
+	var dec struct {
+		Data []PanelTask `json:"data"`
+	}
+	if err := c.QueryTable("tasks").OrderBy("id", true).Limit(limit).Into(ctx, &dec); err != nil {
+		return nil, err
+	}
+	return dec.Data, nil
+}
+
+// GetTaskLog 获取面板后台任务的执行日志 task 为任务 ID 或任务名称（取最近一个名称包含 task 的任务）
+// 面板只保留最近一个已开始任务的日志 task 之后已有其他任务开始执行时返回错误
+// 可在软件安装等任务失败后调用 将实际日志附加到告警中
+func (c *Client) GetTaskLog(ctx context.Context, task string) (string, error) {
+	tasks, err := c.GetTasks(ctx, 100)
+	if err != nil {
+		return "", err
+	}
+	id, byID := strconv.ParseInt(task, 10, 64)
+	var target, latest *PanelTask
+	for i := range tasks {
+		t := &tasks[i]
+		if latest == nil && t.Status != "0" {
+			latest = t
+		}
+		if target == nil && ((byID == nil && t.ID == id) || (byID != nil && strings.Contains(t.Name, task))) {
+			target = t
+		}
+	}
+	if target == nil {
+		return "", errors.New("task not found: " + task)
+	}
+	if target.Status == "0" {
+		return "", errors.New("task has not started: " + target.Name)
+	}
+	if latest != target {
+		return "", errors.New("log of task " + target.Name + " has been overwritten by a later task")
+	}
+	return c.readFile(ctx, panelExecLog)
+}
@@ -0,0 +1,34 @@
+package bt
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestGetTaskLog(t *testing.T) {
+	c := newFakePanel(t, map[string]http.HandlerFunc{
+		"/data?action=getData": reply(`{"data":[
+			{"id":3,"name":"安装[redis-7.0]","type":"execshell","status":"0","addtime":"2024-05-01 10:02:00","start":0,"end":0},
+			{"id":2,"name":"安装[nginx-1.24]","type":"execshell","status":"1","addtime":"2024-05-01 10:01:00","start":1714528860,"end":1714528900},
+			{"id":1,"name":"安装[php-7.4]","type":"execshell","status":"1","addtime":"2024-05-01 10:00:00","start":1714528800,"end":1714528850}
+		]}`),
+		"/files?action=GetFileBody": func(w http.ResponseWriter, r *http.Request) {
+			if r.FormValue("path") != "/tmp/panelExec.log" {
+				t.Errorf("unexpected path %s", r.FormValue("path"))
+			}
+			_, _ = w.Write([]byte(`{"status":true,"data":"make: *** [all] Error 2\n"}`))
+		},
+	})
+	for _, task := range []string{"2", "nginx"} {
+		log, err := c.GetTaskLog(ctx, task)
+		if err != nil || !strings.Contains(log, "Error 2") {
+			t.Fatalf("GetTaskLog(%q) = %q, %v", task, log, err)
+		}
+	}
+	for _, task := range []string{"php", "redis", "mysql"} {
+		if _, err := c.GetTaskLog(ctx, task); err == nil {
+			t.Errorf("GetTaskLog(%q) expected error", task)
+		}
+	}
+}
@@ -0,0 +1,285 @@
+package bt
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// ErrTenantDenied 操作的网站或路径不在 TenantView 的允许范围内
+var ErrTenantDenied = errors.New("bt: operation outside tenant scope")
+
+// TenantView 限定在一组网站和路径内的 Client 视图 用于多租户平台在 SDK 边界阻止跨租户操作
+// 只暴露网站、文件、备份相关的方法 范围外的调用在发出请求前返回 ErrTenantDenied
+type TenantView struct {
+	c     *Client
+	sites map[int64]bool
+	paths []string
+}
+
+// NewTenantView 创建租户视图 siteIDs 为允许操作的网站 paths 为允许访问的目录 eg. /www/wwwroot/tenant-a
+func NewTenantView(c *Client, siteIDs []int64, paths []string) *TenantView {
+	v := &TenantView{c: c, sites: map[int64]bool{}}
+	for _, id := range siteIDs {
+		v.sites[id] = true
+	}
+	for _, p := range paths {
+		if p = path.Clean(p); path.IsAbs(p) {
+			v.paths = append(v.paths, p)
+		}
+	}
+	return v
+}
+
+// AllowsSite 网站是否在允许范围内
+func (v *TenantView) AllowsSite(id int64) bool {
+	return v.sites[id]
+}
+
+// AllowsPath 路径是否在允许的目录内 只接受绝对路径 会先规范化 .. 等片段
+func (v *TenantView) AllowsPath(p string) bool {
+	if !path.IsAbs(p) {
+		return false
+	}
+	p = path.Clean(p)
+	for _, prefix := range v.paths {
+		if p == prefix || strings.HasPrefix(p, strings.TrimSuffix(prefix, "/")+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+func (v *TenantView) checkSite(id int64) error {
+	if !v.AllowsSite(id) {
+		return ErrTenantDenied
+	}
+	return nil
+}
+
+// checkSiteName 检查网站 ID 在允许范围内且 name 为该网站的名称
+// 面板按名称操作配置文件和目录 只检查 ID 时可借用自己的 ID 操作其他租户的网站
+func (v *TenantView) checkSiteName(ctx context.Context, id int64, name string) error {
+	if err := v.checkSite(id); err != nil {
+		return err
+	}
+	actual, err := v.c.siteKey(ctx, id, "name")
+	if err != nil {
+		return err
+	}
+	if actual != name {
+		return ErrTenantDenied
+	}
+	return nil
+}
+
+func (v *TenantView) checkPath(paths ...string) error {
+	for _, p := range paths {
+		if !v.AllowsPath(p) {
+			return ErrTenantDenied
+		}
+	}
+	return nil
+}
+
+// GetSites 获取网站列表 只返回允许范围内的网站
+func (v *TenantView) GetSites(ctx context.Context, params *ReqSites) (RespSites, error) {
+	ret, err := v.c.GetSites(ctx, params)
+	if err != nil {
+		return ret, err
+	}
+	data := ret.Data[:0]
+	for _, s := range ret.Data {
+		if v.AllowsSite(int64(s.ID)) {
+			data = append(data, s)
+		}
+	}
+	ret.Data = data
+	return ret, nil
+}
+
+// StopSite 停用网站 name 需与网站 ID 对应
+func (v *TenantView) StopSite(ctx context.Context, id int64, name string) (RespMSG, error) {
+	if err := v.checkSiteName(ctx, id, name); err != nil {
+		return RespMSG{}, err
+	}
+	return v.c.StopSite(ctx, id, name)
+}
+
+// StartSite 启用网站 name 需与网站 ID 对应
+func (v *TenantView) StartSite(ctx context.Context, id int64, name string) (RespMSG, error) {
+	if err := v.checkSiteName(ctx, id, name); err != nil {
+		return RespMSG{}, err
+	}
+	return v.c.StartSite(ctx, id, name)
+}
+
+// DeleteSite 删除网站 WebName 需与网站 ID 对应
+func (v *TenantView) DeleteSite(ctx context.Context, params *ReqDeleteSite) (RespMSG, error) {
+	if err := v.checkSiteName(ctx, params.ID, params.WebName); err != nil {
+		return RespMSG{}, err
+	}
+	return v.c.DeleteSite(ctx, params)
+}
+
+// SetSitePS 修改网站备注
+func (v *TenantView) SetSitePS(ctx context.Context, id int64, ps string) (RespMSG, error) {
+	if err := v.checkSite(id); err != nil {
+		return RespMSG{}, err
+	}
+	return v.c.SetSitePS(ctx, id, ps)
+}
+
+// AddDomain 添加域名 webname 需与网站 ID 对应
+func (v *TenantView) AddDomain(ctx context.Context, id int64, webname string, domain string) (RespMSG, error) {
+	if err := v.checkSiteName(ctx, id, webname); err != nil {
+		return RespMSG{}, err
+	}
+	return v.c.AddDomain(ctx, id, webname, domain)
+}
+
+// DelDomain 删除域名 webname 需与网站 ID 对应
+func (v *TenantView) DelDomain(ctx context.Context, id int64, webname string, domain string, port int64) (RespMSG, error) {
+	if err := v.checkSiteName(ctx, id, webname); err != nil {
+		return RespMSG{}, err
+	}
+	return v.c.DelDomain(ctx, id, webname, domain, port)
+}
+
+// SetPath 修改网站目录 新目录也必须在允许范围内
+func (v *TenantView) SetPath(ctx context.Context, id int64, p string) (RespMSG, error) {
+	if err := v.checkSite(id); err != nil {
+		return RespMSG{}, err
+	}
+	if err := v.checkPath(p); err != nil {
+		return RespMSG{}, err
+	}
+	return v.c.SetPath(ctx, id, p)
+}
+
+// SetIndex 设置默认文档
+func (v *TenantView) SetIndex(ctx context.Context, id int64, index string) (RespMSG, error) {
+	if err := v.checkSite(id); err != nil {
+		return RespMSG{}, err
+	}
+	return v.c.SetIndex(ctx, id, index)
+}
+
+// GetSiteBackups 获取网站备份列表 params.Search 为网站 ID 只允许查询网站备份（Type 为 0）
+func (v *TenantView) GetSiteBackups(ctx context.Context, params *ReqSiteBackups) (RespSiteBackups, error) {
+	if params.Type != 0 {
+		return RespSiteBackups{}, ErrTenantDenied
+	}
+	if err := v.checkSite(params.Search); err != nil {
+		return RespSiteBackups{}, err
+	}
+	return v.c.GetSiteBackups(ctx, params)
+}
+
+// SiteBackup 创建网站备份
+func (v *TenantView) SiteBackup(ctx context.Context, id int64) (RespMSG, error) {
+	if err := v.checkSite(id); err != nil {
+		return RespMSG{}, err
+	}
+	return v.c.SiteBackup(ctx, id)
+}
+
+// DeleteSiteBackup 删除网站备份 会先查询备份所属的网站
+func (v *TenantView) DeleteSiteBackup(ctx context.Context, id int64) (RespMSG, error) {
+	pid, err := v.c.tableKey(ctx, "backup", id, "pid")
+	if err != nil {
+		return RespMSG{}, err
+	}
+	siteID, err := strconv.ParseInt(pid, 10, 64)
+	if err != nil {
+		return RespMSG{}, ErrTenantDenied
+	}
+	if err := v.checkSite(siteID); err != nil {
+		return RespMSG{}, err
+	}
+	return v.c.DeleteSiteBackup(ctx, id)
+}
+
+// GetFile 获取文件
+func (v *TenantView) GetFile(ctx context.Context, p string) (RespGetFile, error) {
+	if err := v.checkPath(p); err != nil {
+		return RespGetFile{}, err
+	}
+	return v.c.GetFile(ctx, p)
+}
+
+// SetFile 修改文件
+func (v *TenantView) SetFile(ctx context.Context, p string, body string) (RespMSG, error) {
+	if err := v.checkPath(p); err != nil {
+		return RespMSG{}, err
+	}
+	return v.c.SetFile(ctx, p, body)
+}
+
+// EnsureDir 确保目录存在
+func (v *TenantView) EnsureDir(ctx context.Context, p string, mode os.FileMode, owner string) ([]string, error) {
+	if err := v.checkPath(p); err != nil {
+		return nil, err
+	}
+	return v.c.EnsureDir(ctx, p, mode, owner)
+}
+
+// ListFileVersions 获取文件历史版本
+func (v *TenantView) ListFileVersions(ctx context.Context, p string) ([]int64, error) {
+	if err := v.checkPath(p); err != nil {
+		return nil, err
+	}
+	return v.c.ListFileVersions(ctx, p)
+}
+
+// RestoreFileVersion 将文件恢复到指定历史版本
+func (v *TenantView) RestoreFileVersion(ctx context.Context, p string, version int64) (RespMSG, error) {
+	if err := v.checkPath(p); err != nil {
+		return RespMSG{}, err
+	}
+	return v.c.RestoreFileVersion(ctx, p, version)
+}
+
+// WriteFile 写入文件
+func (v *TenantView) WriteFile(ctx context.Context, p string, content string, createIfMissing bool, backup bool) (RespMSG, error) {
+	if err := v.checkPath(p); err != nil {
+		return RespMSG{}, err
+	}
+	return v.c.WriteFile(ctx, p, content, createIfMissing, backup)
+}
+
+// CreateFile 新建空文件
+func (v *TenantView) CreateFile(ctx context.Context, p string) (RespMSG, error) {
+	if err := v.checkPath(p); err != nil {
+		return RespMSG{}, err
+	}
+	return v.c.CreateFile(ctx, p)
+}
+
+// CopyFile 复制文件或目录 源和目标都必须在允许范围内
+func (v *TenantView) CopyFile(ctx context.Context, sfile string, dfile string) (RespMSG, error) {
+	if err := v.checkPath(sfile, dfile); err != nil {
+		return RespMSG{}, err
+	}
+	return v.c.CopyFile(ctx, sfile, dfile)
+}
+
+// MoveFile 移动或重命名文件 源和目标都必须在允许范围内
+func (v *TenantView) MoveFile(ctx context.Context, sfile string, dfile string) (RespMSG, error) {
+	if err := v.checkPath(sfile, dfile); err != nil {
+		return RespMSG{}, err
+	}
+	return v.c.MoveFile(ctx, sfile, dfile)
+}
+
+// UploadChunked 分片上传文件 u.Dir 必须在允许范围内
+func (v *TenantView) UploadChunked(ctx context.Context, u *ChunkedUpload, r io.ReaderAt) error {
+	if err := v.checkPath(path.Join(u.Dir, u.Name)); err != nil {
+		return err
+	}
+	return v.c.UploadChunked(ctx, u, r)
+}
@@ -0,0 +1,65 @@
+package bt
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestTenantView(t *testing.T) {
+	c := newFakePanel(t, map[string]http.HandlerFunc{
+		"/data?action=getData": reply(`{"data":[{"id":1,"name":"a.com"},{"id":2,"name":"b.com"}]}`),
+		"/data?action=getKey": func(w http.ResponseWriter, r *http.Request) {
+			if r.FormValue("key") == "name" {
+				_, _ = w.Write([]byte(`"a.com"`))
+				return
+			}
+			if r.FormValue("id") == "10" {
+				_, _ = w.Write([]byte(`1`))
+				return
+			}
+			_, _ = w.Write([]byte(`2`))
+		},
+		"/site?action=DelBackup":     reply(`{"status":true,"msg":"删除成功"}`),
+		"/site?action=SiteStop":      reply(`{"status":true,"msg":"站点已停用"}`),
+		"/files?action=SaveFileBody": reply(`{"status":true,"msg":"文件已保存!"}`),
+	})
+	v := NewTenantView(c, []int64{1}, []string{"/www/wwwroot/a.com/"})
+	sites, err := v.GetSites(ctx, nil)
+	if err != nil || len(sites.Data) != 1 || sites.Data[0].ID != 1 {
+		t.Fatalf("GetSites = %+v, %v", sites, err)
+	}
+	if _, err := v.StopSite(ctx, 2, "b.com"); !errors.Is(err, ErrTenantDenied) {
+		t.Fatalf("StopSite err = %v", err)
+	}
+	if r, err := v.StopSite(ctx, 1, "a.com"); err != nil || !r.Status {
+		t.Fatalf("StopSite = %+v, %v", r, err)
+	}
+	// 使用自己的网站 ID 和其他租户的网站名
+	if _, err := v.StopSite(ctx, 1, "b.com"); !errors.Is(err, ErrTenantDenied) {
+		t.Fatalf("StopSite with foreign name err = %v", err)
+	}
+	if _, err := v.AddDomain(ctx, 1, "b.com", "x.b.com"); !errors.Is(err, ErrTenantDenied) {
+		t.Fatalf("AddDomain with foreign name err = %v", err)
+	}
+	if _, err := v.GetSiteBackups(ctx, &ReqSiteBackups{Limit: 10, Type: 1, Search: 1}); !errors.Is(err, ErrTenantDenied) {
+		t.Fatalf("GetSiteBackups with database type err = %v", err)
+	}
+	if r, err := v.DeleteSiteBackup(ctx, 10); err != nil || !r.Status {
+		t.Fatalf("DeleteSiteBackup = %+v, %v", r, err)
+	}
+	if _, err := v.DeleteSiteBackup(ctx, 11); !errors.Is(err, ErrTenantDenied) {
+		t.Fatalf("DeleteSiteBackup err = %v", err)
+	}
+	if r, err := v.SetFile(ctx, "/www/wwwroot/a.com/index.html", "hi"); err != nil || !r.Status {
+		t.Fatalf("SetFile = %+v, %v", r, err)
+	}
+	for _, p := range []string{"/www/wwwroot/a.com/../b.com/index.html", "/www/wwwroot/a.com.evil/x", "a.com/x"} {
+		if _, err := v.SetFile(ctx, p, "hi"); !errors.Is(err, ErrTenantDenied) {
+			t.Errorf("SetFile(%q) err = %v", p, err)
+		}
+	}
+	if _, err := v.CopyFile(ctx, "/www/wwwroot/a.com/x", "/tmp/x"); !errors.Is(err, ErrTenantDenied) {
+		t.Fatalf("CopyFile err = %v", err)
+	}
+}
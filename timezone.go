@@ -0,0 +1,38 @@
+package bt
+
+import "context"
+
+// GetTimezone 获取服务器当前时区及可选的时区列表
+func (c *Client) GetTimezone(ctx context.Context) (TimezoneData, error) {
+	resp, err := c.btAPI(ctx, map[string][]string{}, "/config?action=get_timezone_data")
+	if err != nil {
+		return TimezoneData{}, err
+	}
+	var dec TimezoneData
+	if err := c.unmarshal(ctx, resp, &dec); err != nil {
+		return TimezoneData{}, err
+	}
+	return dec, nil
+}
+
+// Name 时区的 IANA 名称 eg. Asia/Shanghai
+func (t TimezoneData) Name() string {
+	if t.Zone.City == "" {
+		return t.Zone.Area
+	}
+	return t.Zone.Area + "/" + t.Zone.City
+}
+
+// SetTimezone 设置服务器时区 zone 为 IANA 时区名 eg. Asia/Shanghai
+func (c *Client) SetTimezone(ctx context.Context, zone string) (RespMSG, error) {
+	data := map[string][]string{
+		"zone": {zone},
+	}
+	return c.btResult(ctx, data, "/config?action=set_timezone")
+}
+
+// SyncTime 从面板时间服务器同步服务器时间
+// 服务器时间偏差过大会导致 API 签名校验失败 可在签名失败前定期调用
+func (c *Client) SyncTime(ctx context.Context) (RespMSG, error) {
+	return c.btResult(ctx, map[string][]string{}, "/config?action=syncDate")
+}
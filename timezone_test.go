@@ -0,0 +1,19 @@
+package bt
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestGetTimezone(t *testing.T) {
+	c := newFakePanel(t, map[string]http.HandlerFunc{
+		"/config?action=get_timezone_data": reply(`{"zone":{"area":"Asia","city":"Shanghai"},"areaList":["Asia","Europe"],"cityList":["Shanghai","Tokyo"]}`),
+	})
+	r, err := c.GetTimezone(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Name() != "Asia/Shanghai" || len(r.AreaList) != 2 {
+		t.Fatalf("unexpected %+v", r)
+	}
+}
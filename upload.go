@@ -0,0 +1,165 @@
+package bt
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"path"
+	"strconv"
+	"time"
+)
+
+// DefaultUploadChunkSize 分片上传默认的分片大小
+const DefaultUploadChunkSize = 1 << 20
+
+// ChunkedUpload 分片上传任务 上传中断后 Offset 保留已确认的进度 再次调用 UploadChunked 即可续传
+type ChunkedUpload struct {
+	Dir        string                  // 服务器上的目标目录 eg. /www/wwwroot/a.com
+	Name       string                  // 文件名
+	Size       int64                   // 文件总大小
+	ChunkSize  int64                   // 分片大小 默认 DefaultUploadChunkSize
+	Offset     int64                   // 面板已确认接收的字节数
+	OnProgress func(sent, total int64) // 可选 每个分片确认后回调
+}
+
+// UploadChunked 按面板的分片协议（f_size/f_start）上传文件 r 为文件内容
+// 面板会返回其已接收的偏移量 因此即使 Offset 为 0 也会从面板保留的断点继续
+func (c *Client) UploadChunked(ctx context.Context, u *ChunkedUpload, r io.ReaderAt) error {
+	if u.Dir == "" || u.Name == "" {
+		return errors.New("upload dir and name are required")
+	}
+	if u.Offset < 0 || u.Offset > u.Size {
+		return errors.New("upload offset out of range")
+	}
+	size := u.ChunkSize
+	if size <= 0 {
+		size = DefaultUploadChunkSize
+	}
+	buf := make([]byte, size)
+	stalled := 0
+	for {
+		n := u.Size - u.Offset
+		if n > size {
+			n = size
+		}
+		read, err := r.ReadAt(buf[:n], u.Offset)
+		if int64(read) != n {
+			if err == nil {
+				err = io.ErrUnexpectedEOF
+			}
+			return err
+		}
+		resp, err := c.uploadChunk(ctx, u, buf[:n])
+		if err != nil {
+			return err
+		}
+		// 未完成时面板返回下一个分片的偏移量 完成后返回 JSON 消息
+		var next int64
+		if DecodeBare(resp, &next) == nil {
+			if next < 0 || next > u.Size {
+				return errors.New("panel returned invalid upload offset " + strconv.FormatInt(next, 10))
+			}
+			if next == u.Offset {
+				if stalled++; stalled > 3 {
+					return errors.New("upload stalled at offset " + strconv.FormatInt(next, 10))
+				}
+			} else {
+				stalled = 0
+			}
+			u.Offset = next
+			if u.OnProgress != nil {
+				u.OnProgress(u.Offset, u.Size)
+			}
+			continue
+		}
+		if _, err := c.decodeResult(resp, "/files?action=upload"); err != nil {
+			return err
+		}
+		u.Offset = u.Size
+		if u.OnProgress != nil {
+			u.OnProgress(u.Offset, u.Size)
+		}
+		return nil
+	}
+}
+
+func (c *Client) uploadChunk(ctx context.Context, u *ChunkedUpload, blob []byte) ([]byte, error) {
+	data := map[string][]string{
+		"f_path":  {u.Dir},
+		"f_name":  {u.Name},
+		"f_size":  {strconv.FormatInt(u.Size, 10)},
+		"f_start": {strconv.FormatInt(u.Offset, 10)},
+	}
+	return c.call(ctx, data, "/files?action=upload", func(info *RequestInfo, timeout time.Duration) (*http.Response, error) {
+		var body bytes.Buffer
+		w := multipart.NewWriter(&body)
+		info.form, info.multipart = c.signedForm(info.Params), true
+		for k, vs := range info.form {
+			for _, v := range vs {
+				if err := w.WriteField(k, v); err != nil {
+					return nil, err
+				}
+			}
+		}
+		part, err := w.CreateFormFile("blob", u.Name)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := part.Write(blob); err != nil {
+			return nil, err
+		}
+		if err := w.Close(); err != nil {
+			return nil, err
+		}
+		return c.post(info, timeout, w.FormDataContentType(), &body)
+	})
+}
+
+// UploadFile 将 r 的内容上传到服务器的 remotePath（绝对路径） 已存在的文件会被覆盖
+// r 实现了 io.ReaderAt 且能获取大小时（eg. *os.File *bytes.Reader *strings.Reader）直接分片上传
+// 否则先写入本地临时文件 内容不经过内存
+func (c *Client) UploadFile(ctx context.Context, remotePath string, r io.Reader) error {
+	dir, name := path.Split(remotePath)
+	if !path.IsAbs(remotePath) || name == "" {
+		return errors.New("invalid remote path: " + remotePath)
+	}
+	ra, size, cleanup, err := spool(r)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	return c.UploadChunked(ctx, &ChunkedUpload{Dir: path.Clean(dir), Name: name, Size: size}, ra)
+}
+
+// spool 返回可随机读取的 r 及其大小 无法直接获取时写入临时文件 cleanup 用于删除临时文件
+func spool(r io.Reader) (io.ReaderAt, int64, func(), error) {
+	switch v := r.(type) {
+	case *os.File:
+		if fi, err := v.Stat(); err == nil && fi.Mode().IsRegular() {
+			return v, fi.Size(), func() {}, nil
+		}
+	case interface {
+		io.ReaderAt
+		Size() int64
+	}:
+		return v, v.Size(), func() {}, nil
+	}
+	tmp, err := os.CreateTemp("", "btsdk-upload-*")
+	if err != nil {
+		return nil, 0, nil, err
+	}
+	cleanup := func() {
+		tmp.Close()
+		os.Remove(tmp.Name())
+	}
+	size, err := io.Copy(tmp, r)
+	if err != nil {
+		cleanup()
+		return nil, 0, nil, err
+	}
+	return tmp, size, cleanup, nil
+}
@@ -0,0 +1,100 @@
+package bt
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestUploadChunked(t *testing.T) {
+	content := strings.Repeat("0123456789", 25)
+	var received []byte
+	fail := true
+	c := newFakePanel(t, map[string]http.HandlerFunc{
+		"/files?action=upload": func(w http.ResponseWriter, r *http.Request) {
+			if r.FormValue("request_token") == "" || r.FormValue("f_name") != "a.zip" || r.FormValue("f_size") != "250" {
+				t.Errorf("unexpected form %v", r.MultipartForm)
+			}
+			start, _ := strconv.Atoi(r.FormValue("f_start"))
+			if start != len(received) {
+				// 与面板保存的进度不一致时返回实际进度
+				_, _ = w.Write([]byte(strconv.Itoa(len(received))))
+				return
+			}
+			f, _, err := r.FormFile("blob")
+			if err != nil {
+				t.Fatal(err)
+			}
+			b, _ := io.ReadAll(f)
+			received = append(received, b...)
+			if len(received) == 100 && fail {
+				fail = false
+				http.Error(w, "bad gateway", http.StatusBadGateway)
+				return
+			}
+			if len(received) < 250 {
+				_, _ = w.Write([]byte(strconv.Itoa(len(received))))
+				return
+			}
+			_, _ = w.Write([]byte(`{"status":true,"msg":"上传成功"}`))
+		},
+	})
+	u := &ChunkedUpload{Dir: "/www/backup", Name: "a.zip", Size: 250, ChunkSize: 50}
+	if err := c.UploadChunked(ctx, u, strings.NewReader(content)); err == nil || u.Offset != 50 {
+		t.Fatalf("expected interruption, offset %d err %v", u.Offset, err)
+	}
+	// 续传时面板已收到 100 字节 会纠正偏移量
+	var progress []int64
+	u.OnProgress = func(sent, total int64) { progress = append(progress, sent) }
+	if err := c.UploadChunked(ctx, u, strings.NewReader(content)); err != nil {
+		t.Fatal(err)
+	}
+	if string(received) != content || u.Offset != 250 || progress[0] != 100 {
+		t.Fatalf("received %d bytes progress %v", len(received), progress)
+	}
+}
+
+func TestUploadFile(t *testing.T) {
+	files := map[string][]byte{}
+	c := newFakePanel(t, map[string]http.HandlerFunc{
+		"/files?action=upload": func(w http.ResponseWriter, r *http.Request) {
+			key := r.FormValue("f_path") + "/" + r.FormValue("f_name")
+			f, _, err := r.FormFile("blob")
+			if err != nil {
+				t.Fatal(err)
+			}
+			b, _ := io.ReadAll(f)
+			files[key] = append(files[key], b...)
+			size, _ := strconv.Atoi(r.FormValue("f_size"))
+			if len(files[key]) < size {
+				_, _ = w.Write([]byte(strconv.Itoa(len(files[key]))))
+				return
+			}
+			_, _ = w.Write([]byte(`{"status":true,"msg":"上传成功"}`))
+		},
+		"/download": func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write(files[r.FormValue("filename")])
+		},
+	})
+	content := strings.Repeat("x", DefaultUploadChunkSize+10)
+	// io.MultiReader 无法随机读取 会先写入临时文件
+	if err := c.UploadFile(ctx, "/www/backup/a.zip", io.MultiReader(strings.NewReader(content))); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.UploadFile(ctx, "/www/backup/b.txt", strings.NewReader("hello")); err != nil {
+		t.Fatal(err)
+	}
+	if string(files["/www/backup/a.zip"]) != content {
+		t.Fatalf("a.zip has %d bytes", len(files["/www/backup/a.zip"]))
+	}
+	var buf bytes.Buffer
+	if n, err := c.DownloadFile(ctx, "/www/backup/b.txt", &buf); err != nil || n != 5 || buf.String() != "hello" {
+		t.Fatalf("DownloadFile = %d, %v, %q", n, err, buf.String())
+	}
+	if err := c.UploadFile(ctx, "relative/a.zip", strings.NewReader("")); err == nil {
+		t.Error("UploadFile accepted a relative path")
+	}
+}
@@ -0,0 +1,112 @@
+package bt
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// Version 面板版本号 eg. 7.9.10 比较时缺少的段视为 0
+type Version struct {
+	Segments []int
+	Raw      string
+}
+
+// ParseVersion 解析版本号 忽略开头的 v 以及各段数字后的后缀（如 8.0.1-beta）
+func ParseVersion(s string) (Version, error) {
+	raw := s
+	s = strings.TrimPrefix(strings.TrimSpace(s), "v")
+	if s == "" {
+		return Version{}, errors.New("empty version")
+	}
+	var segs []int
+	for _, part := range strings.Split(s, ".") {
+		end := 0
+		for end < len(part) && part[end] >= '0' && part[end] <= '9' {
+			end++
+		}
+		if end == 0 {
+			return Version{}, errors.New("invalid version: " + raw)
+		}
+		n, err := strconv.Atoi(part[:end])
+		if err != nil {
+			return Version{}, err
+		}
+		segs = append(segs, n)
+		if end < len(part) {
+			break
+		}
+	}
+	return Version{Segments: segs, Raw: raw}, nil
+}
+
+// Compare 比较版本 v 小于、等于、大于 o 时分别返回 -1、0、1
+func (v Version) Compare(o Version) int {
+	n := len(v.Segments)
+	if len(o.Segments) > n {
+		n = len(o.Segments)
+	}
+	for i := 0; i < n; i++ {
+		a, b := 0, 0
+		if i < len(v.Segments) {
+			a = v.Segments[i]
+		}
+		if i < len(o.Segments) {
+			b = o.Segments[i]
+		}
+		if a != b {
+			if a < b {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+// IsAtLeast 判断版本不低于 min min 无法解析时返回 false
+func (v Version) IsAtLeast(min string) bool {
+	m, err := ParseVersion(min)
+	if err != nil {
+		return false
+	}
+	return v.Compare(m) >= 0
+}
+
+// String 返回规范化的版本号
+func (v Version) String() string {
+	parts := make([]string, len(v.Segments))
+	for i, s := range v.Segments {
+		parts[i] = strconv.Itoa(s)
+	}
+	return strings.Join(parts, ".")
+}
+
+// UpdateAvailable 面板有可用更新
+func (u UpdateStatus) UpdateAvailable() bool {
+	if !u.Status || u.Version == "" {
+		return false
+	}
+	_, err := ParseVersion(u.Version)
+	return err == nil
+}
+
+// NewVersion 可更新到的版本
+func (u UpdateStatus) NewVersion() (Version, error) {
+	return ParseVersion(u.Version)
+}
+
+// PanelVersion 当前面板版本
+func (s SystemTotal) PanelVersion() (Version, error) {
+	return ParseVersion(s.Version)
+}
+
+// GetPanelVersion 获取当前面板版本
+func (c *Client) GetPanelVersion(ctx context.Context) (Version, error) {
+	total, err := c.GetSystemTotal(ctx)
+	if err != nil {
+		return Version{}, err
+	}
+	return total.PanelVersion()
+}
@@ -0,0 +1,44 @@
+package bt
+
+import "testing"
+
+func TestParseVersion(t *testing.T) {
+	cases := []struct {
+		a, b string
+		want int
+	}{
+		{"7.9.10", "7.9.9", 1},
+		{"7.9", "7.9.0", 0},
+		{"v8.0.1-beta", "8.0.1", 0},
+		{"6.9.8", "7.0", -1},
+	}
+	for _, c := range cases {
+		a, err := ParseVersion(c.a)
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, err := ParseVersion(c.b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := a.Compare(b); got != c.want {
+			t.Errorf("Compare(%s, %s) = %d", c.a, c.b, got)
+		}
+	}
+	if _, err := ParseVersion("latest"); err == nil {
+		t.Error("expected error")
+	}
+	v, _ := ParseVersion("7.9.3")
+	if !v.IsAtLeast("7.9") || v.IsAtLeast("8") || v.String() != "7.9.3" {
+		t.Errorf("unexpected %v", v)
+	}
+}
+
+func TestUpdateStatus_UpdateAvailable(t *testing.T) {
+	if !(UpdateStatus{Status: true, Version: "8.0.1"}).UpdateAvailable() {
+		t.Error("expected update")
+	}
+	if (UpdateStatus{Status: false, UpdateMsg: "当前已是最新版本"}).UpdateAvailable() {
+		t.Error("unexpected update")
+	}
+}
@@ -0,0 +1,101 @@
+package bt
+
+import (
+	"context"
+	"errors"
+	"strings"
+)
+
+// NginxVhostPath 网站 nginx 配置文件路径
+func NginxVhostPath(siteName string) string {
+	return "/www/server/panel/vhost/nginx/" + siteName + ".conf"
+}
+
+// ApacheVhostPath 网站 apache 配置文件路径
+func ApacheVhostPath(siteName string) string {
+	return "/www/server/panel/vhost/apache/" + siteName + ".conf"
+}
+
+// OpenLiteSpeedVhostPath 网站 openlitespeed 配置文件路径
+func OpenLiteSpeedVhostPath(siteName string) string {
+	return "/www/server/panel/vhost/openlitespeed/" + siteName + ".conf"
+}
+
+// SiteConfPath 按面板当前的 Web 服务器返回网站配置文件路径 Web 服务器由 Capabilities 探测
+func (c *Client) SiteConfPath(ctx context.Context, siteName string) (string, error) {
+	caps, err := c.Capabilities(ctx)
+	if err != nil {
+		return "", err
+	}
+	switch caps.WebServer {
+	case "nginx":
+		return NginxVhostPath(siteName), nil
+	case "apache":
+		return ApacheVhostPath(siteName), nil
+	case "openlitespeed":
+		return OpenLiteSpeedVhostPath(siteName), nil
+	}
+	return "", errors.New("no web server installed")
+}
+
+// GetSiteConf 获取网站的 Web 服务器配置文件内容
+func (c *Client) GetSiteConf(ctx context.Context, siteName string) (string, error) {
+	path, err := c.SiteConfPath(ctx, siteName)
+	if err != nil {
+		return "", err
+	}
+	return c.readFile(ctx, path)
+}
+
+// SaveSiteConf 保存网站的 Web 服务器配置文件 面板会检测配置 检测失败时不保存并返回错误
+func (c *Client) SaveSiteConf(ctx context.Context, siteName string, body string) (RespMSG, error) {
+	path, err := c.SiteConfPath(ctx, siteName)
+	if err != nil {
+		return RespMSG{}, err
+	}
+	return c.SetFile(ctx, path, body)
+}
+
+// setManagedBlock 在 nginx server 块中写入由 SDK 管理的配置段 以 #BTSDK-<name>-START/END 标记
+// block 为空时删除该段 已存在时整体替换 否则插入到 #ERROR-PAGE-START 前（不存在时插入到最后一个 } 前）
+func setManagedBlock(conf string, name string, block string) string {
+	start, end := "#BTSDK-"+name+"-START", "#BTSDK-"+name+"-END"
+	if i := strings.Index(conf, start); i >= 0 {
+		if j := strings.Index(conf[i:], end); j >= 0 {
+			lineStart := strings.LastIndex(conf[:i], "\n") + 1
+			stop := i + j + len(end)
+			if stop < len(conf) && conf[stop] == '\n' {
+				stop++
+			}
+			conf = conf[:lineStart] + conf[stop:]
+		}
+	}
+	if block == "" {
+		return conf
+	}
+	var sb strings.Builder
+	sb.WriteString("    " + start + "\n")
+	for _, line := range strings.Split(strings.TrimRight(block, "\n"), "\n") {
+		sb.WriteString("    " + strings.TrimSpace(line) + "\n")
+	}
+	sb.WriteString("    " + end + "\n")
+	at := strings.Index(conf, "#ERROR-PAGE-START")
+	if at >= 0 {
+		at = strings.LastIndex(conf[:at], "\n") + 1
+	} else {
+		at = strings.LastIndex(conf, "}")
+		if at < 0 {
+			at = len(conf)
+		} else {
+			at = strings.LastIndex(conf[:at], "\n") + 1
+		}
+	}
+	return conf[:at] + sb.String() + conf[at:]
+}
+
+// editManagedBlock 修改网站 nginx 配置中的 SDK 管理段 面板保存配置文件后会自动重载 nginx
+func (c *Client) editManagedBlock(ctx context.Context, siteName string, name string, block string) (string, error) {
+	return c.SafeEditConfig(ctx, NginxVhostPath(siteName), func(conf string) (string, error) {
+		return setManagedBlock(conf, name, block), nil
+	}, nil)
+}
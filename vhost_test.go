@@ -0,0 +1,82 @@
+package bt
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+)
+
+const testVhost = `server
+{
+    listen 80;
+    server_name w1.hao.com;
+    #ERROR-PAGE-START  错误页配置
+    #error_page 404 /404.html;
+    #ERROR-PAGE-END
+}
+`
+
+func TestSetManagedBlock(t *testing.T) {
+	conf := setManagedBlock(testVhost, "TEST", "gzip on;\ngzip_vary on;")
+	want := `server
+{
+    listen 80;
+    server_name w1.hao.com;
+    #BTSDK-TEST-START
+    gzip on;
+    gzip_vary on;
+    #BTSDK-TEST-END
+    #ERROR-PAGE-START  错误页配置
+    #error_page 404 /404.html;
+    #ERROR-PAGE-END
+}
+`
+	if conf != want {
+		t.Fatalf("insert:\n%s", conf)
+	}
+	replaced := setManagedBlock(conf, "TEST", "gzip off;")
+	if setManagedBlock(replaced, "TEST", "gzip on;\ngzip_vary on;") != want {
+		t.Fatalf("replace:\n%s", replaced)
+	}
+	if removed := setManagedBlock(conf, "TEST", ""); removed != testVhost {
+		t.Fatalf("remove:\n%s", removed)
+	}
+	if got := setManagedBlock("server {\n    listen 80;\n}\n", "TEST", "a;"); got != "server {\n    listen 80;\n    #BTSDK-TEST-START\n    a;\n    #BTSDK-TEST-END\n}\n" {
+		t.Fatalf("no error page marker:\n%s", got)
+	}
+}
+
+func TestCompression_Block(t *testing.T) {
+	if _, err := (Compression{Gzip: true, Level: 10}).block(); err == nil {
+		t.Fatal("expected level error")
+	}
+	b, err := Compression{Gzip: true, Brotli: true, Types: []string{"text/css"}}.block()
+	want := "gzip on;\ngzip_comp_level 6;\ngzip_min_length 1k;\ngzip_vary on;\ngzip_types text/css;\nbrotli on;\nbrotli_comp_level 4;\nbrotli_types text/css;"
+	if err != nil || b != want {
+		t.Fatalf("block %q %v", b, err)
+	}
+}
+
+func TestSiteConf(t *testing.T) {
+	var saved string
+	c := newFakePanel(t, map[string]http.HandlerFunc{
+		"/plugin?action=get_soft_list": reply(`{"list":{"data":[{"name":"apache","setup":true,"status":true}]}}`),
+		"/files?action=GetFileBody": func(w http.ResponseWriter, r *http.Request) {
+			if r.FormValue("path") != "/www/server/panel/vhost/apache/w1.hao.com.conf" {
+				t.Errorf("unexpected path %s", r.FormValue("path"))
+			}
+			_, _ = w.Write([]byte(`{"status":true,"data":"<VirtualHost *:80>\n</VirtualHost>\n"}`))
+		},
+		"/files?action=SaveFileBody": func(w http.ResponseWriter, r *http.Request) {
+			saved = r.FormValue("path")
+			_, _ = w.Write([]byte(`{"status":true,"msg":"文件已保存!"}`))
+		},
+	})
+	conf, err := c.GetSiteConf(ctx, "w1.hao.com")
+	if err != nil || !strings.HasPrefix(conf, "<VirtualHost") {
+		t.Fatalf("GetSiteConf = %q, %v", conf, err)
+	}
+	if _, err := c.SaveSiteConf(ctx, "w1.hao.com", conf); err != nil || saved != ApacheVhostPath("w1.hao.com") {
+		t.Fatalf("SaveSiteConf saved %q, %v", saved, err)
+	}
+}
@@ -0,0 +1,42 @@
+package bt
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestSetSiteCC(t *testing.T) {
+	var calls []string
+	c := newFakePanel(t, map[string]http.HandlerFunc{
+		"/plugin?action=a": func(w http.ResponseWriter, r *http.Request) {
+			s := r.URL.Query().Get("s")
+			calls = append(calls, s)
+			switch s {
+			case "get_site_config_byname":
+				_, _ = w.Write([]byte(`{"cc":{"open":false,"cycle":60,"limit":120,"endtime":300,"increase":false}}`))
+			case "set_site_cc_conf":
+				if r.FormValue("cycle") != "10" || r.FormValue("limit") != "30" || r.FormValue("endtime") != "3600" || r.FormValue("increase") != "1" {
+					t.Errorf("unexpected form %v", r.Form)
+				}
+				_, _ = w.Write([]byte(`{"status":true,"msg":"设置成功"}`))
+			case "set_site_obj_open":
+				_, _ = w.Write([]byte(`{"status":true,"msg":"设置成功"}`))
+			}
+		},
+	})
+	cur, err := c.GetSiteCC(ctx, "a.com")
+	if err != nil || cur.Limit != 120 || cur.BanTime != 300 {
+		t.Fatalf("GetSiteCC = %+v, %v", cur, err)
+	}
+	calls = nil
+	r, err := c.SetSiteCC(ctx, "a.com", CCProtection{Enabled: true, Cycle: 10, Limit: 30, BanTime: 3600, Enhanced: true})
+	if err != nil || !r.Status {
+		t.Fatalf("SetSiteCC = %+v, %v", r, err)
+	}
+	if len(calls) != 3 || calls[2] != "set_site_obj_open" {
+		t.Fatalf("calls %v", calls)
+	}
+	if _, err := c.SetSiteCC(ctx, "a.com", CCProtection{}); err == nil {
+		t.Fatal("expected error")
+	}
+}
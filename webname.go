@@ -0,0 +1,78 @@
+package bt
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// WebName 创建网站时的域名参数 Domain 为主域名 DomainList 为附加域名
+// 域名可带端口 eg. www.example.com:8080 建议使用 NewWebName 构造
+type WebName struct {
+	Domain     string   `json:"domain"`     // 必填
+	DomainList []string `json:"domainlist"` // 必填 无附加域名时为空切片
+	Count      int      `json:"count"`      // 附加域名数量
+}
+
+// NewWebName 以主域名和附加域名构造 WebName 会去除空白和重复的附加域名
+func NewWebName(primary string, extras ...string) WebName {
+	w := WebName{
+		Domain:     strings.TrimSpace(primary),
+		DomainList: []string{},
+	}
+	seen := map[string]bool{strings.ToLower(w.Domain): true}
+	for _, d := range extras {
+		d = strings.TrimSpace(d)
+		if d == "" || seen[strings.ToLower(d)] {
+			continue
+		}
+		seen[strings.ToLower(d)] = true
+		w.DomainList = append(w.DomainList, d)
+	}
+	w.Count = len(w.DomainList)
+	return w
+}
+
+// Validate 检查域名格式 主域名不能为空 不能带协议或路径 端口需在 1-65535 之间
+func (w WebName) Validate() error {
+	if w.Domain == "" {
+		return errors.New("webname: primary domain is required")
+	}
+	for _, d := range append([]string{w.Domain}, w.DomainList...) {
+		if err := validateDomain(d); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// MarshalJSON 保证 domainlist 不为 null 且 count 与附加域名数量一致
+func (w WebName) MarshalJSON() ([]byte, error) {
+	type plain WebName
+	if w.DomainList == nil {
+		w.DomainList = []string{}
+	}
+	w.Count = len(w.DomainList)
+	return json.Marshal(plain(w))
+}
+
+func validateDomain(d string) error {
+	if strings.Contains(d, "://") || strings.ContainsAny(d, "/ \t\r\n,") {
+		return errors.New("webname: invalid domain " + strconv.Quote(d))
+	}
+	host := d
+	if i := strings.LastIndex(d, ":"); i >= 0 {
+		host = d[:i]
+		port, err := strconv.Atoi(d[i+1:])
+		if err != nil || port < 1 || port > 65535 {
+			return errors.New("webname: invalid port in " + strconv.Quote(d))
+		}
+	}
+	host = strings.TrimPrefix(host, "*.")
+	for _, label := range strings.Split(host, ".") {
+		if label == "" || strings.HasPrefix(label, "-") || strings.HasSuffix(label, "-") {
+			return errors.New("webname: invalid domain " + strconv.Quote(d))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,30 @@
+package bt
+
+import "testing"
+
+func TestNewWebName(t *testing.T) {
+	w := NewWebName(" example.com ", "www.example.com", "", "EXAMPLE.com", "www.example.com", "m.example.com:8080")
+	if w.Domain != "example.com" || len(w.DomainList) != 2 || w.Count != 2 {
+		t.Fatalf("unexpected %+v", w)
+	}
+	if err := w.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	b, err := json.Marshal(WebName{Domain: "a.com"})
+	if err != nil || string(b) != `{"domain":"a.com","domainlist":[],"count":0}` {
+		t.Fatalf("marshal = %s, %v", b, err)
+	}
+}
+
+func TestWebName_Validate(t *testing.T) {
+	for _, d := range []string{"", "http://a.com", "a.com/path", "a..com", "a.com:0", "a.com:http", "-a.com"} {
+		if err := NewWebName(d).Validate(); err == nil {
+			t.Errorf("Validate(%q) expected error", d)
+		}
+	}
+	for _, d := range []string{"*.a.com", "a.com:8080", "中文.com"} {
+		if err := NewWebName(d).Validate(); err != nil {
+			t.Errorf("Validate(%q) = %v", d, err)
+		}
+	}
+}